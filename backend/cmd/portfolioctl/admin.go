@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/portfolio/backend/internal/auth"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository/postgres"
+)
+
+func adminCreate(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("admin create")
+	email := fs.String("email", "", "admin email (required)")
+	name := fs.String("name", "Admin", "display name")
+	role := fs.String("role", auth.RoleOwner, "owner, editor, moderator or viewer")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if strings.TrimSpace(*email) == "" {
+		return errors.New("-email is required")
+	}
+	if !auth.ValidRole(*role) {
+		return fmt.Errorf("invalid role %q", *role)
+	}
+
+	hash, err := readPasswordHash()
+	if err != nil {
+		return err
+	}
+	created, err := a.repo.CreateAdmin(ctx, model.Admin{
+		Email:        strings.TrimSpace(*email),
+		PasswordHash: hash,
+		Name:         strings.TrimSpace(*name),
+		Role:         *role,
+	})
+	if err != nil {
+		if errors.Is(err, postgres.ErrAdminExists) {
+			return fmt.Errorf("an admin with email %s already exists", *email)
+		}
+		return err
+	}
+
+	fmt.Printf("Created %s admin %s\n", created.Role, created.Email)
+	return nil
+}
+
+func adminResetPassword(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("admin reset-password")
+	email := fs.String("email", "", "admin email (required)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if strings.TrimSpace(*email) == "" {
+		return errors.New("-email is required")
+	}
+
+	admin, err := a.repo.GetAdminByEmail(ctx, strings.TrimSpace(*email))
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return fmt.Errorf("no admin with email %s", *email)
+		}
+		return err
+	}
+	hash, err := readPasswordHash()
+	if err != nil {
+		return err
+	}
+	if err := a.repo.UpdateAdminPassword(ctx, admin.ID, hash); err != nil {
+		return err
+	}
+	// Sessions signed in with the old password must not outlive it.
+	if err := a.repo.RevokeAdminSessions(ctx, admin.ID); err != nil {
+		return fmt.Errorf("password changed but signing out existing sessions failed: %w", err)
+	}
+
+	fmt.Printf("Reset the password of %s and signed them out everywhere\n", admin.Email)
+	return nil
+}
+
+// readPasswordHash reads a password from the first line of stdin, so it stays
+// out of the shell history and process list, and hashes it.
+func readPasswordHash() (string, error) {
+	if info, err := os.Stdin.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		fmt.Fprint(os.Stderr, "Password: ")
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", errors.New("no password on stdin")
+	}
+	return auth.HashPassword(strings.TrimRight(line, "\r\n"))
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// auditActor is the actor recorded in the audit log for changes made with
+// portfolioctl.
+const auditActor = "portfolioctl"
+
+// snapshot returns the stored row for an audit entry's before snapshot.
+func snapshot(ctx context.Context, repo repository.AuditStore, entityType, id string) json.RawMessage {
+	s, err := repo.SnapshotEntity(ctx, entityType, id)
+	if err != nil {
+		log.Printf("Failed to snapshot %s %s for audit log: %v", entityType, id, err)
+		return nil
+	}
+	return s
+}
+
+// recordAudit writes an audit row for a change that has already succeeded,
+// like the server does for changes made through the API.
+func recordAudit(ctx context.Context, repo repository.AuditStore, action, entityType, id string, before json.RawMessage) {
+	var after json.RawMessage
+	if action != model.AuditActionDelete {
+		after = snapshot(ctx, repo, entityType, id)
+	}
+
+	entry := model.AuditEntry{
+		ActorEmail: auditActor,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   id,
+		Before:     before,
+		After:      after,
+	}
+	if err := repo.CreateAuditEntry(ctx, entry); err != nil {
+		log.Printf("Failed to write audit log entry (%s %s %s): %v", action, entityType, id, err)
+	}
+}
@@ -0,0 +1,375 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+	"github.com/portfolio/backend/internal/repository/postgres"
+)
+
+// contentDocument is the file written by 'content export' and read by
+// 'content import'. Items keep their translations, publication state and
+// sort order; ids, versions and timestamps are assigned on import.
+type contentDocument struct {
+	ExportedAt  time.Time          `json:"exportedAt"`
+	Skills      []model.Skill      `json:"skills"`
+	Projects    []model.Project    `json:"projects"`
+	Experience  []model.Experience `json:"experience"`
+	Education   []model.Education  `json:"education"`
+	Hobbies     []model.Hobby      `json:"hobbies"`
+	ContactInfo *model.ContactInfo `json:"contactInfo,omitempty"`
+}
+
+func contentExport(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("content export")
+	output := fs.String("o", "-", "file to write, or - for stdout")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	doc := contentDocument{ExportedAt: time.Now().UTC()}
+	var err error
+	if doc.Skills, err = a.repo.GetAllSkills(ctx); err != nil {
+		return err
+	}
+	if doc.Projects, err = a.repo.GetAllProjects(ctx); err != nil {
+		return err
+	}
+	if doc.Experience, err = a.repo.GetAllExperiences(ctx); err != nil {
+		return err
+	}
+	if doc.Education, err = a.repo.GetAllEducation(ctx); err != nil {
+		return err
+	}
+	if doc.Hobbies, err = a.repo.GetAllHobbies(ctx); err != nil {
+		return err
+	}
+	info, err := a.repo.GetContactInfo(ctx)
+	if err != nil {
+		return err
+	}
+	if info.ID != "" {
+		doc.ContactInfo = &info
+	}
+
+	w := io.Writer(os.Stdout)
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d skills, %d projects, %d experience, %d education and %d hobbies\n",
+		len(doc.Skills), len(doc.Projects), len(doc.Experience), len(doc.Education), len(doc.Hobbies))
+	return nil
+}
+
+func contentImport(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("content import")
+	replace := fs.Bool("replace", false, "move the existing content to the trash first")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("expected one FILE argument (- for stdin)")
+	}
+
+	r := io.Reader(os.Stdin)
+	if path := fs.Arg(0); path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	var doc contentDocument
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		return fmt.Errorf("invalid content file: %w", err)
+	}
+
+	if err := validateContent(doc); err != nil {
+		return fmt.Errorf("invalid content file: %w", err)
+	}
+
+	// Trashing and importing run in one transaction, so a failure part way
+	// leaves the content as it was.
+	trashed := 0
+	err := a.repo.WithTx(ctx, func(tx *postgres.Repository) error {
+		if *replace {
+			var err error
+			if trashed, err = trashContent(ctx, tx); err != nil {
+				return err
+			}
+		}
+		return importContent(ctx, tx, doc)
+	})
+	if err != nil {
+		return fmt.Errorf("%w; nothing was changed", err)
+	}
+
+	if *replace {
+		fmt.Printf("Moved %d existing items to the trash\n", trashed)
+	}
+
+	fmt.Printf("Imported %d skills, %d projects, %d experience, %d education and %d hobbies\n",
+		len(doc.Skills), len(doc.Projects), len(doc.Experience), len(doc.Education), len(doc.Hobbies))
+	return nil
+}
+
+// validateContent applies the checks the admin API makes on create to every
+// item, so a bad file is rejected before anything is trashed or imported.
+func validateContent(doc contentDocument) error {
+	for i, s := range doc.Skills {
+		if err := validateItem(s.Publication, required("name", s.Name)); err != nil {
+			return fmt.Errorf("skill %d: %w", i+1, err)
+		}
+		if s.Proficiency < 0 || s.Proficiency > 100 {
+			return fmt.Errorf("skill %d: %w", i+1, &model.FieldError{Field: "proficiency", Code: "range", Message: "must be between 0 and 100"})
+		}
+	}
+	for i, p := range doc.Projects {
+		if err := validateItem(p.Publication, required("title", p.Title), required("description", p.Description)); err != nil {
+			return fmt.Errorf("project %d: %w", i+1, err)
+		}
+	}
+	for i, e := range doc.Experience {
+		if err := validateItem(e.Publication, required("title", e.Title), required("company", e.Company), model.ValidateDateRange(e.StartDate, e.EndDate, e.Current)); err != nil {
+			return fmt.Errorf("experience %d: %w", i+1, err)
+		}
+	}
+	for i, e := range doc.Education {
+		if err := validateItem(e.Publication, required("degree", e.Degree), required("school", e.School), model.ValidateDateRange(e.StartDate, e.EndDate, false)); err != nil {
+			return fmt.Errorf("education %d: %w", i+1, err)
+		}
+	}
+	for i, h := range doc.Hobbies {
+		if err := validateItem(h.Publication, required("name", h.Name)); err != nil {
+			return fmt.Errorf("hobby %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// validateItem returns the first of errs, then checks the publication state.
+func validateItem(publication model.Publication, errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	switch publication.Status {
+	case "", model.StatusDraft, model.StatusPublished:
+	case model.StatusScheduled:
+		if publication.PublishAt == nil {
+			return &model.FieldError{Field: "publishAt", Code: "required", Message: "is required when status is scheduled"}
+		}
+	default:
+		return &model.FieldError{Field: "status", Code: "oneof", Message: "must be one of draft, published, scheduled"}
+	}
+	return nil
+}
+
+func required(field, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return &model.FieldError{Field: field, Code: "required", Message: "is required"}
+	}
+	return nil
+}
+
+// importContent creates the items of doc. Translations the file flags as
+// needing review are stored as machine translations again.
+func importContent(ctx context.Context, repo repository.Store, doc contentDocument) error {
+	for _, s := range doc.Skills {
+		s.Status = importStatus(s.Status)
+		created, err := repo.CreateSkill(ctx, s)
+		if err != nil {
+			return fmt.Errorf("skill %q: %w", s.Name, err)
+		}
+		recordAudit(ctx, repo, model.AuditActionCreate, model.AuditEntitySkill, created.ID, nil)
+	}
+	for _, p := range doc.Projects {
+		p.Status = importStatus(p.Status)
+		var machine model.Translations
+		p.Translations, machine = splitMachineTranslations(p.Translations, p.NeedsReview)
+		created, err := repo.CreateProject(ctx, p)
+		if err != nil {
+			return fmt.Errorf("project %q: %w", p.Title, err)
+		}
+		if machine != nil {
+			if err := repo.AddMachineTranslations(ctx, model.AuditEntityProject, created.ID, created.Version, machine); err != nil {
+				return fmt.Errorf("project %q: %w", p.Title, err)
+			}
+		}
+		recordAudit(ctx, repo, model.AuditActionCreate, model.AuditEntityProject, created.ID, nil)
+	}
+	for _, e := range doc.Experience {
+		e.Status = importStatus(e.Status)
+		var machine model.Translations
+		e.Translations, machine = splitMachineTranslations(e.Translations, e.NeedsReview)
+		created, err := repo.CreateExperience(ctx, e)
+		if err != nil {
+			return fmt.Errorf("experience %q: %w", e.Title, err)
+		}
+		if machine != nil {
+			if err := repo.AddMachineTranslations(ctx, model.AuditEntityExperience, created.ID, created.Version, machine); err != nil {
+				return fmt.Errorf("experience %q: %w", e.Title, err)
+			}
+		}
+		recordAudit(ctx, repo, model.AuditActionCreate, model.AuditEntityExperience, created.ID, nil)
+	}
+	for _, e := range doc.Education {
+		e.Status = importStatus(e.Status)
+		var machine model.Translations
+		e.Translations, machine = splitMachineTranslations(e.Translations, e.NeedsReview)
+		created, err := repo.CreateEducation(ctx, e)
+		if err != nil {
+			return fmt.Errorf("education %q: %w", e.Degree, err)
+		}
+		if machine != nil {
+			if err := repo.AddMachineTranslations(ctx, model.AuditEntityEducation, created.ID, created.Version, machine); err != nil {
+				return fmt.Errorf("education %q: %w", e.Degree, err)
+			}
+		}
+		recordAudit(ctx, repo, model.AuditActionCreate, model.AuditEntityEducation, created.ID, nil)
+	}
+	for _, h := range doc.Hobbies {
+		h.Status = importStatus(h.Status)
+		created, err := repo.CreateHobby(ctx, h)
+		if err != nil {
+			return fmt.Errorf("hobby %q: %w", h.Name, err)
+		}
+		recordAudit(ctx, repo, model.AuditActionCreate, model.AuditEntityHobby, created.ID, nil)
+	}
+	if doc.ContactInfo != nil {
+		existing, err := repo.GetContactInfo(ctx)
+		if err != nil {
+			return fmt.Errorf("contact info: %w", err)
+		}
+		before := snapshot(ctx, repo, model.AuditEntityContactInfo, existing.ID)
+		info := *doc.ContactInfo
+		info.Version = existing.Version
+		updated, err := repo.UpdateContactInfo(ctx, info)
+		if err != nil {
+			return fmt.Errorf("contact info: %w", err)
+		}
+		recordAudit(ctx, repo, model.AuditActionUpdate, model.AuditEntityContactInfo, updated.ID, before)
+		if before != nil && repository.IsRevisioned(model.AuditEntityContactInfo) {
+			if err := repo.CreateRevision(ctx, model.AuditEntityContactInfo, updated.ID, before, nil); err != nil {
+				log.Printf("Failed to save revision of contact info: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// splitMachineTranslations separates the translations flagged for review
+// from those a person wrote or checked.
+func splitMachineTranslations(t model.Translations, needsReview model.ReviewFlags) (human, machine model.Translations) {
+	for locale, fields := range t {
+		for field, value := range fields {
+			if slices.Contains(needsReview[locale], field) {
+				if machine == nil {
+					machine = model.Translations{}
+				}
+				machine.Set(locale, field, value)
+				continue
+			}
+			if human == nil {
+				human = model.Translations{}
+			}
+			human.Set(locale, field, value)
+		}
+	}
+	return human, machine
+}
+
+// importStatus publishes items whose file does not say otherwise.
+func importStatus(status string) string {
+	if status == "" {
+		return model.StatusPublished
+	}
+	return status
+}
+
+// trashContent soft-deletes every skill, project, experience, education
+// entry and hobby, so a replacing import can be undone from the trash.
+func trashContent(ctx context.Context, repo repository.ContentStore) (int, error) {
+	trashed := 0
+	trash := func(entityType, id string, del func() error) error {
+		before := snapshot(ctx, repo, entityType, id)
+		if err := del(); err != nil {
+			return fmt.Errorf("%s %s: %w", entityType, id, err)
+		}
+		recordAudit(ctx, repo, model.AuditActionDelete, entityType, id, before)
+		trashed++
+		return nil
+	}
+
+	skills, err := repo.GetAllSkills(ctx)
+	if err != nil {
+		return trashed, err
+	}
+	for _, s := range skills {
+		if err := trash(model.AuditEntitySkill, s.ID, func() error { return repo.DeleteSkill(ctx, s.ID, s.Version) }); err != nil {
+			return trashed, err
+		}
+	}
+	projects, err := repo.GetAllProjects(ctx)
+	if err != nil {
+		return trashed, err
+	}
+	for _, p := range projects {
+		if err := trash(model.AuditEntityProject, p.ID, func() error { return repo.DeleteProject(ctx, p.ID, p.Version) }); err != nil {
+			return trashed, err
+		}
+	}
+	experiences, err := repo.GetAllExperiences(ctx)
+	if err != nil {
+		return trashed, err
+	}
+	for _, e := range experiences {
+		if err := trash(model.AuditEntityExperience, e.ID, func() error { return repo.DeleteExperience(ctx, e.ID, e.Version) }); err != nil {
+			return trashed, err
+		}
+	}
+	education, err := repo.GetAllEducation(ctx)
+	if err != nil {
+		return trashed, err
+	}
+	for _, e := range education {
+		if err := trash(model.AuditEntityEducation, e.ID, func() error { return repo.DeleteEducation(ctx, e.ID, e.Version) }); err != nil {
+			return trashed, err
+		}
+	}
+	hobbies, err := repo.GetAllHobbies(ctx)
+	if err != nil {
+		return trashed, err
+	}
+	for _, h := range hobbies {
+		if err := trash(model.AuditEntityHobby, h.ID, func() error { return repo.DeleteHobby(ctx, h.ID, h.Version) }); err != nil {
+			return trashed, err
+		}
+	}
+	return trashed, nil
+}
@@ -0,0 +1,141 @@
+// Command portfolioctl runs administrative chores directly against the
+// portfolio database and uploads directory, without going through the API.
+//
+// Usage:
+//
+//	portfolioctl <command> <subcommand> [flags] [args]
+//
+// It reads the same environment (and .env file) as the server.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+	"github.com/portfolio/backend/internal/config"
+	"github.com/portfolio/backend/internal/repository/postgres"
+)
+
+// app is what subcommands work with.
+type app struct {
+	db   *pgxpool.Pool
+	repo *postgres.Repository
+}
+
+type subcommand struct {
+	args    string
+	summary string
+	run     func(ctx context.Context, a *app, args []string) error
+}
+
+var commands = map[string]map[string]subcommand{
+	"migrate": {
+		"up":     {"", "Apply pending migrations", migrateUp},
+		"down":   {"[-steps N]", "Revert the last N applied migrations (default 1)", migrateDown},
+		"status": {"", "List migrations and when they were applied", migrateStatus},
+	},
+	"admin": {
+		"create":         {"-email EMAIL [-name NAME] [-role ROLE]", "Create an admin; the password is read from stdin", adminCreate},
+		"reset-password": {"-email EMAIL", "Set an admin's password, read from stdin, and sign them out everywhere", adminResetPassword},
+	},
+	"content": {
+		"export": {"[-o FILE]", "Write all content as JSON to FILE or stdout", contentExport},
+		"import": {"[-replace] FILE", "Import content written by 'content export' (- reads stdin)", contentImport},
+	},
+	"messages": {
+		"purge": {"-older-than-days N", "Permanently delete messages received more than N days ago", messagesPurge},
+	},
+	"resume": {
+		"upload": {"-lang en|fr [-dir DIR] FILE", "Install a PDF as the resume served for a language", resumeUpload},
+	},
+}
+
+// errUsage is returned by subcommands whose arguments are wrong; the flag
+// package has already explained why.
+var errUsage = errors.New("usage")
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("portfolioctl: ")
+
+	if len(os.Args) < 3 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]][os.Args[2]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Failed to read .env: %v", err)
+	}
+	cfg := config.Load()
+
+	db, err := postgres.NewConnection(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	a := &app{db: db, repo: postgres.NewRepository(db)}
+	if err := cmd.run(ctx, a, os.Args[3:]); err != nil {
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+		log.Printf("%s %s: %v", os.Args[1], os.Args[2], err)
+		db.Close()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	var b strings.Builder
+	b.WriteString("Usage: portfolioctl <command> <subcommand> [flags] [args]\n\nCommands:\n")
+	for _, name := range sortedKeys(commands) {
+		for _, sub := range sortedKeys(commands[name]) {
+			cmd := commands[name][sub]
+			fmt.Fprintf(&b, "  %s\n      %s\n", strings.TrimSpace(name+" "+sub+" "+cmd.args), cmd.summary)
+		}
+	}
+	fmt.Fprint(os.Stderr, b.String())
+}
+
+// newFlagSet returns a flag set for a subcommand that reports errors
+// instead of exiting.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("portfolioctl "+name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// parseFlags parses args into fs, turning flag errors into errUsage.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
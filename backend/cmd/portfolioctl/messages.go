@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func messagesPurge(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("messages purge")
+	days := fs.Int("older-than-days", 0, "delete messages received more than this many days ago (required)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *days < 1 {
+		return errors.New("-older-than-days must be at least 1")
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -*days)
+	purged, err := a.repo.PurgeMessages(ctx, cutoff)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Deleted %d messages received before %s\n", purged, cutoff.Format("2006-01-02"))
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/portfolio/backend/internal/repository/postgres"
+)
+
+func migrateUp(ctx context.Context, a *app, args []string) error {
+	if err := parseFlags(newFlagSet("migrate up"), args); err != nil {
+		return err
+	}
+
+	applied, err := postgres.Migrate(ctx, a.db)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		fmt.Println("Database is up to date")
+	}
+	for _, m := range applied {
+		fmt.Printf("Applied %03d_%s\n", m.Version, m.Name)
+	}
+	return nil
+}
+
+func migrateDown(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("migrate down")
+	steps := fs.Int("steps", 1, "number of migrations to revert")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *steps < 1 {
+		return fmt.Errorf("-steps must be at least 1")
+	}
+
+	reverted, err := postgres.RollbackMigrations(ctx, a.db, *steps)
+	for _, m := range reverted {
+		fmt.Printf("Reverted %03d_%s\n", m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(reverted) == 0 {
+		fmt.Println("No migrations to revert")
+	}
+	return nil
+}
+
+func migrateStatus(ctx context.Context, a *app, args []string) error {
+	if err := parseFlags(newFlagSet("migrate status"), args); err != nil {
+		return err
+	}
+
+	migrations, err := postgres.MigrationStatus(ctx, a.db)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED\tNOTE")
+	for _, m := range migrations {
+		applied, note := "pending", ""
+		if m.AppliedAt != nil {
+			applied = m.AppliedAt.Local().Format("2006-01-02 15:04:05")
+		}
+		switch {
+		case m.Missing:
+			note = "not in this build"
+		case m.Modified:
+			note = "changed since applied"
+		}
+		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\n", m.Version, m.Name, applied, note)
+	}
+	return w.Flush()
+}
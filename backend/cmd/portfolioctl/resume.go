@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/portfolio/backend/internal/model"
+)
+
+// resumeUpload installs a PDF where the server serves resumes from, as
+// POST /api/admin/resume does.
+func resumeUpload(ctx context.Context, a *app, args []string) error {
+	fs := newFlagSet("resume upload")
+	lang := fs.String("lang", "", "resume language: en or fr (required)")
+	dir := fs.String("dir", "./uploads", "uploads directory of the server")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *lang != "en" && *lang != "fr" {
+		return errors.New("-lang must be en or fr")
+	}
+	if fs.NArg() != 1 {
+		return errors.New("expected one FILE argument")
+	}
+	source := fs.Arg(0)
+	if strings.ToLower(filepath.Ext(source)) != ".pdf" {
+		return errors.New("only PDF files are allowed")
+	}
+
+	if err := os.MkdirAll(*dir, 0755); err != nil {
+		return err
+	}
+	target := filepath.Join(*dir, "resume_"+*lang+".pdf")
+	if err := copyFile(source, target); err != nil {
+		return err
+	}
+	recordAudit(ctx, a.repo, model.AuditActionUpload, model.AuditEntityResume, *lang, nil)
+
+	fmt.Printf("Installed %s as %s\n", source, target)
+	return nil
+}
+
+// copyFile writes source to target through a temporary file, so the server
+// never serves a half-written resume.
+func copyFile(source, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".resume-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, in); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
@@ -1,17 +1,23 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/portfolio/backend/internal/auth"
 	"github.com/portfolio/backend/internal/config"
 	"github.com/portfolio/backend/internal/handler"
+	"github.com/portfolio/backend/internal/locale"
 	"github.com/portfolio/backend/internal/middleware"
+	"github.com/portfolio/backend/internal/problem"
 	"github.com/portfolio/backend/internal/repository/postgres"
+	"github.com/portfolio/backend/internal/translate"
 )
 
 func main() {
@@ -30,13 +36,91 @@ func main() {
 	}
 	defer db.Close()
 
+	// Apply pending schema migrations; replicas starting together wait their turn
+	migrateCtx, cancelMigrate := context.WithTimeout(context.Background(), 5*time.Minute)
+	applied, err := postgres.Migrate(migrateCtx, db)
+	if err != nil {
+		log.Fatalf("Failed to migrate database: %v", err)
+	}
+	for _, m := range applied {
+		log.Printf("Applied migration %03d_%s", m.Version, m.Name)
+	}
+	cancelMigrate()
+
 	// Initialize repository
 	repo := postgres.NewRepository(db)
 
+	// Create the first admin account from env vars if none exists yet
+	bootstrapCtx, cancelBootstrap := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := handler.BootstrapAdmin(bootstrapCtx, repo, cfg); err != nil {
+		log.Fatalf("Failed to bootstrap admin account: %v", err)
+	}
+	cancelBootstrap()
+
+	// Periodically drop expired refresh tokens, denylist entries, stale limiter state
+	// and trashed content past its retention period
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			if err := repo.PurgeExpiredTokens(ctx); err != nil {
+				log.Printf("Failed to purge expired tokens: %v", err)
+			}
+			if cfg.LimiterStore == "postgres" {
+				if err := repo.PurgeLimiterState(ctx); err != nil {
+					log.Printf("Failed to purge limiter state: %v", err)
+				}
+			}
+			if cfg.TrashRetentionDays > 0 {
+				cutoff := time.Now().AddDate(0, 0, -cfg.TrashRetentionDays)
+				if purged, err := repo.PurgeTrash(ctx, cutoff); err != nil {
+					log.Printf("Failed to purge trash: %v", err)
+				} else if purged > 0 {
+					log.Printf("Purged %d trashed items", purged)
+				}
+			}
+			cancel()
+		}
+	}()
+
+	// Publish scheduled content once its publish_at has passed
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			if published, err := repo.PublishScheduled(ctx, now); err != nil {
+				log.Printf("Failed to publish scheduled content: %v", err)
+			} else if published > 0 {
+				log.Printf("Published %d scheduled items", published)
+			}
+			cancel()
+		}
+	}()
+
+	// Login lockouts and contact rate limits, in memory unless LIMITER_STORE=postgres
+	limiter := handler.NewLimiterStore(cfg, repo)
+
+	// Content locales and their fallbacks
+	locales, err := locale.NewSettings(cfg.SupportedLocales, cfg.LocaleFallbacks)
+	if err != nil {
+		log.Fatalf("Invalid locale configuration: %v", err)
+	}
+
+	// Machine translation for pre-filling drafts, off unless TRANSLATOR_URL is set
+	var translator translate.Translator
+	if cfg.TranslatorURL != "" {
+		translator = translate.NewLibreTranslate(cfg.TranslatorURL, cfg.TranslatorAPIKey, time.Duration(cfg.TranslatorTimeoutSeconds)*time.Second)
+	}
+
 	// Initialize handlers
 	healthHandler := handler.NewHealthHandler()
-	adminHandler := handler.NewAdminHandler(repo, cfg)
-	portfolioHandler := handler.NewPortfolioHandler(repo, cfg)
+	adminHandler := handler.NewAdminHandler(repo, cfg, limiter, locales, translator)
+	portfolioHandler := handler.NewPortfolioHandler(repo, cfg, limiter, locales)
+
+	// Validation errors name fields by their JSON keys
+	problem.UseJSONFieldNames()
 
 	// Initialize router
 	r := gin.Default()
@@ -59,9 +143,9 @@ func main() {
 
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     allowedOrigins,
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "X-API-Key", "If-Match", middleware.PreviewTokenHeader},
+		ExposeHeaders:    []string{"Content-Length", "ETag"},
 		AllowCredentials: true,
 	}))
 
@@ -73,6 +157,7 @@ func main() {
 
 		// Public routes - anyone can access
 		public := api.Group("/public")
+		public.Use(middleware.PreviewMiddleware())
 		{
 			// Get full portfolio data
 			public.GET("/portfolio", portfolioHandler.GetPortfolio)
@@ -101,56 +186,152 @@ func main() {
 
 		// Admin authentication
 		api.POST("/admin/login", adminHandler.Login)
+		api.POST("/admin/login/2fa", adminHandler.LoginTwoFactor)
+		api.POST("/admin/refresh", adminHandler.Refresh)
+		api.POST("/admin/webauthn/login/begin", adminHandler.BeginWebAuthnLogin)
+		api.POST("/admin/webauthn/login/finish", adminHandler.FinishWebAuthnLogin)
 
-		// Admin routes (would need auth middleware in production)
+		// Admin routes
 		admin := api.Group("/admin")
-		admin.Use(middleware.AuthMiddleware())
+		admin.Use(middleware.AuthMiddleware(repo))
+		admin.Use(middleware.UUIDParams("id", "entityId"))
+
+		// Per-route permission checks; internal/auth/roles.go maps roles to permissions.
+		// Routes that API keys may call also name the scope a key needs.
+		canWrite := func(scope auth.Scope) gin.HandlerFunc {
+			return middleware.RequirePermission(auth.PermContentWrite, scope)
+		}
+		canDelete := func(scope auth.Scope) gin.HandlerFunc {
+			return middleware.RequirePermission(auth.PermContentDelete, scope)
+		}
+		canReadContent := middleware.RequirePermission(auth.PermContentRead)
+		canReadTestimonials := middleware.RequirePermission(auth.PermTestimonialsRead)
+		canModerateTestimonials := middleware.RequirePermission(auth.PermTestimonialsModerate)
+		canReadMessages := middleware.RequirePermission(auth.PermMessagesRead)
+		canManageMessages := middleware.RequirePermission(auth.PermMessagesManage)
+		canManageUsers := middleware.RequirePermission(auth.PermUsersManage)
+		canManageAPIKeys := middleware.RequirePermission(auth.PermAPIKeysManage)
+		canReadAuditLog := middleware.RequirePermission(auth.PermAuditRead)
+
+		// Account routes act on the signed-in user and are not available to API keys
+		account := admin.Group("")
+		account.Use(middleware.RequireUserSession())
+		{
+			account.GET("/profile", adminHandler.GetProfile)
+			account.POST("/logout", adminHandler.Logout)
+
+			// Sessions
+			account.GET("/sessions", adminHandler.GetSessions)
+			account.DELETE("/sessions/:id", adminHandler.RevokeSession)
+
+			// Two-factor authentication
+			account.GET("/2fa", adminHandler.GetTwoFactorStatus)
+			account.POST("/2fa/setup", adminHandler.SetupTwoFactor)
+			account.POST("/2fa/enable", adminHandler.EnableTwoFactor)
+			account.POST("/2fa/disable", adminHandler.DisableTwoFactor)
+			account.POST("/2fa/recovery-codes", adminHandler.RegenerateRecoveryCodes)
+
+			// Passkeys
+			account.GET("/webauthn/credentials", adminHandler.GetWebAuthnCredentials)
+			account.POST("/webauthn/register/begin", adminHandler.BeginWebAuthnRegistration)
+			account.POST("/webauthn/register/finish", adminHandler.FinishWebAuthnRegistration)
+			account.DELETE("/webauthn/credentials/:id", adminHandler.DeleteWebAuthnCredential)
+		}
+
 		{
-			admin.GET("/profile", adminHandler.GetProfile)
+			// User management
+			admin.GET("/users", canManageUsers, adminHandler.GetUsers)
+			admin.POST("/users", canManageUsers, adminHandler.CreateUser)
+			admin.PUT("/users/:id", canManageUsers, adminHandler.UpdateUser)
+			admin.DELETE("/users/:id", canManageUsers, adminHandler.DeleteUser)
+
+			// API keys
+			admin.GET("/api-keys", canManageAPIKeys, adminHandler.GetAPIKeys)
+			admin.POST("/api-keys", canManageAPIKeys, adminHandler.CreateAPIKey)
+			admin.DELETE("/api-keys/:id", canManageAPIKeys, adminHandler.DeleteAPIKey)
+
+			// Preview links for unpublished content (tokens cannot be revoked, so
+			// only editors may mint them, and not with API keys)
+			admin.POST("/preview-token", middleware.RequirePermission(auth.PermContentWrite), adminHandler.CreatePreviewToken)
+
+			// Audit log
+			admin.GET("/audit-log", canReadAuditLog, adminHandler.GetAuditLog)
+
+			// Revision history (restore is not available to API keys)
+			admin.GET("/revisions/:entityType/:entityId", canReadContent, adminHandler.GetRevisions)
+			admin.GET("/revisions/:entityType/:entityId/diff", canReadContent, adminHandler.DiffRevisions)
+			admin.POST("/revisions/:entityType/:entityId/:revision/restore", middleware.RequirePermission(auth.PermContentWrite), adminHandler.RestoreRevision)
+
+			// Trash (each item type needs the permission that deleted it)
+			admin.GET("/trash", middleware.RequireUserSession(), adminHandler.GetTrash)
+			admin.POST("/trash/:entityType/:id/restore", middleware.RequireUserSession(), adminHandler.RestoreFromTrash)
+
+			// Translation coverage, missing and stale translations
+			admin.GET("/translations/status", canReadContent, adminHandler.GetTranslationStatus)
+			// Machine-translated drafts (not available to API keys)
+			admin.POST("/translations/:entityType/:entityId/prefill", middleware.RequirePermission(auth.PermContentWrite), adminHandler.PrefillTranslations)
+			// Clears stale and needs-review flags of translations kept as they are
+			admin.POST("/translations/:entityType/:entityId/review", middleware.RequirePermission(auth.PermContentWrite), adminHandler.MarkTranslationsReviewed)
 			
 			// Contact Info
-			admin.GET("/contact-info", adminHandler.GetContactInfo)
-			admin.PUT("/contact-info", adminHandler.UpdateContactInfo)
+			admin.GET("/contact-info", canReadContent, adminHandler.GetContactInfo)
+			admin.PUT("/contact-info", canWrite(auth.ScopeContactInfoWrite), adminHandler.UpdateContactInfo)
+			admin.PATCH("/contact-info", canWrite(auth.ScopeContactInfoWrite), adminHandler.PatchContactInfo)
 			
 			// Resume & Profile Picture
-			admin.POST("/resume", portfolioHandler.UploadResume)
-			admin.POST("/profile-picture", portfolioHandler.UploadProfilePicture)
+			admin.POST("/resume", canWrite(auth.ScopeResumeWrite), portfolioHandler.UploadResume)
+			admin.POST("/profile-picture", canWrite(auth.ScopeProfilePictureWrite), portfolioHandler.UploadProfilePicture)
 
 			// Skills management
-			admin.POST("/skills", portfolioHandler.CreateSkill)
-			admin.PUT("/skills/:id", portfolioHandler.UpdateSkill)
-			admin.DELETE("/skills/:id", portfolioHandler.DeleteSkill)
+			admin.GET("/skills", canReadContent, portfolioHandler.GetAllSkills)
+			admin.GET("/skills/:id", canReadContent, portfolioHandler.GetSkillByID)
+			admin.POST("/skills", canWrite(auth.ScopeSkillsWrite), portfolioHandler.CreateSkill)
+			admin.PUT("/skills/:id", canWrite(auth.ScopeSkillsWrite), portfolioHandler.UpdateSkill)
+			admin.PATCH("/skills/:id", canWrite(auth.ScopeSkillsWrite), portfolioHandler.PatchSkill)
+			admin.DELETE("/skills/:id", canDelete(auth.ScopeSkillsWrite), portfolioHandler.DeleteSkill)
 
 			// Projects management
-			admin.POST("/projects", portfolioHandler.CreateProject)
-			admin.PUT("/projects/:id", portfolioHandler.UpdateProject)
-			admin.DELETE("/projects/:id", portfolioHandler.DeleteProject)
+			admin.GET("/projects", canReadContent, portfolioHandler.GetAllProjects)
+			admin.GET("/projects/:id", canReadContent, portfolioHandler.GetProjectByID)
+			admin.POST("/projects", canWrite(auth.ScopeProjectsWrite), portfolioHandler.CreateProject)
+			admin.PUT("/projects/:id", canWrite(auth.ScopeProjectsWrite), portfolioHandler.UpdateProject)
+			admin.PATCH("/projects/:id", canWrite(auth.ScopeProjectsWrite), portfolioHandler.PatchProject)
+			admin.DELETE("/projects/:id", canDelete(auth.ScopeProjectsWrite), portfolioHandler.DeleteProject)
 
 			// Experience management
-			admin.POST("/experience", portfolioHandler.CreateExperience)
-			admin.PUT("/experience/:id", portfolioHandler.UpdateExperience)
-			admin.DELETE("/experience/:id", portfolioHandler.DeleteExperience)
+			admin.GET("/experience", canReadContent, portfolioHandler.GetAllExperience)
+			admin.GET("/experience/:id", canReadContent, portfolioHandler.GetExperienceByID)
+			admin.POST("/experience", canWrite(auth.ScopeExperienceWrite), portfolioHandler.CreateExperience)
+			admin.PUT("/experience/:id", canWrite(auth.ScopeExperienceWrite), portfolioHandler.UpdateExperience)
+			admin.PATCH("/experience/:id", canWrite(auth.ScopeExperienceWrite), portfolioHandler.PatchExperience)
+			admin.DELETE("/experience/:id", canDelete(auth.ScopeExperienceWrite), portfolioHandler.DeleteExperience)
 
 			// Education management
-			admin.POST("/education", portfolioHandler.CreateEducation)
-			admin.PUT("/education/:id", portfolioHandler.UpdateEducation)
-			admin.DELETE("/education/:id", portfolioHandler.DeleteEducation)
+			admin.GET("/education", canReadContent, portfolioHandler.GetAllEducation)
+			admin.GET("/education/:id", canReadContent, portfolioHandler.GetEducationByID)
+			admin.POST("/education", canWrite(auth.ScopeEducationWrite), portfolioHandler.CreateEducation)
+			admin.PUT("/education/:id", canWrite(auth.ScopeEducationWrite), portfolioHandler.UpdateEducation)
+			admin.PATCH("/education/:id", canWrite(auth.ScopeEducationWrite), portfolioHandler.PatchEducation)
+			admin.DELETE("/education/:id", canDelete(auth.ScopeEducationWrite), portfolioHandler.DeleteEducation)
 
 			// Hobbies management
-			admin.POST("/hobbies", portfolioHandler.CreateHobby)
-			admin.PUT("/hobbies/:id", portfolioHandler.UpdateHobby)
-			admin.DELETE("/hobbies/:id", portfolioHandler.DeleteHobby)
+			admin.GET("/hobbies", canReadContent, portfolioHandler.GetAllHobbies)
+			admin.GET("/hobbies/:id", canReadContent, portfolioHandler.GetHobbyByID)
+			admin.POST("/hobbies", canWrite(auth.ScopeHobbiesWrite), portfolioHandler.CreateHobby)
+			admin.PUT("/hobbies/:id", canWrite(auth.ScopeHobbiesWrite), portfolioHandler.UpdateHobby)
+			admin.PATCH("/hobbies/:id", canWrite(auth.ScopeHobbiesWrite), portfolioHandler.PatchHobby)
+			admin.DELETE("/hobbies/:id", canDelete(auth.ScopeHobbiesWrite), portfolioHandler.DeleteHobby)
 
 			// Testimonials management (approve/reject/delete)
-			admin.GET("/testimonials", portfolioHandler.GetAllTestimonials)
-			admin.PUT("/testimonials/:id/approve", portfolioHandler.ApproveTestimonial)
-			admin.PUT("/testimonials/:id/reject", portfolioHandler.RejectTestimonial)
-			admin.DELETE("/testimonials/:id", portfolioHandler.DeleteTestimonial)
+			admin.GET("/testimonials", canReadTestimonials, portfolioHandler.GetAllTestimonials)
+			admin.PUT("/testimonials/:id/approve", canModerateTestimonials, portfolioHandler.ApproveTestimonial)
+			admin.PUT("/testimonials/:id/reject", canModerateTestimonials, portfolioHandler.RejectTestimonial)
+			admin.DELETE("/testimonials/:id", canModerateTestimonials, portfolioHandler.DeleteTestimonial)
 
 			// Messages management
-			admin.GET("/messages", portfolioHandler.GetMessages)
-			admin.PUT("/messages/:id/read", portfolioHandler.MarkMessageRead)
-			admin.DELETE("/messages/:id", portfolioHandler.DeleteMessage)
+			admin.GET("/messages", canReadMessages, portfolioHandler.GetMessages)
+			admin.PUT("/messages/:id/read", canManageMessages, portfolioHandler.MarkMessageRead)
+			admin.DELETE("/messages/:id", canManageMessages, portfolioHandler.DeleteMessage)
 		}
 	}
 
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// APIKeyPrefix marks API keys so they can be told apart from JWTs in a Bearer header.
+const APIKeyPrefix = "pfk_"
+
+// apiKeyDisplayLength is how much of a key is kept in clear to identify it in listings.
+const apiKeyDisplayLength = len(APIKeyPrefix) + 8
+
+// Scope limits what an API key may do, on top of its creator's role.
+type Scope string
+
+const (
+	ScopeResumeWrite         Scope = "resume:write"
+	ScopeProfilePictureWrite Scope = "profile-picture:write"
+	ScopeContactInfoWrite    Scope = "contact-info:write"
+	ScopeSkillsWrite         Scope = "skills:write"
+	ScopeProjectsWrite       Scope = "projects:write"
+	ScopeExperienceWrite     Scope = "experience:write"
+	ScopeEducationWrite      Scope = "education:write"
+	ScopeHobbiesWrite        Scope = "hobbies:write"
+)
+
+var validScopes = map[Scope]bool{
+	ScopeResumeWrite:         true,
+	ScopeProfilePictureWrite: true,
+	ScopeContactInfoWrite:    true,
+	ScopeSkillsWrite:         true,
+	ScopeProjectsWrite:       true,
+	ScopeExperienceWrite:     true,
+	ScopeEducationWrite:      true,
+	ScopeHobbiesWrite:        true,
+}
+
+func ValidScope(scope Scope) bool {
+	return validScopes[scope]
+}
+
+// HasAnyScope reports whether granted contains one of required.
+func HasAnyScope(granted []Scope, required ...Scope) bool {
+	for _, r := range required {
+		for _, g := range granted {
+			if g == r {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GenerateAPIKey returns a new random key and the short prefix shown in listings.
+// Only HashAPIKey(key) is stored; the key itself is shown to the user once.
+func GenerateAPIKey() (key, displayPrefix string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	key = APIKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return key, key[:apiKeyDisplayLength], nil
+}
+
+// HashAPIKey returns the SHA-256 hex digest used to look a key up. Keys carry
+// 256 bits of entropy, so a fast hash is enough; bcrypt would only add latency.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
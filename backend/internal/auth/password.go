@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordCost is the bcrypt work factor used for new admin password hashes.
+const PasswordCost = 12
+
+// MinPasswordLength is the shortest password accepted when creating or resetting an admin.
+const MinPasswordLength = 8
+
+var ErrPasswordTooShort = errors.New("password must be at least 8 characters")
+
+// dummyHash is compared against when no account matches the submitted email,
+// so a missing account costs the same time as a wrong password.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("portfolio-dummy-password"), PasswordCost)
+
+// HashPassword returns a bcrypt hash suitable for the admin.password_hash column.
+func HashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength {
+		return "", ErrPasswordTooShort
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), PasswordCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword reports whether password matches the stored hash.
+// An empty hash still performs a full comparison to keep timing uniform.
+func CheckPassword(hash, password string) bool {
+	if hash == "" {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
@@ -0,0 +1,68 @@
+package auth
+
+// Roles an admin user can hold. The first account created on boot is an owner.
+const (
+	RoleOwner     = "owner"
+	RoleEditor    = "editor"
+	RoleModerator = "moderator"
+	RoleViewer    = "viewer"
+)
+
+// Permission names a group of admin routes guarded by middleware.RequirePermission.
+type Permission string
+
+const (
+	PermContentRead          Permission = "content:read"
+	PermContentWrite         Permission = "content:write"
+	PermContentDelete        Permission = "content:delete"
+	PermTestimonialsRead     Permission = "testimonials:read"
+	PermTestimonialsModerate Permission = "testimonials:moderate"
+	PermMessagesRead         Permission = "messages:read"
+	PermMessagesManage       Permission = "messages:manage"
+	PermUsersManage          Permission = "users:manage"
+	PermAPIKeysManage        Permission = "api-keys:manage"
+	PermAuditRead            Permission = "audit:read"
+)
+
+// rolePermissions is the single source of truth for what each role may do.
+// Editors can change any content (including translations) but not delete it;
+// moderators only handle testimonials and messages; viewers are read-only.
+var rolePermissions = map[string][]Permission{
+	RoleOwner: {
+		PermContentRead, PermContentWrite, PermContentDelete,
+		PermTestimonialsRead, PermTestimonialsModerate,
+		PermMessagesRead, PermMessagesManage,
+		PermUsersManage, PermAPIKeysManage,
+		PermAuditRead,
+	},
+	RoleEditor: {
+		PermContentRead, PermContentWrite,
+		PermTestimonialsRead,
+	},
+	RoleModerator: {
+		PermContentRead,
+		PermTestimonialsRead, PermTestimonialsModerate,
+		PermMessagesRead, PermMessagesManage,
+	},
+	RoleViewer: {
+		PermContentRead,
+		PermTestimonialsRead,
+		PermMessagesRead,
+	},
+}
+
+// ValidRole reports whether role is one of the known roles.
+func ValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// RoleHasPermission reports whether role grants perm. Unknown roles grant nothing.
+func RoleHasPermission(role string, perm Permission) bool {
+	for _, granted := range rolePermissions[role] {
+		if granted == perm {
+			return true
+		}
+	}
+	return false
+}
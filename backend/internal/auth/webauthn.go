@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+)
+
+// COSE algorithm identifiers accepted for passkeys, in order of preference.
+const (
+	COSEAlgES256 = int(webauthncose.AlgES256)
+	COSEAlgEdDSA = int(webauthncose.AlgEdDSA)
+	COSEAlgRS256 = int(webauthncose.AlgRS256)
+)
+
+// SupportedCOSEAlgorithms is advertised to browsers as pubKeyCredParams.
+var SupportedCOSEAlgorithms = []int{COSEAlgES256, COSEAlgEdDSA, COSEAlgRS256}
+
+var ErrWebAuthnVerification = errors.New("webauthn verification failed")
+
+// RelyingParty holds the values a browser binds every WebAuthn response to.
+// Parsing and verification of the responses is left to go-webauthn's protocol
+// package; RelyingParty adds the policy on top of it: user verification is
+// always required, cross-origin ceremonies are refused and signature counters
+// must advance.
+type RelyingParty struct {
+	ID      string
+	Name    string
+	Origins []string
+}
+
+// WebAuthnCredential is a newly registered public key credential.
+// PublicKey is the COSE_Key exactly as the authenticator produced it.
+type WebAuthnCredential struct {
+	ID        []byte
+	PublicKey []byte
+	SignCount uint32
+}
+
+// GenerateWebAuthnChallenge returns 32 random bytes, base64url encoded as browsers echo them back.
+func GenerateWebAuthnChallenge() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// VerifyRegistration checks a navigator.credentials.create() response against the
+// issued challenge and returns the credential to store. Registration requests
+// attestation "none"; an attestation statement the authenticator sends anyway is
+// checked for consistency but not against any trust anchor, since the credential
+// is trusted because it is registered from an authenticated session.
+func (rp RelyingParty) VerifyRegistration(challenge string, clientDataJSON, attestationObject []byte) (WebAuthnCredential, error) {
+	response := protocol.AuthenticatorAttestationResponse{
+		AuthenticatorResponse: protocol.AuthenticatorResponse{ClientDataJSON: clientDataJSON},
+		AttestationObject:     attestationObject,
+	}
+	parsed, err := response.Parse()
+	if err != nil {
+		return WebAuthnCredential{}, webAuthnError(err)
+	}
+	if err := rejectCrossOrigin(parsed.CollectedClientData); err != nil {
+		return WebAuthnCredential{}, err
+	}
+
+	creation := protocol.ParsedCredentialCreationData{
+		Response: *parsed,
+		Raw:      protocol.CredentialCreationResponse{AttestationResponse: response},
+	}
+	if _, err := creation.Verify(challenge, true, true, rp.ID, rp.Origins, nil, protocol.TopOriginExplicitVerificationMode, nil, credentialParameters()); err != nil {
+		return WebAuthnCredential{}, webAuthnError(err)
+	}
+
+	authData := parsed.AttestationObject.AuthData
+	if _, err := webauthncose.ParsePublicKey(authData.AttData.CredentialPublicKey); err != nil {
+		return WebAuthnCredential{}, webAuthnError(err)
+	}
+	return WebAuthnCredential{
+		ID:        authData.AttData.CredentialID,
+		PublicKey: authData.AttData.CredentialPublicKey,
+		SignCount: authData.Counter,
+	}, nil
+}
+
+// VerifyAssertion checks a navigator.credentials.get() response signed by the
+// stored publicKey and returns the authenticator's new signature counter.
+// A counter that fails to advance suggests a cloned authenticator and is rejected,
+// except when both values are zero (passkeys that do not implement counters).
+func (rp RelyingParty) VerifyAssertion(challenge string, publicKey []byte, storedSignCount uint32, clientDataJSON, rawAuthData, signature []byte) (uint32, error) {
+	assertion := protocol.ParsedCredentialAssertionData{
+		Response: protocol.ParsedAssertionResponse{Signature: signature},
+		Raw: protocol.CredentialAssertionResponse{
+			AssertionResponse: protocol.AuthenticatorAssertionResponse{
+				AuthenticatorResponse: protocol.AuthenticatorResponse{ClientDataJSON: clientDataJSON},
+				AuthenticatorData:     rawAuthData,
+				Signature:             signature,
+			},
+		},
+	}
+	if err := json.Unmarshal(clientDataJSON, &assertion.Response.CollectedClientData); err != nil {
+		return 0, fmt.Errorf("%w: malformed client data", ErrWebAuthnVerification)
+	}
+	if err := assertion.Response.AuthenticatorData.Unmarshal(rawAuthData); err != nil {
+		return 0, webAuthnError(err)
+	}
+	if err := rejectCrossOrigin(assertion.Response.CollectedClientData); err != nil {
+		return 0, err
+	}
+
+	if err := assertion.Verify(challenge, rp.ID, rp.Origins, nil, protocol.TopOriginExplicitVerificationMode, "", true, true, publicKey); err != nil {
+		return 0, webAuthnError(err)
+	}
+
+	signCount := assertion.Response.AuthenticatorData.Counter
+	if (signCount != 0 || storedSignCount != 0) && signCount <= storedSignCount {
+		return 0, fmt.Errorf("%w: signature counter did not increase", ErrWebAuthnVerification)
+	}
+	return signCount, nil
+}
+
+// rejectCrossOrigin refuses ceremonies run inside a cross-origin iframe. The
+// admin never embeds its login, so such a response can only come from a page
+// framing it.
+func rejectCrossOrigin(clientData protocol.CollectedClientData) error {
+	if clientData.CrossOrigin || clientData.TopOrigin != "" {
+		return fmt.Errorf("%w: cross-origin requests are not allowed", ErrWebAuthnVerification)
+	}
+	return nil
+}
+
+func credentialParameters() []protocol.CredentialParameter {
+	params := make([]protocol.CredentialParameter, 0, len(SupportedCOSEAlgorithms))
+	for _, alg := range SupportedCOSEAlgorithms {
+		params = append(params, protocol.CredentialParameter{
+			Type:      protocol.PublicKeyCredentialType,
+			Algorithm: webauthncose.COSEAlgorithmIdentifier(alg),
+		})
+	}
+	return params
+}
+
+// webAuthnError wraps a go-webauthn error in ErrWebAuthnVerification, keeping
+// the library's diagnostic detail in the message.
+func webAuthnError(err error) error {
+	var protocolErr *protocol.Error
+	if errors.As(err, &protocolErr) && protocolErr.DevInfo != "" {
+		return fmt.Errorf("%w: %s: %s", ErrWebAuthnVerification, protocolErr.Details, protocolErr.DevInfo)
+	}
+	return fmt.Errorf("%w: %v", ErrWebAuthnVerification, err)
+}
@@ -0,0 +1,338 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+)
+
+var testRP = RelyingParty{ID: "example.com", Name: "Example", Origins: []string{"https://example.com"}}
+
+const testChallenge = "dGVzdC1jaGFsbGVuZ2UtdGVzdC1jaGFsbGVuZ2UtMDE"
+
+const (
+	flagUP = 0x01
+	flagUV = 0x04
+	flagAT = 0x40
+)
+
+// authenticator plays the part of a passkey for one COSE algorithm: it
+// produces attestation objects and assertions the way a browser would hand
+// them to the server.
+type authenticator struct {
+	name    string
+	coseKey []byte
+	sign    func(message []byte) []byte
+}
+
+func newAuthenticators(t *testing.T) []authenticator {
+	t.Helper()
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return []authenticator{
+		{
+			name: "ES256",
+			coseKey: mustCBOR(t, map[int]any{
+				1: 2, 3: COSEAlgES256, -1: 1,
+				-2: ecKey.X.FillBytes(make([]byte, 32)),
+				-3: ecKey.Y.FillBytes(make([]byte, 32)),
+			}),
+			sign: func(message []byte) []byte {
+				digest := sha256.Sum256(message)
+				signature, err := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+				if err != nil {
+					t.Fatal(err)
+				}
+				return signature
+			},
+		},
+		{
+			name:    "EdDSA",
+			coseKey: mustCBOR(t, map[int]any{1: 1, 3: COSEAlgEdDSA, -1: 6, -2: []byte(edPublic)}),
+			sign: func(message []byte) []byte {
+				return ed25519.Sign(edPrivate, message)
+			},
+		},
+		{
+			name: "RS256",
+			coseKey: mustCBOR(t, map[int]any{
+				1: 3, 3: COSEAlgRS256,
+				-1: rsaKey.N.Bytes(),
+				-2: big32(rsaKey.E),
+			}),
+			sign: func(message []byte) []byte {
+				digest := sha256.Sum256(message)
+				signature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+				if err != nil {
+					t.Fatal(err)
+				}
+				return signature
+			},
+		},
+	}
+}
+
+// ceremony holds the inputs of one registration or assertion, which each test
+// case mutates before the response is assembled.
+type ceremony struct {
+	rpID       string
+	flags      byte
+	signCount  uint32
+	clientData map[string]any
+}
+
+func newCeremony(ceremonyType string) ceremony {
+	return ceremony{
+		rpID:      testRP.ID,
+		flags:     flagUP | flagUV,
+		signCount: 5,
+		clientData: map[string]any{
+			"type":      ceremonyType,
+			"challenge": testChallenge,
+			"origin":    "https://example.com",
+		},
+	}
+}
+
+func (c ceremony) authData(attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(c.rpID))
+	data := append([]byte(nil), rpIDHash[:]...)
+	flags := c.flags
+	if attested != nil {
+		flags |= flagAT
+	}
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, c.signCount)
+	return append(data, attested...)
+}
+
+func (c ceremony) clientDataJSON(t *testing.T) []byte {
+	t.Helper()
+	raw, err := json.Marshal(c.clientData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+var testCredentialID = []byte("credential-id-0123456789")
+
+func (a authenticator) attestationObject(t *testing.T, c ceremony) []byte {
+	t.Helper()
+	attested := make([]byte, 16) // AAGUID
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(testCredentialID)))
+	attested = append(attested, testCredentialID...)
+	attested = append(attested, a.coseKey...)
+	return mustCBOR(t, map[string]any{"fmt": "none", "attStmt": map[string]any{}, "authData": c.authData(attested)})
+}
+
+func (a authenticator) assertion(t *testing.T, c ceremony) (clientDataJSON, authData, signature []byte) {
+	t.Helper()
+	clientDataJSON = c.clientDataJSON(t)
+	authData = c.authData(nil)
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	return clientDataJSON, authData, a.sign(append(append([]byte(nil), authData...), clientDataHash[:]...))
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *ceremony)
+		ok     bool
+	}{
+		{"valid", func(c *ceremony) {}, true},
+		{"rpIdHash mismatch", func(c *ceremony) { c.rpID = "evil.example" }, false},
+		{"user not present", func(c *ceremony) { c.flags &^= flagUP }, false},
+		{"user not verified", func(c *ceremony) { c.flags &^= flagUV }, false},
+		{"wrong challenge", func(c *ceremony) { c.clientData["challenge"] = "b3RoZXItY2hhbGxlbmdl" }, false},
+		{"wrong origin", func(c *ceremony) { c.clientData["origin"] = "https://evil.example" }, false},
+		{"cross origin", func(c *ceremony) { c.clientData["crossOrigin"] = true }, false},
+		{"top origin", func(c *ceremony) { c.clientData["topOrigin"] = "https://example.com" }, false},
+		{"assertion type", func(c *ceremony) { c.clientData["type"] = "webauthn.get" }, false},
+	}
+
+	for _, a := range newAuthenticators(t) {
+		for _, tc := range tests {
+			t.Run(a.name+"/"+tc.name, func(t *testing.T) {
+				c := newCeremony("webauthn.create")
+				tc.modify(&c)
+				credential, err := testRP.VerifyRegistration(testChallenge, c.clientDataJSON(t), a.attestationObject(t, c))
+				if !tc.ok {
+					wantVerificationError(t, err)
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(credential.ID) != string(testCredentialID) || string(credential.PublicKey) != string(a.coseKey) || credential.SignCount != 5 {
+					t.Fatalf("credential = %+v", credential)
+				}
+			})
+		}
+	}
+}
+
+func TestVerifyRegistrationMalformed(t *testing.T) {
+	a := newAuthenticators(t)[0]
+	c := newCeremony("webauthn.create")
+	valid := a.attestationObject(t, c)
+
+	deep := []byte{0xa1, 0x63, 'f', 'm', 't'}
+	for range 32 {
+		deep = append(deep, 0x81) // an array holding the next item
+	}
+	deep = append(deep, 0x00)
+
+	tests := []struct {
+		name              string
+		attestationObject []byte
+	}{
+		{"empty", nil},
+		{"truncated", valid[:len(valid)/2]},
+		{"trailing byte cut", valid[:len(valid)-1]},
+		{"over deep", deep},
+		{"unsupported map key", mustCBOR(t, map[any]any{1.5: "x", "fmt": "none"})},
+		{"not a map", mustCBOR(t, []any{"none"})},
+		{"unsupported algorithm", a.withCOSEKey(t, c, map[int]any{1: 2, 3: -35, -1: 2, -2: make([]byte, 48), -3: make([]byte, 48)})},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := testRP.VerifyRegistration(testChallenge, c.clientDataJSON(t), tc.attestationObject)
+			wantVerificationError(t, err)
+		})
+	}
+}
+
+// withCOSEKey returns an attestation object carrying key in place of the
+// authenticator's own.
+func (a authenticator) withCOSEKey(t *testing.T, c ceremony, key map[int]any) []byte {
+	t.Helper()
+	a.coseKey = mustCBOR(t, key)
+	return a.attestationObject(t, c)
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	tests := []struct {
+		name            string
+		storedSignCount uint32
+		modify          func(c *ceremony)
+		ok              bool
+	}{
+		{"valid", 4, func(c *ceremony) {}, true},
+		{"no counters", 0, func(c *ceremony) { c.signCount = 0 }, true},
+		{"sign count regression", 9, func(c *ceremony) {}, false},
+		{"sign count repeated", 5, func(c *ceremony) {}, false},
+		{"counter reset to zero", 4, func(c *ceremony) { c.signCount = 0 }, false},
+		{"rpIdHash mismatch", 4, func(c *ceremony) { c.rpID = "evil.example" }, false},
+		{"user not present", 4, func(c *ceremony) { c.flags &^= flagUP }, false},
+		{"user not verified", 4, func(c *ceremony) { c.flags &^= flagUV }, false},
+		{"wrong challenge", 4, func(c *ceremony) { c.clientData["challenge"] = "b3RoZXItY2hhbGxlbmdl" }, false},
+		{"wrong origin", 4, func(c *ceremony) { c.clientData["origin"] = "https://example.com.evil.example" }, false},
+		{"cross origin", 4, func(c *ceremony) { c.clientData["crossOrigin"] = true }, false},
+		{"top origin", 4, func(c *ceremony) { c.clientData["topOrigin"] = "https://evil.example" }, false},
+		{"registration type", 4, func(c *ceremony) { c.clientData["type"] = "webauthn.create" }, false},
+	}
+
+	authenticators := newAuthenticators(t)
+	for _, a := range authenticators {
+		for _, tc := range tests {
+			t.Run(a.name+"/"+tc.name, func(t *testing.T) {
+				c := newCeremony("webauthn.get")
+				tc.modify(&c)
+				clientDataJSON, authData, signature := a.assertion(t, c)
+				signCount, err := testRP.VerifyAssertion(testChallenge, a.coseKey, tc.storedSignCount, clientDataJSON, authData, signature)
+				if !tc.ok {
+					wantVerificationError(t, err)
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if signCount != c.signCount {
+					t.Fatalf("sign count = %d, want %d", signCount, c.signCount)
+				}
+			})
+		}
+
+		t.Run(a.name+"/tampering", func(t *testing.T) {
+			c := newCeremony("webauthn.get")
+			clientDataJSON, authData, signature := a.assertion(t, c)
+
+			tampered := append([]byte(nil), authData...)
+			tampered[len(tampered)-1]++ // the sign count the signature covers
+			_, err := testRP.VerifyAssertion(testChallenge, a.coseKey, 0, clientDataJSON, tampered, signature)
+			wantVerificationError(t, err)
+
+			badSignature := append([]byte(nil), signature...)
+			badSignature[len(badSignature)/2] ^= 0xff
+			_, err = testRP.VerifyAssertion(testChallenge, a.coseKey, 0, clientDataJSON, authData, badSignature)
+			wantVerificationError(t, err)
+
+			other := authenticators[(indexOf(authenticators, a.name)+1)%len(authenticators)]
+			_, err = testRP.VerifyAssertion(testChallenge, other.coseKey, 0, clientDataJSON, authData, signature)
+			wantVerificationError(t, err)
+
+			_, err = testRP.VerifyAssertion(testChallenge, a.coseKey, 0, clientDataJSON, authData[:36], signature)
+			wantVerificationError(t, err)
+
+			_, err = testRP.VerifyAssertion(testChallenge, a.coseKey[:len(a.coseKey)-2], 0, clientDataJSON, authData, signature)
+			wantVerificationError(t, err)
+		})
+	}
+}
+
+func wantVerificationError(t *testing.T, err error) {
+	t.Helper()
+	if !errors.Is(err, ErrWebAuthnVerification) {
+		t.Fatalf("got error %v, want ErrWebAuthnVerification", err)
+	}
+}
+
+func indexOf(authenticators []authenticator, name string) int {
+	for i, a := range authenticators {
+		if a.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func mustCBOR(t *testing.T, v any) []byte {
+	t.Helper()
+	encoded, err := webauthncbor.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
+
+// big32 encodes an RSA exponent as the shortest big-endian byte string.
+func big32(e int) []byte {
+	encoded := binary.BigEndian.AppendUint32(nil, uint32(e))
+	for len(encoded) > 1 && encoded[0] == 0 {
+		encoded = encoded[1:]
+	}
+	return encoded
+}
@@ -22,6 +22,22 @@ type Config struct {
 	AdminLoginMaxAttempts        int
 	AdminLoginBlockMinutes       int
 	AdminLoginAttemptWindowMinutes int
+	AdminBootstrapEmail          string
+	AdminBootstrapPassword       string
+	AdminBootstrapName           string
+	AccessTokenMinutes           int
+	RefreshTokenDays             int
+	TOTPIssuer                   string
+	WebAuthnRPID                 string
+	WebAuthnRPName               string
+	WebAuthnOrigins              []string
+	LimiterStore                 string
+	TrashRetentionDays           int
+	SupportedLocales             []string
+	LocaleFallbacks              map[string]string
+	TranslatorURL                string
+	TranslatorAPIKey             string
+	TranslatorTimeoutSeconds     int
 }
 
 func Load() *Config {
@@ -46,6 +62,22 @@ func Load() *Config {
 		AdminLoginMaxAttempts:        getEnvInt("ADMIN_LOGIN_MAX_ATTEMPTS", 5),
 		AdminLoginBlockMinutes:       getEnvInt("ADMIN_LOGIN_BLOCK_MINUTES", 15),
 		AdminLoginAttemptWindowMinutes: getEnvInt("ADMIN_LOGIN_ATTEMPT_WINDOW_MINUTES", 15),
+		AdminBootstrapEmail:          strings.TrimSpace(getEnv("ADMIN_USER", "")),
+		AdminBootstrapPassword:       getEnv("ADMIN_PASSWORD", ""),
+		AdminBootstrapName:           getEnv("ADMIN_NAME", "Portfolio Admin"),
+		AccessTokenMinutes:           getEnvInt("ACCESS_TOKEN_MINUTES", 15),
+		RefreshTokenDays:             getEnvInt("REFRESH_TOKEN_DAYS", 30),
+		TOTPIssuer:                   getEnv("TOTP_ISSUER", "Portfolio"),
+		WebAuthnRPID:                 strings.TrimSpace(getEnv("WEBAUTHN_RP_ID", "")),
+		WebAuthnRPName:               getEnv("WEBAUTHN_RP_NAME", "Portfolio"),
+		WebAuthnOrigins:              uniqueValues(parseCSVEnv("WEBAUTHN_ORIGINS")),
+		LimiterStore:                 strings.ToLower(strings.TrimSpace(getEnv("LIMITER_STORE", "memory"))),
+		TrashRetentionDays:           getEnvInt("TRASH_RETENTION_DAYS", 30),
+		SupportedLocales:             strings.Split(getEnv("SUPPORTED_LOCALES", "en,fr"), ","),
+		LocaleFallbacks:              parsePairsEnv("LOCALE_FALLBACKS"),
+		TranslatorURL:                strings.TrimRight(strings.TrimSpace(getEnv("TRANSLATOR_URL", "")), "/"),
+		TranslatorAPIKey:             getEnv("TRANSLATOR_API_KEY", ""),
+		TranslatorTimeoutSeconds:     getEnvInt("TRANSLATOR_TIMEOUT_SECONDS", 30),
 	}
 }
 
@@ -67,6 +99,18 @@ func parseCSVEnv(key string) []string {
 	return values
 }
 
+// parsePairsEnv reads "a:b,c:d" into a map.
+func parsePairsEnv(key string) map[string]string {
+	pairs := map[string]string{}
+	for _, part := range strings.Split(os.Getenv(key), ",") {
+		from, to, ok := strings.Cut(part, ":")
+		if ok && strings.TrimSpace(from) != "" {
+			pairs[strings.TrimSpace(from)] = strings.TrimSpace(to)
+		}
+	}
+	return pairs
+}
+
 func uniqueValues(values []string) []string {
 	seen := make(map[string]struct{}, len(values))
 	unique := make([]string, 0, len(values))
@@ -1,135 +1,301 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
-	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/auth"
 	"github.com/portfolio/backend/internal/config"
-	"github.com/portfolio/backend/internal/middleware"
+	"github.com/portfolio/backend/internal/locale"
 	"github.com/portfolio/backend/internal/model"
-	"github.com/portfolio/backend/internal/repository/postgres"
+	"github.com/portfolio/backend/internal/problem"
+	"github.com/portfolio/backend/internal/ratelimit"
+	"github.com/portfolio/backend/internal/repository"
+	"github.com/portfolio/backend/internal/translate"
 )
 
 type AdminHandler struct {
-	repo            *postgres.Repository
+	repo            repository.Store
 	loginProtection *AdminLoginProtection
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+	totpIssuer      string
+	webauthn        auth.RelyingParty
+	locales         locale.Settings
+	translator      translate.Translator
 }
 
-func NewAdminHandler(repo *postgres.Repository, cfg *config.Config) *AdminHandler {
+func NewAdminHandler(repo repository.Store, cfg *config.Config, limiter ratelimit.Store, locales locale.Settings, translator translate.Translator) *AdminHandler {
+	accessTokenTTL := time.Duration(cfg.AccessTokenMinutes) * time.Minute
+	if accessTokenTTL <= 0 {
+		accessTokenTTL = 15 * time.Minute
+	}
+
+	refreshTokenTTL := time.Duration(cfg.RefreshTokenDays) * 24 * time.Hour
+	if refreshTokenTTL <= 0 {
+		refreshTokenTTL = 30 * 24 * time.Hour
+	}
+
 	return &AdminHandler{
 		repo:            repo,
-		loginProtection: NewAdminLoginProtection(cfg),
+		loginProtection: NewAdminLoginProtection(cfg, limiter),
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+		totpIssuer:      cfg.TOTPIssuer,
+		webauthn:        newRelyingParty(cfg),
+		locales:         locales,
+		translator:      translator,
 	}
 }
 
 func (h *AdminHandler) Login(c *gin.Context) {
 	var req model.AdminLoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Validation(c, err)
 		return
 	}
 
 	now := time.Now().UTC()
 	clientIP := c.ClientIP()
-	if remaining, blocked := h.loginProtection.GetBlockRemaining(clientIP, now); blocked {
-		retryAfter := max(1, int(remaining.Seconds()))
-		c.Header("Retry-After", strconv.Itoa(retryAfter))
-		c.JSON(http.StatusTooManyRequests, gin.H{
-			"error":            "Too many failed login attempts. Try again in " + strconv.Itoa(retryAfter) + " seconds.",
-			"retry_after_secs": retryAfter,
-		})
+	if !h.loginAllowed(c, clientIP, now) {
 		return
 	}
 
-	// Verify credentials against environment variables
-	adminUser := os.Getenv("ADMIN_USER")
-	adminPassword := os.Getenv("ADMIN_PASSWORD")
-
-	if req.Email == adminUser && req.Password == adminPassword {
-		h.loginProtection.RegisterSuccess(clientIP)
+	admin, err := h.repo.GetAdminByEmail(c.Request.Context(), strings.TrimSpace(req.Email))
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		problem.WriteCode(c, http.StatusInternalServerError, "credentials_verification_failed")
+		return
+	}
 
-		user := &model.Admin{
-			ID:    "admin-1",
-			Email: req.Email,
-			Name:  "Portfolio Admin",
+	// CheckPassword runs even for unknown emails so both paths take the same time.
+	if auth.CheckPassword(admin.PasswordHash, req.Password) {
+		// The lockout counter is only cleared once the second factor passes.
+		if admin.TwoFactorEnabled {
+			h.respondTwoFactorChallenge(c, admin)
+			return
 		}
 
-		token, err := middleware.GenerateToken(user, time.Hour)
+		h.loginProtection.RegisterSuccess(c.Request.Context(), clientIP)
+
+		response, err := h.startSession(c, admin)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+			problem.WriteCode(c, http.StatusInternalServerError, "token_generation_failed")
 			return
 		}
-
-		response := model.AdminLoginResponse{
-			Token: token,
-			Admin: *user,
-		}
 		c.JSON(http.StatusOK, response)
 		return
 	}
 
-	if remaining, blocked := h.loginProtection.RegisterFailure(clientIP, now); blocked {
-		retryAfter := max(1, int(remaining.Seconds()))
-		c.Header("Retry-After", strconv.Itoa(retryAfter))
-		c.JSON(http.StatusTooManyRequests, gin.H{
-			"error":            "Too many failed login attempts. Try again in " + strconv.Itoa(retryAfter) + " seconds.",
-			"retry_after_secs": retryAfter,
-		})
+	if h.registerLoginFailure(c, clientIP, now) {
 		return
 	}
 
-	c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+	problem.WriteCode(c, http.StatusUnauthorized, "invalid_credentials")
+}
+
+// loginAllowed answers 429 while clientIP is locked out, and 503 when the
+// lockout cannot be checked, so a limiter outage never lets attempts through.
+func (h *AdminHandler) loginAllowed(c *gin.Context, clientIP string, now time.Time) bool {
+	remaining, blocked, err := h.loginProtection.GetBlockRemaining(c.Request.Context(), clientIP, now)
+	if err != nil {
+		log.Printf("Failed to read admin login lockout: %v", err)
+		problem.WriteCode(c, http.StatusServiceUnavailable, "login_protection_unavailable")
+		return false
+	}
+	if blocked {
+		respondLoginBlocked(c, remaining)
+		return false
+	}
+	return true
+}
+
+// registerLoginFailure counts a failed attempt from clientIP. It answers 429
+// when the attempt starts a lockout and 503 when it cannot be counted, and
+// reports whether it has answered.
+func (h *AdminHandler) registerLoginFailure(c *gin.Context, clientIP string, now time.Time) bool {
+	remaining, blocked, err := h.loginProtection.RegisterFailure(c.Request.Context(), clientIP, now)
+	if err != nil {
+		log.Printf("Failed to record admin login failure: %v", err)
+		problem.WriteCode(c, http.StatusServiceUnavailable, "login_protection_unavailable")
+		return true
+	}
+	if blocked {
+		respondLoginBlocked(c, remaining)
+		return true
+	}
+	return false
+}
+
+func respondLoginBlocked(c *gin.Context, remaining time.Duration) {
+	retryAfter := max(1, int(remaining.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	problem.WriteCodeParam(c, http.StatusTooManyRequests, "login_rate_limited", strconv.Itoa(retryAfter))
 }
 
 func (h *AdminHandler) GetProfile(c *gin.Context) {
-	// Placeholder - would fetch from database
-	admin := model.Admin{
-		ID:    "admin-1",
-		Email: "admin@portfolio.com",
-		Name:  "Portfolio Admin",
+	admin, ok := h.currentAdmin(c)
+	if !ok {
+		return
 	}
 	c.JSON(http.StatusOK, admin)
 }
 
+// BootstrapAdmin creates the first admin account from ADMIN_USER/ADMIN_PASSWORD.
+// It is a no-op once any admin exists, so the env vars are only read on first boot.
+func BootstrapAdmin(ctx context.Context, repo repository.AdminStore, cfg *config.Config) error {
+	count, err := repo.CountAdmins(ctx)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if cfg.AdminBootstrapEmail == "" || cfg.AdminBootstrapPassword == "" {
+		log.Println("No admin account exists and ADMIN_USER/ADMIN_PASSWORD are not set; admin login is disabled")
+		return nil
+	}
+
+	hash, err := auth.HashPassword(cfg.AdminBootstrapPassword)
+	if err != nil {
+		return fmt.Errorf("invalid ADMIN_PASSWORD: %w", err)
+	}
+
+	admin, err := repo.CreateAdmin(ctx, model.Admin{
+		Email:        cfg.AdminBootstrapEmail,
+		PasswordHash: hash,
+		Name:         cfg.AdminBootstrapName,
+		Role:         auth.RoleOwner,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Created initial admin account %s", admin.Email)
+	return nil
+}
+
 
 func (h *AdminHandler) GetContactInfo(c *gin.Context) {
 	info, err := h.repo.GetContactInfo(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch contact info"})
+		problem.WriteCode(c, http.StatusInternalServerError, "contact_info_fetch_failed")
 		return
 	}
+	if info.ID != "" {
+		setETag(c, info.Version)
+	}
 	c.JSON(http.StatusOK, info)
 }
 
+// contactInfoVersion returns the contact info version the client last read.
+// Until the contact info is first saved there is nothing to overwrite, so
+// If-Match is only required once it exists.
+func contactInfoVersion(c *gin.Context, existing model.ContactInfo) (int, bool) {
+	if existing.ID == "" {
+		return 0, true
+	}
+	return requireIfMatch(c)
+}
+
 func (h *AdminHandler) UpdateContactInfo(c *gin.Context) {
+	existing, err := h.repo.GetContactInfo(c.Request.Context())
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "contact_info_update_failed")
+		return
+	}
+	version, ok := contactInfoVersion(c, existing)
+	if !ok {
+		return
+	}
+
 	var req model.UpdateContactInfoRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Validation(c, err)
+		return
+	}
+	h.saveContactInfo(c, existing, version, req, nil)
+}
+
+// PatchContactInfo applies a JSON Merge Patch to the contact info.
+func (h *AdminHandler) PatchContactInfo(c *gin.Context) {
+	existing, err := h.repo.GetContactInfo(c.Request.Context())
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "contact_info_fetch_failed")
+		return
+	}
+	version, ok := contactInfoVersion(c, existing)
+	if !ok {
+		return
+	}
+	if existing.Version != version {
+		writeVersionedError(c, repository.ErrVersionConflict, "contact_info_not_found")
+		return
+	}
+
+	previous := existing.Translations.Omit(model.LegacyLocale)
+	req := model.UpdateContactInfoRequest{
+		Email:        existing.Email,
+		Phone:        existing.Phone,
+		Location:     existing.Location,
+		LocationFr:   existing.LocationFr,
+		LinkedIn:     existing.LinkedIn,
+		GitHub:       existing.GitHub,
+		Twitter:      existing.Twitter,
+		Website:      existing.Website,
+		Bio:          existing.Bio,
+		BioFr:        existing.BioFr,
+		AboutTitle:   existing.AboutTitle,
+		AboutTitleFr: existing.AboutTitleFr,
+		Translations: previous,
+	}
+	if !bindMergePatch(c, &req) {
+		return
+	}
+	h.saveContactInfo(c, existing, version, req, previous)
+}
+
+func (h *AdminHandler) saveContactInfo(c *gin.Context, existing model.ContactInfo, version int, req model.UpdateContactInfoRequest, previous model.Translations) {
+	translations, ok := bindTranslations(c, h.locales, model.AuditEntityContactInfo, &req, previous)
+	if !ok {
 		return
 	}
 
 	info := model.ContactInfo{
-		Email:    req.Email,
-		Phone:    req.Phone,
-		Location: req.Location,
-		LinkedIn: req.LinkedIn,
-		GitHub:   req.GitHub,
-		Twitter:  req.Twitter,
-		Website:  req.Website,
+		Email:        req.Email,
+		Phone:        req.Phone,
+		Location:     req.Location,
+		LinkedIn:     req.LinkedIn,
+		GitHub:       req.GitHub,
+		Twitter:      req.Twitter,
+		Website:      req.Website,
 		Bio:          req.Bio,
-		BioFr:        req.BioFr,
 		AboutTitle:   req.AboutTitle,
-		AboutTitleFr: req.AboutTitleFr,
+		Translations: translations,
+		Version:      version,
 	}
 
+	before := snapshotForAudit(c, h.repo, model.AuditEntityContactInfo, existing.ID)
+
 	updated, err := h.repo.UpdateContactInfo(c.Request.Context(), info)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update contact info"})
+		writeVersionedError(c, err, "contact_info_not_found")
 		return
 	}
+	action := model.AuditActionUpdate
+	if existing.ID == "" {
+		action = model.AuditActionCreate
+	}
+	recordAudit(c, h.repo, action, model.AuditEntityContactInfo, updated.ID, before)
+	recordRevision(c, h.repo, model.AuditEntityContactInfo, updated.ID, before)
 	
+	setETag(c, updated.Version)
 	c.JSON(http.StatusOK, updated)
 }
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/auth"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/problem"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+func (h *AdminHandler) GetAPIKeys(c *gin.Context) {
+	keys, err := h.repo.GetAPIKeys(c.Request.Context())
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "api_keys_fetch_failed")
+		return
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+// CreateAPIKey issues a key owned by the caller. The plaintext key is only in this response.
+func (h *AdminHandler) CreateAPIKey(c *gin.Context) {
+	var req model.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	for _, scope := range req.Scopes {
+		if !auth.ValidScope(auth.Scope(scope)) {
+			problem.WriteCodeParam(c, http.StatusBadRequest, "invalid_scope", scope)
+			return
+		}
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		problem.WriteCode(c, http.StatusBadRequest, "expires_at_in_past")
+		return
+	}
+
+	rawKey, prefix, err := auth.GenerateAPIKey()
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "api_key_generation_failed")
+		return
+	}
+
+	created, err := h.repo.CreateAPIKey(c.Request.Context(), model.APIKey{
+		AdminID:   c.GetString("userID"),
+		Name:      strings.TrimSpace(req.Name),
+		Prefix:    prefix,
+		KeyHash:   auth.HashAPIKey(rawKey),
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "api_key_create_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, model.CreateAPIKeyResponse{APIKey: created, Key: rawKey})
+}
+
+func (h *AdminHandler) DeleteAPIKey(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.repo.DeleteAPIKey(c.Request.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "api_key_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "api_key_delete_failed")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "API key deleted", "id": id})
+}
@@ -1,29 +1,21 @@
 package handler
 
 import (
+	"context"
+	"log"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/portfolio/backend/internal/config"
+	"github.com/portfolio/backend/internal/ratelimit"
 )
 
 type AdminLoginProtection struct {
-	mu            sync.Mutex
-	attempts      map[string]*adminLoginAttempt
-	maxAttempts   int
-	blockDuration time.Duration
-	attemptWindow time.Duration
+	store  ratelimit.Store
+	policy ratelimit.LoginPolicy
 }
 
-type adminLoginAttempt struct {
-	failedCount  int
-	lastFailedAt time.Time
-	blockedUntil time.Time
-	lastSeenAt   time.Time
-}
-
-func NewAdminLoginProtection(cfg *config.Config) *AdminLoginProtection {
+func NewAdminLoginProtection(cfg *config.Config, store ratelimit.Store) *AdminLoginProtection {
 	maxAttempts := max(1, cfg.AdminLoginMaxAttempts)
 
 	blockDuration := time.Duration(cfg.AdminLoginBlockMinutes) * time.Minute
@@ -37,82 +29,44 @@ func NewAdminLoginProtection(cfg *config.Config) *AdminLoginProtection {
 	}
 
 	return &AdminLoginProtection{
-		attempts:      make(map[string]*adminLoginAttempt),
-		maxAttempts:   maxAttempts,
-		blockDuration: blockDuration,
-		attemptWindow: attemptWindow,
+		store: store,
+		policy: ratelimit.LoginPolicy{
+			MaxAttempts:   maxAttempts,
+			AttemptWindow: attemptWindow,
+			BlockDuration: blockDuration,
+		},
 	}
 }
 
-func (p *AdminLoginProtection) GetBlockRemaining(clientIP string, now time.Time) (time.Duration, bool) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	key := normalizeClientIP(clientIP)
-	p.cleanupLocked(now)
+// Store errors are returned so the login can be refused: with a shared
+// store, treating them as "not blocked" would switch off the lockout whenever
+// the limiter database misbehaves.
 
-	entry, exists := p.attempts[key]
-	if !exists || !entry.blockedUntil.After(now) {
-		return 0, false
+func (p *AdminLoginProtection) GetBlockRemaining(ctx context.Context, clientIP string, now time.Time) (time.Duration, bool, error) {
+	blockedUntil, err := p.store.LoginBlockedUntil(ctx, loginProtectionKey(clientIP), now)
+	if err != nil {
+		return 0, false, err
 	}
-
-	entry.lastSeenAt = now
-	return entry.blockedUntil.Sub(now), true
-}
-
-func (p *AdminLoginProtection) RegisterFailure(clientIP string, now time.Time) (time.Duration, bool) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	key := normalizeClientIP(clientIP)
-	p.cleanupLocked(now)
-
-	entry, exists := p.attempts[key]
-	if !exists {
-		entry = &adminLoginAttempt{}
-		p.attempts[key] = entry
+	if !blockedUntil.After(now) {
+		return 0, false, nil
 	}
-
-	// Already blocked.
-	if entry.blockedUntil.After(now) {
-		entry.lastSeenAt = now
-		return entry.blockedUntil.Sub(now), true
-	}
-
-	// Reset rolling failure count if outside the configured window.
-	if !entry.lastFailedAt.IsZero() && now.Sub(entry.lastFailedAt) > p.attemptWindow {
-		entry.failedCount = 0
-	}
-
-	entry.failedCount++
-	entry.lastFailedAt = now
-	entry.lastSeenAt = now
-
-	if entry.failedCount >= p.maxAttempts {
-		entry.blockedUntil = now.Add(p.blockDuration)
-		entry.failedCount = 0
-		return p.blockDuration, true
-	}
-
-	return 0, false
+	return blockedUntil.Sub(now), true, nil
 }
 
-func (p *AdminLoginProtection) RegisterSuccess(clientIP string) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	delete(p.attempts, normalizeClientIP(clientIP))
+func (p *AdminLoginProtection) RegisterFailure(ctx context.Context, clientIP string, now time.Time) (time.Duration, bool, error) {
+	return p.store.RegisterLoginFailure(ctx, loginProtectionKey(clientIP), now, p.policy)
 }
 
-func (p *AdminLoginProtection) cleanupLocked(now time.Time) {
-	staleThreshold := now.Add(-72 * time.Hour)
-	for key, entry := range p.attempts {
-		if entry.lastSeenAt.Before(staleThreshold) {
-			delete(p.attempts, key)
-		}
+func (p *AdminLoginProtection) RegisterSuccess(ctx context.Context, clientIP string) {
+	if err := p.store.ResetLoginFailures(ctx, loginProtectionKey(clientIP)); err != nil {
+		log.Printf("Failed to reset admin login failures: %v", err)
 	}
 }
 
+func loginProtectionKey(clientIP string) string {
+	return "admin-login:" + normalizeClientIP(clientIP)
+}
+
 func normalizeClientIP(clientIP string) string {
 	normalized := strings.TrimSpace(clientIP)
 	if normalized == "" {
@@ -121,3 +75,11 @@ func normalizeClientIP(clientIP string) string {
 	return normalized
 }
 
+// NewLimiterStore returns the store selected by LIMITER_STORE: "postgres" shares
+// state through repo, anything else keeps it in memory.
+func NewLimiterStore(cfg *config.Config, repo ratelimit.Store) ratelimit.Store {
+	if cfg.LimiterStore == "postgres" {
+		return repo
+	}
+	return ratelimit.NewMemoryStore()
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/middleware"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/problem"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// startSession mints an access token and the first refresh token of a new session.
+func (h *AdminHandler) startSession(c *gin.Context, admin model.Admin) (model.AdminLoginResponse, error) {
+	return h.issueTokens(c, admin, "", "")
+}
+
+// issueTokens mints an access/refresh pair. With an empty previousID a new
+// session is created; otherwise previousID is rotated out within sessionID.
+func (h *AdminHandler) issueTokens(c *gin.Context, admin model.Admin, sessionID, previousID string) (model.AdminLoginResponse, error) {
+	ctx := c.Request.Context()
+	now := time.Now().UTC()
+
+	rawRefresh, err := generateOpaqueToken()
+	if err != nil {
+		return model.AdminLoginResponse{}, err
+	}
+
+	if sessionID == "" {
+		sessionID, err = newSessionID()
+		if err != nil {
+			return model.AdminLoginResponse{}, err
+		}
+	}
+
+	token, jti, err := middleware.GenerateToken(&admin, sessionID, h.accessTokenTTL)
+	if err != nil {
+		return model.AdminLoginResponse{}, err
+	}
+
+	next := model.RefreshToken{
+		AdminID:       admin.ID,
+		SessionID:     sessionID,
+		TokenHash:     hashOpaqueToken(rawRefresh),
+		AccessTokenID: jti,
+		AccessExpires: now.Add(h.accessTokenTTL),
+		UserAgent:     c.Request.UserAgent(),
+		IPAddress:     c.ClientIP(),
+		ExpiresAt:     now.Add(h.refreshTokenTTL),
+	}
+
+	if previousID == "" {
+		_, err = h.repo.CreateRefreshToken(ctx, next)
+	} else {
+		_, err = h.repo.RotateRefreshToken(ctx, previousID, next)
+	}
+	if err != nil {
+		return model.AdminLoginResponse{}, err
+	}
+
+	return model.AdminLoginResponse{
+		Token:        token,
+		RefreshToken: rawRefresh,
+		ExpiresIn:    int(h.accessTokenTTL.Seconds()),
+		Admin:        admin,
+	}, nil
+}
+
+// Refresh exchanges a refresh token for a new access/refresh pair. Each refresh
+// token works once; presenting a rotated token again revokes the whole session.
+func (h *AdminHandler) Refresh(c *gin.Context) {
+	var req model.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	current, err := h.repo.GetRefreshToken(ctx, hashOpaqueToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusUnauthorized, "refresh_token_invalid")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "refresh_token_verification_failed")
+		return
+	}
+
+	if current.RevokedAt != nil {
+		if err := h.repo.RevokeSession(ctx, current.SessionID); err != nil {
+			problem.WriteCode(c, http.StatusInternalServerError, "session_revoke_failed")
+			return
+		}
+		problem.WriteCode(c, http.StatusUnauthorized, "refresh_token_reused")
+		return
+	}
+
+	admin, err := h.repo.GetAdminByID(ctx, current.AdminID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusUnauthorized, "refresh_token_invalid")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "refresh_token_verification_failed")
+		return
+	}
+
+	response, err := h.issueTokens(c, admin, current.SessionID, current.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenReused) {
+			problem.WriteCode(c, http.StatusUnauthorized, "refresh_token_reused")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "token_generation_failed")
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// Logout revokes the caller's session: its refresh tokens and the current access token.
+func (h *AdminHandler) Logout(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if sessionID := c.GetString("sessionID"); sessionID != "" {
+		if err := h.repo.RevokeSession(ctx, sessionID); err != nil {
+			problem.WriteCode(c, http.StatusInternalServerError, "session_revoke_failed")
+			return
+		}
+	}
+
+	if jti := c.GetString("tokenID"); jti != "" {
+		if err := h.repo.RevokeAccessToken(ctx, jti, c.GetTime("tokenExpiresAt")); err != nil {
+			problem.WriteCode(c, http.StatusInternalServerError, "token_revoke_failed")
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+}
+
+func (h *AdminHandler) GetSessions(c *gin.Context) {
+	sessions, err := h.repo.GetActiveSessions(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "sessions_fetch_failed")
+		return
+	}
+
+	currentSessionID := c.GetString("sessionID")
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession signs out a single session (e.g. another device) without touching the others.
+func (h *AdminHandler) RevokeSession(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.repo.RevokeAdminSession(c.Request.Context(), c.GetString("userID"), id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "session_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "session_revoke_failed")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked", "id": id})
+}
+
+func generateOpaqueToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// newSessionID returns a random RFC 4122 version 4 UUID.
+func newSessionID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	buf[6] = (buf[6] & 0x0f) | 0x40
+	buf[8] = (buf[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", buf[0:4], buf[4:6], buf[6:8], buf[8:10], buf[10:16]), nil
+}
+
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
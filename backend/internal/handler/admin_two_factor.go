@@ -0,0 +1,321 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/auth"
+	"github.com/portfolio/backend/internal/middleware"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/problem"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+const (
+	twoFactorChallengeTTL = 5 * time.Minute
+	recoveryCodeCount     = 10
+)
+
+func (h *AdminHandler) respondTwoFactorChallenge(c *gin.Context, admin model.Admin) {
+	challenge, err := middleware.GenerateChallengeToken(&admin, twoFactorChallengeTTL)
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "token_generation_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, model.AdminLoginChallengeResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    challenge,
+		ExpiresIn:         int(twoFactorChallengeTTL.Seconds()),
+	})
+}
+
+// LoginTwoFactor is the second step of a two-factor login. It trades the
+// challenge token from Login plus a TOTP or recovery code for a real session.
+func (h *AdminHandler) LoginTwoFactor(c *gin.Context) {
+	var req model.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	now := time.Now().UTC()
+	clientIP := c.ClientIP()
+	if !h.loginAllowed(c, clientIP, now) {
+		return
+	}
+
+	claims, err := middleware.ParseChallengeToken(req.ChallengeToken)
+	if err != nil {
+		problem.WriteCode(c, http.StatusUnauthorized, "login_challenge_invalid")
+		return
+	}
+
+	ctx := c.Request.Context()
+	admin, err := h.repo.GetAdminByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusUnauthorized, "login_challenge_invalid")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "credentials_verification_failed")
+		return
+	}
+
+	valid, err := h.verifySecondFactor(ctx, admin, req.Code, now)
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "code_verification_failed")
+		return
+	}
+
+	if valid {
+		h.loginProtection.RegisterSuccess(c.Request.Context(), clientIP)
+
+		response, err := h.startSession(c, admin)
+		if err != nil {
+			problem.WriteCode(c, http.StatusInternalServerError, "token_generation_failed")
+			return
+		}
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	if h.registerLoginFailure(c, clientIP, now) {
+		return
+	}
+
+	problem.WriteCode(c, http.StatusUnauthorized, "invalid_verification_code")
+}
+
+// verifySecondFactor accepts either a current TOTP code or an unused recovery code.
+// Both are single-use: a TOTP time step cannot be replayed and recovery codes are burned.
+func (h *AdminHandler) verifySecondFactor(ctx context.Context, admin model.Admin, code string, now time.Time) (bool, error) {
+	if !admin.TwoFactorEnabled || admin.TOTPSecret == "" {
+		return false, nil
+	}
+
+	if step, ok := auth.ValidateTOTP(admin.TOTPSecret, code, now); ok {
+		return h.repo.ConsumeTOTPStep(ctx, admin.ID, step)
+	}
+
+	normalized := auth.NormalizeRecoveryCode(code)
+	if normalized == "" {
+		return false, nil
+	}
+
+	err := h.repo.UseRecoveryCode(ctx, admin.ID, hashOpaqueToken(normalized))
+	if errors.Is(err, repository.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (h *AdminHandler) GetTwoFactorStatus(c *gin.Context) {
+	admin, ok := h.currentAdmin(c)
+	if !ok {
+		return
+	}
+
+	remaining, err := h.repo.CountUnusedRecoveryCodes(c.Request.Context(), admin.ID)
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "two_factor_status_fetch_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, model.TwoFactorStatusResponse{
+		Enabled:                admin.TwoFactorEnabled,
+		RecoveryCodesRemaining: remaining,
+	})
+}
+
+// SetupTwoFactor generates a new secret and returns the otpauth:// URI to scan.
+// The secret is not enforced until it is confirmed through EnableTwoFactor.
+func (h *AdminHandler) SetupTwoFactor(c *gin.Context) {
+	admin, ok := h.currentAdmin(c)
+	if !ok {
+		return
+	}
+	if admin.TwoFactorEnabled {
+		problem.WriteCode(c, http.StatusConflict, "two_factor_already_enabled")
+		return
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "secret_generation_failed")
+		return
+	}
+
+	if err := h.repo.SetPendingTOTPSecret(c.Request.Context(), admin.ID, secret); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusConflict, "two_factor_already_enabled")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "secret_save_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, model.TwoFactorSetupResponse{
+		Secret:          secret,
+		ProvisioningURI: auth.TOTPProvisioningURI(h.totpIssuer, admin.Email, secret),
+	})
+}
+
+// EnableTwoFactor confirms the pending secret with a code from the authenticator
+// app and returns the recovery codes. They are only shown this once.
+func (h *AdminHandler) EnableTwoFactor(c *gin.Context) {
+	var req model.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	admin, ok := h.currentAdmin(c)
+	if !ok {
+		return
+	}
+	if admin.TwoFactorEnabled {
+		problem.WriteCode(c, http.StatusConflict, "two_factor_already_enabled")
+		return
+	}
+	if admin.TOTPSecret == "" {
+		problem.WriteCode(c, http.StatusBadRequest, "two_factor_setup_required")
+		return
+	}
+
+	step, valid := auth.ValidateTOTP(admin.TOTPSecret, req.Code, time.Now().UTC())
+	if !valid {
+		problem.WriteCode(c, http.StatusBadRequest, "invalid_verification_code")
+		return
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "recovery_codes_generation_failed")
+		return
+	}
+
+	if err := h.repo.EnableTOTP(c.Request.Context(), admin.ID, step, hashes); err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "two_factor_enable_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, model.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableTwoFactor requires both the password and a second factor.
+func (h *AdminHandler) DisableTwoFactor(c *gin.Context) {
+	var req model.TwoFactorDisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	admin, ok := h.currentAdmin(c)
+	if !ok {
+		return
+	}
+	if !admin.TwoFactorEnabled {
+		problem.WriteCode(c, http.StatusConflict, "two_factor_not_enabled")
+		return
+	}
+
+	if !auth.CheckPassword(admin.PasswordHash, req.Password) {
+		problem.WriteCode(c, http.StatusUnauthorized, "invalid_password")
+		return
+	}
+
+	ctx := c.Request.Context()
+	valid, err := h.verifySecondFactor(ctx, admin, req.Code, time.Now().UTC())
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "code_verification_failed")
+		return
+	}
+	if !valid {
+		problem.WriteCode(c, http.StatusUnauthorized, "invalid_verification_code")
+		return
+	}
+
+	if err := h.repo.DisableTOTP(ctx, admin.ID); err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "two_factor_disable_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodes invalidates all existing recovery codes and issues a new set.
+func (h *AdminHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req model.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	admin, ok := h.currentAdmin(c)
+	if !ok {
+		return
+	}
+	if !admin.TwoFactorEnabled {
+		problem.WriteCode(c, http.StatusConflict, "two_factor_not_enabled")
+		return
+	}
+
+	ctx := c.Request.Context()
+	step, valid := auth.ValidateTOTP(admin.TOTPSecret, req.Code, time.Now().UTC())
+	if valid {
+		var err error
+		if valid, err = h.repo.ConsumeTOTPStep(ctx, admin.ID, step); err != nil {
+			problem.WriteCode(c, http.StatusInternalServerError, "code_verification_failed")
+			return
+		}
+	}
+	if !valid {
+		problem.WriteCode(c, http.StatusUnauthorized, "invalid_verification_code")
+		return
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "recovery_codes_generation_failed")
+		return
+	}
+
+	if err := h.repo.ReplaceRecoveryCodes(ctx, admin.ID, hashes); err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "recovery_codes_save_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, model.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// currentAdmin loads the admin behind the request's access token, writing an
+// error response and returning false if that fails.
+func (h *AdminHandler) currentAdmin(c *gin.Context) (model.Admin, bool) {
+	admin, err := h.repo.GetAdminByID(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "admin_not_found")
+			return model.Admin{}, false
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "profile_fetch_failed")
+		return model.Admin{}, false
+	}
+	return admin, true
+}
+
+func newRecoveryCodes() ([]string, []string, error) {
+	codes, err := auth.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = hashOpaqueToken(auth.NormalizeRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/auth"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/problem"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+func (h *AdminHandler) GetUsers(c *gin.Context) {
+	admins, err := h.repo.GetAdmins(c.Request.Context())
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "users_fetch_failed")
+		return
+	}
+	c.JSON(http.StatusOK, admins)
+}
+
+func (h *AdminHandler) CreateUser(c *gin.Context) {
+	var req model.CreateAdminUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	if !auth.ValidRole(req.Role) {
+		problem.WriteCode(c, http.StatusBadRequest, "invalid_role")
+		return
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		if errors.Is(err, auth.ErrPasswordTooShort) {
+			problem.Validation(c, err)
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "user_create_failed")
+		return
+	}
+
+	created, err := h.repo.CreateAdmin(c.Request.Context(), model.Admin{
+		Email:        strings.TrimSpace(req.Email),
+		PasswordHash: hash,
+		Name:         strings.TrimSpace(req.Name),
+		Role:         req.Role,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrAdminExists) {
+			problem.WriteCode(c, http.StatusConflict, "user_email_taken")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "user_create_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// UpdateUser changes a user's name and role. A role change signs the user out
+// everywhere so their existing tokens cannot keep the old permissions.
+func (h *AdminHandler) UpdateUser(c *gin.Context) {
+	id := c.Param("id")
+
+	var req model.UpdateAdminUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	if !auth.ValidRole(req.Role) {
+		problem.WriteCode(c, http.StatusBadRequest, "invalid_role")
+		return
+	}
+
+	ctx := c.Request.Context()
+	existing, err := h.repo.GetAdminByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "user_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "user_update_failed")
+		return
+	}
+
+	updated, err := h.repo.UpdateAdminUser(ctx, id, strings.TrimSpace(req.Name), req.Role)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			problem.WriteCode(c, http.StatusNotFound, "user_not_found")
+		case errors.Is(err, repository.ErrLastOwner):
+			problem.WriteCode(c, http.StatusConflict, "owner_required")
+		default:
+			problem.WriteCode(c, http.StatusInternalServerError, "user_update_failed")
+		}
+		return
+	}
+
+	if existing.Role != updated.Role {
+		if err := h.repo.RevokeAdminSessions(ctx, id); err != nil {
+			problem.WriteCode(c, http.StatusInternalServerError, "sessions_revoke_failed")
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+func (h *AdminHandler) DeleteUser(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.repo.DeleteAdmin(c.Request.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			problem.WriteCode(c, http.StatusNotFound, "user_not_found")
+		case errors.Is(err, repository.ErrLastOwner):
+			problem.WriteCode(c, http.StatusConflict, "owner_required")
+		default:
+			problem.WriteCode(c, http.StatusInternalServerError, "user_delete_failed")
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "User deleted", "id": id})
+}
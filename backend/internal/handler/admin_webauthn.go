@@ -0,0 +1,311 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/auth"
+	"github.com/portfolio/backend/internal/config"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/problem"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+const webAuthnChallengeTTL = 5 * time.Minute
+
+// newRelyingParty resolves the WebAuthn relying party. Origins default to the
+// CORS origins and the RP ID defaults to the host of the first origin.
+func newRelyingParty(cfg *config.Config) auth.RelyingParty {
+	origins := cfg.WebAuthnOrigins
+	if len(origins) == 0 {
+		for _, origin := range cfg.AllowedOrigins {
+			if origin != "*" {
+				origins = append(origins, origin)
+			}
+		}
+	}
+	if len(origins) == 0 {
+		origins = []string{"http://localhost:5173", "http://localhost:3000"}
+	}
+
+	rpID := cfg.WebAuthnRPID
+	if rpID == "" {
+		if parsed, err := url.Parse(origins[0]); err == nil {
+			rpID = parsed.Hostname()
+		}
+	}
+
+	return auth.RelyingParty{ID: rpID, Name: cfg.WebAuthnRPName, Origins: origins}
+}
+
+// BeginWebAuthnLogin issues a challenge for a passkey login. No account is named
+// up front: the browser offers the admin's discoverable credentials itself.
+func (h *AdminHandler) BeginWebAuthnLogin(c *gin.Context) {
+	if !h.loginAllowed(c, c.ClientIP(), time.Now().UTC()) {
+		return
+	}
+
+	challengeID, challenge, ok := h.createWebAuthnChallenge(c, model.WebAuthnPurposeLogin, "")
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, model.WebAuthnLoginOptionsResponse{
+		ChallengeID: challengeID,
+		PublicKey: model.WebAuthnRequestOptions{
+			Challenge:        challenge,
+			RPID:             h.webauthn.ID,
+			Timeout:          int(webAuthnChallengeTTL.Milliseconds()),
+			UserVerification: "required",
+			AllowCredentials: []model.WebAuthnCredentialDescriptor{},
+		},
+	})
+}
+
+// FinishWebAuthnLogin verifies a passkey assertion and starts a session exactly
+// like a password login. A user-verified passkey already combines possession and
+// a PIN or biometric, so the TOTP step is not asked for on top of it.
+func (h *AdminHandler) FinishWebAuthnLogin(c *gin.Context) {
+	var req model.WebAuthnLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	now := time.Now().UTC()
+	clientIP := c.ClientIP()
+	if !h.loginAllowed(c, clientIP, now) {
+		return
+	}
+
+	ctx := c.Request.Context()
+	challenge, err := h.repo.ConsumeWebAuthnChallenge(ctx, req.ChallengeID, model.WebAuthnPurposeLogin, "")
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusUnauthorized, "passkey_challenge_invalid")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "passkey_verification_error")
+		return
+	}
+
+	admin, err := h.verifyWebAuthnAssertion(c, challenge.Challenge, req.Credential)
+	if err != nil {
+		if !errors.Is(err, auth.ErrWebAuthnVerification) && !errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusInternalServerError, "passkey_verification_error")
+			return
+		}
+		if h.registerLoginFailure(c, clientIP, now) {
+			return
+		}
+		problem.WriteCode(c, http.StatusUnauthorized, "passkey_verification_failed")
+		return
+	}
+
+	h.loginProtection.RegisterSuccess(c.Request.Context(), clientIP)
+
+	response, err := h.startSession(c, admin)
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "token_generation_failed")
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// verifyWebAuthnAssertion checks the assertion against the stored credential and
+// advances its signature counter. Unknown credentials return repository.ErrNotFound
+// and bad assertions wrap auth.ErrWebAuthnVerification.
+func (h *AdminHandler) verifyWebAuthnAssertion(c *gin.Context, challenge string, credential model.WebAuthnAssertionCredential) (model.Admin, error) {
+	ctx := c.Request.Context()
+
+	stored, err := h.repo.GetWebAuthnCredentialByCredentialID(ctx, credential.ID)
+	if err != nil {
+		return model.Admin{}, err
+	}
+
+	clientDataJSON, err1 := decodeBase64URL(credential.Response.ClientDataJSON)
+	authData, err2 := decodeBase64URL(credential.Response.AuthenticatorData)
+	signature, err3 := decodeBase64URL(credential.Response.Signature)
+	if err := errors.Join(err1, err2, err3); err != nil || credential.Type != "public-key" {
+		return model.Admin{}, auth.ErrWebAuthnVerification
+	}
+
+	// The user handle is the admin ID we set at registration; when the browser
+	// sends one it must agree with the credential's owner.
+	if credential.Response.UserHandle != "" {
+		userHandle, err := decodeBase64URL(credential.Response.UserHandle)
+		if err != nil || string(userHandle) != stored.AdminID {
+			return model.Admin{}, auth.ErrWebAuthnVerification
+		}
+	}
+
+	signCount, err := h.webauthn.VerifyAssertion(challenge, stored.PublicKey, stored.SignCount, clientDataJSON, authData, signature)
+	if err != nil {
+		return model.Admin{}, err
+	}
+
+	if err := h.repo.UpdateWebAuthnSignCount(ctx, stored.ID, signCount); err != nil {
+		return model.Admin{}, err
+	}
+	return h.repo.GetAdminByID(ctx, stored.AdminID)
+}
+
+// BeginWebAuthnRegistration issues a challenge for adding a passkey to the current admin.
+func (h *AdminHandler) BeginWebAuthnRegistration(c *gin.Context) {
+	admin, ok := h.currentAdmin(c)
+	if !ok {
+		return
+	}
+
+	existing, err := h.repo.GetWebAuthnCredentials(c.Request.Context(), admin.ID)
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "passkeys_fetch_failed")
+		return
+	}
+
+	challengeID, challenge, ok := h.createWebAuthnChallenge(c, model.WebAuthnPurposeRegister, admin.ID)
+	if !ok {
+		return
+	}
+
+	params := make([]model.WebAuthnCredentialParameter, 0, len(auth.SupportedCOSEAlgorithms))
+	for _, alg := range auth.SupportedCOSEAlgorithms {
+		params = append(params, model.WebAuthnCredentialParameter{Type: "public-key", Alg: alg})
+	}
+
+	exclude := make([]model.WebAuthnCredentialDescriptor, 0, len(existing))
+	for _, cred := range existing {
+		exclude = append(exclude, model.WebAuthnCredentialDescriptor{Type: "public-key", ID: cred.CredentialID, Transports: cred.Transports})
+	}
+
+	c.JSON(http.StatusOK, model.WebAuthnRegistrationOptionsResponse{
+		ChallengeID: challengeID,
+		PublicKey: model.WebAuthnCreationOptions{
+			Challenge: challenge,
+			RP:        model.WebAuthnRelyingParty{ID: h.webauthn.ID, Name: h.webauthn.Name},
+			User: model.WebAuthnUser{
+				ID:          base64.RawURLEncoding.EncodeToString([]byte(admin.ID)),
+				Name:        admin.Email,
+				DisplayName: admin.Name,
+			},
+			PubKeyCredParams: params,
+			Timeout:          int(webAuthnChallengeTTL.Milliseconds()),
+			Attestation:      "none",
+			AuthenticatorSelection: model.WebAuthnAuthenticatorSelection{
+				ResidentKey:      "required",
+				UserVerification: "required",
+			},
+			ExcludeCredentials: exclude,
+		},
+	})
+}
+
+// FinishWebAuthnRegistration verifies the attestation and stores the new passkey.
+func (h *AdminHandler) FinishWebAuthnRegistration(c *gin.Context) {
+	var req model.WebAuthnRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	adminID := c.GetString("userID")
+	challenge, err := h.repo.ConsumeWebAuthnChallenge(ctx, req.ChallengeID, model.WebAuthnPurposeRegister, adminID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusBadRequest, "passkey_challenge_invalid")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "passkey_verification_error")
+		return
+	}
+
+	clientDataJSON, err1 := decodeBase64URL(req.Credential.Response.ClientDataJSON)
+	attestationObject, err2 := decodeBase64URL(req.Credential.Response.AttestationObject)
+	if errors.Join(err1, err2) != nil || req.Credential.Type != "public-key" {
+		problem.WriteCode(c, http.StatusBadRequest, "passkey_response_malformed")
+		return
+	}
+
+	verified, err := h.webauthn.VerifyRegistration(challenge.Challenge, clientDataJSON, attestationObject)
+	if err != nil {
+		problem.WriteCode(c, http.StatusBadRequest, "passkey_verification_failed")
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = "Passkey"
+	}
+
+	created, err := h.repo.CreateWebAuthnCredential(ctx, model.WebAuthnCredential{
+		AdminID:      adminID,
+		CredentialID: base64.RawURLEncoding.EncodeToString(verified.ID),
+		PublicKey:    verified.PublicKey,
+		SignCount:    verified.SignCount,
+		Transports:   req.Credential.Response.Transports,
+		Name:         name,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrCredentialExists) {
+			problem.WriteCode(c, http.StatusConflict, "passkey_already_registered")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "passkey_save_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *AdminHandler) GetWebAuthnCredentials(c *gin.Context) {
+	creds, err := h.repo.GetWebAuthnCredentials(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "passkeys_fetch_failed")
+		return
+	}
+	c.JSON(http.StatusOK, creds)
+}
+
+func (h *AdminHandler) DeleteWebAuthnCredential(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.repo.DeleteWebAuthnCredential(c.Request.Context(), c.GetString("userID"), id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "passkey_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "passkey_delete_failed")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Passkey deleted", "id": id})
+}
+
+func (h *AdminHandler) createWebAuthnChallenge(c *gin.Context, purpose, adminID string) (string, string, bool) {
+	challenge, err := auth.GenerateWebAuthnChallenge()
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "challenge_generation_failed")
+		return "", "", false
+	}
+
+	id, err := h.repo.CreateWebAuthnChallenge(c.Request.Context(), model.WebAuthnChallenge{
+		AdminID:   adminID,
+		Challenge: challenge,
+		Purpose:   purpose,
+		ExpiresAt: time.Now().UTC().Add(webAuthnChallengeTTL),
+	})
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "challenge_generation_failed")
+		return "", "", false
+	}
+	return id, challenge, true
+}
+
+// decodeBase64URL accepts base64url with or without padding, as browsers differ.
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
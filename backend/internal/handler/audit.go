@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/problem"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 200
+)
+
+// snapshotForAudit captures a row before it is changed. Failures are logged and
+// recorded as a null snapshot rather than blocking the change itself.
+func snapshotForAudit(c *gin.Context, repo repository.AuditStore, entityType, id string) json.RawMessage {
+	snapshot, err := repo.SnapshotEntity(c.Request.Context(), entityType, id)
+	if err != nil {
+		log.Printf("Failed to snapshot %s %s for audit log: %v", entityType, id, err)
+		return nil
+	}
+	return snapshot
+}
+
+// recordAudit writes an audit row for a change that has already succeeded.
+// The after snapshot is read back from the database unless the row was deleted.
+func recordAudit(c *gin.Context, repo repository.AuditStore, action, entityType, id string, before json.RawMessage) {
+	var after json.RawMessage
+	if action != model.AuditActionDelete {
+		after = snapshotForAudit(c, repo, entityType, id)
+	}
+
+	entry := model.AuditEntry{
+		ActorEmail: c.GetString("email"),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   id,
+		Before:     before,
+		After:      after,
+		IPAddress:  c.ClientIP(),
+	}
+	if actorID := c.GetString("userID"); actorID != "" {
+		entry.ActorID = &actorID
+	}
+	if apiKeyID := c.GetString("apiKeyID"); apiKeyID != "" {
+		entry.APIKeyID = &apiKeyID
+	}
+
+	if err := repo.CreateAuditEntry(c.Request.Context(), entry); err != nil {
+		log.Printf("Failed to write audit log entry (%s %s %s): %v", action, entityType, id, err)
+	}
+}
+
+// GetAuditLog lists audit entries, newest first. Supported query parameters:
+// actorId, action, entityType, entityId, from and to (RFC 3339), limit, offset.
+func (h *AdminHandler) GetAuditLog(c *gin.Context) {
+	filter := model.AuditLogFilter{
+		ActorID:    c.Query("actorId"),
+		Action:     c.Query("action"),
+		EntityType: c.Query("entityType"),
+		EntityID:   c.Query("entityId"),
+		Limit:      defaultAuditPageSize,
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxAuditPageSize {
+			problem.WriteCodeParam(c, http.StatusBadRequest, "invalid_limit", strconv.Itoa(maxAuditPageSize))
+			return
+		}
+		filter.Limit = limit
+	}
+	if value := c.Query("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			problem.WriteCode(c, http.StatusBadRequest, "invalid_offset")
+			return
+		}
+		filter.Offset = offset
+	}
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := c.Query(param); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				problem.WriteCodeParam(c, http.StatusBadRequest, "invalid_timestamp", param)
+				return
+			}
+			*target = &parsed
+		}
+	}
+
+	entries, total, err := h.repo.ListAuditEntries(c.Request.Context(), filter)
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "audit_log_fetch_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, model.AuditLogPage{
+		Items:  entries,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	})
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/problem"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// setETag exposes a row version as a strong entity tag.
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", fmt.Sprintf(`"%d"`, version))
+}
+
+// requireIfMatch returns the row version the client last read, taken from its
+// If-Match header. It answers 428 when the header is missing and 412 when it
+// does not carry an entity tag this API handed out.
+func requireIfMatch(c *gin.Context) (int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		problem.WriteCode(c, http.StatusPreconditionRequired, problem.CodePreconditionRequired)
+		return 0, false
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	version, err := strconv.Atoi(strings.Trim(tag, `"`))
+	if err != nil || version < 1 {
+		problem.WriteCode(c, http.StatusPreconditionFailed, "if_match_invalid")
+		return 0, false
+	}
+	return version, true
+}
+
+// writeVersionedError answers a failed versioned write: 404 with the
+// notFound problem code when the row is gone, 412 when someone else changed
+// it after the client read it.
+func writeVersionedError(c *gin.Context, err error, notFound string) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		problem.WriteCode(c, http.StatusNotFound, notFound)
+	case errors.Is(err, repository.ErrVersionConflict):
+		problem.WriteCode(c, http.StatusPreconditionFailed, problem.CodeVersionConflict)
+	default:
+		problem.FromError(c, err)
+	}
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/locale"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/problem"
+)
+
+// contentLocales picks the language of a public content response from a
+// supported ?lang=, then Accept-Language, and returns the locales to resolve its
+// fields from. The response is marked as varying by Accept-Language.
+func contentLocales(c *gin.Context, locales locale.Settings) []string {
+	tag := c.Query("lang")
+	if locale.Match(tag, locales.Supported()) == "" {
+		tag = locale.Negotiate(c.GetHeader("Accept-Language"), locales.Supported())
+	}
+
+	c.Writer.Header().Add("Vary", "Accept-Language")
+	return locales.Chain(tag)
+}
+
+// setContentLanguage marks the response with the first locale along chain
+// that a field was resolved from: the only one when every field came from
+// the same locale, the most preferred one when they are mixed. Nothing is
+// set when no field had text in any of them.
+func setContentLanguage(c *gin.Context, chain []string, used map[string]bool) {
+	for _, l := range chain {
+		if used[l] {
+			c.Header("Content-Language", l)
+			return
+		}
+	}
+}
+
+// localize returns v, an entity or a slice of entities, as JSON documents
+// whose translatable fields hold the first non-empty value along chain. The
+// untranslated value is used for the default locale, and the per-locale
+// fields ("titleFr", "translations", "needsReview") are left out. The
+// locales fields were resolved from are added to used.
+func localize(entityType string, chain []string, defaultLocale string, v any, used map[string]bool) (any, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var document any
+	if err := json.Unmarshal(encoded, &document); err != nil {
+		return nil, err
+	}
+
+	switch doc := document.(type) {
+	case []any:
+		for _, item := range doc {
+			if entity, ok := item.(map[string]any); ok {
+				localizeEntity(entityType, chain, defaultLocale, entity, used)
+			}
+		}
+	case map[string]any:
+		localizeEntity(entityType, chain, defaultLocale, doc, used)
+	}
+	return document, nil
+}
+
+func localizeEntity(entityType string, chain []string, defaultLocale string, entity map[string]any, used map[string]bool) {
+	var translations model.Translations
+	if raw, ok := entity["translations"].(map[string]any); ok {
+		translations = model.Translations{}
+		for l, fields := range raw {
+			if fields, ok := fields.(map[string]any); ok {
+				translations[l] = fields
+			}
+		}
+	}
+
+	for field := range model.TranslatableFields[entityType] {
+		for _, l := range chain {
+			value := entity[field]
+			if l != defaultLocale {
+				value = translations[l][field]
+			}
+			if !model.IsEmptyTranslation(value) {
+				entity[field] = value
+				used[l] = true
+				break
+			}
+		}
+		delete(entity, field+"Fr")
+	}
+	delete(entity, "translations")
+	delete(entity, "needsReview")
+}
+
+// respondLocalized writes v, content of entityType, in the language the
+// request asks for.
+func (h *PortfolioHandler) respondLocalized(c *gin.Context, entityType string, v any) {
+	chain := contentLocales(c, h.locales)
+	used := map[string]bool{}
+	localized, err := localize(entityType, chain, h.locales.Default(), v, used)
+	if err != nil {
+		problem.FromError(c, err)
+		return
+	}
+	setContentLanguage(c, chain, used)
+	c.JSON(http.StatusOK, localized)
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/portfolio/backend/internal/problem"
+)
+
+// MergePatchContentType is the media type of RFC 7386 JSON Merge Patch bodies.
+// Plain application/json is accepted as well.
+const MergePatchContentType = "application/merge-patch+json"
+
+// bindMergePatch applies the request body, a JSON Merge Patch, to req. On
+// entry req holds the stored state; a field missing from the patch keeps it,
+// an explicit null clears it, and any other value replaces it. Keys that are
+// not fields of req are rejected. It writes the error response itself and
+// returns false when the patch cannot be applied.
+func bindMergePatch(c *gin.Context, req any) bool {
+	contentType := c.ContentType()
+	if contentType != MergePatchContentType && contentType != binding.MIMEJSON {
+		problem.WriteCodeParam(c, http.StatusUnsupportedMediaType, "unsupported_content_type", MergePatchContentType)
+		return false
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		problem.WriteCode(c, http.StatusBadRequest, "body_read_failed")
+		return false
+	}
+
+	var patch map[string]any
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+		problem.WriteCode(c, http.StatusBadRequest, "patch_not_object")
+		return false
+	}
+
+	allowed := jsonFieldNames(reflect.TypeOf(req).Elem())
+	var unknown []string
+	for key := range patch {
+		if !allowed[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		problem.WriteCodeParam(c, http.StatusBadRequest, "unknown_patch_fields", strings.Join(unknown, ", "))
+		return false
+	}
+
+	if err := applyMergePatch(req, patch); err != nil {
+		problem.Validation(c, err)
+		return false
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		problem.Validation(c, err)
+		return false
+	}
+	return true
+}
+
+// applyMergePatch round-trips req through JSON so the patch can be merged into
+// its document form, then decodes the result back into a zeroed req.
+func applyMergePatch(req any, patch map[string]any) error {
+	current, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	var document map[string]any
+	if err := json.Unmarshal(current, &document); err != nil {
+		return err
+	}
+
+	merged, err := json.Marshal(mergePatch(document, patch))
+	if err != nil {
+		return err
+	}
+
+	target := reflect.ValueOf(req).Elem()
+	target.Set(reflect.Zero(target.Type()))
+
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(req); err != nil {
+		return fmt.Errorf("invalid patch: %w", err)
+	}
+	return nil
+}
+
+// mergePatch implements the MergePatch algorithm of RFC 7386, section 2.
+func mergePatch(target any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+	return targetObject
+}
+
+// jsonFieldNames lists the JSON keys of a struct, including those promoted
+// from embedded structs.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for name := range jsonFieldNames(field.Type) {
+				names[name] = true
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
@@ -7,39 +7,26 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"net/url"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/portfolio/backend/internal/config"
+	"github.com/portfolio/backend/internal/ratelimit"
 )
 
 type MessageProtection struct {
 	turnstileSecret string
 	minSubmitDelay  time.Duration
 	duplicateWindow time.Duration
-	rateLimiter     *ipRateLimiter
+	limiter         ratelimit.Store
+	ratePolicy      ratelimit.RatePolicy
 	httpClient      *http.Client
 }
 
-type ipRateLimiter struct {
-	mu          sync.Mutex
-	entries     map[string]*ipRateEntry
-	window      time.Duration
-	maxInWindow int
-	maxPerDay   int
-}
-
-type ipRateEntry struct {
-	recentRequests []time.Time
-	dayKey         string
-	dayCount       int
-	lastSeen       time.Time
-}
-
-func NewMessageProtection(cfg *config.Config) *MessageProtection {
+func NewMessageProtection(cfg *config.Config, limiter ratelimit.Store) *MessageProtection {
 	window := time.Duration(cfg.ContactRateLimitWindowMinute) * time.Minute
 	if window <= 0 {
 		window = 10 * time.Minute
@@ -59,11 +46,11 @@ func NewMessageProtection(cfg *config.Config) *MessageProtection {
 		turnstileSecret: strings.TrimSpace(cfg.TurnstileSecretKey),
 		minSubmitDelay:  minDelay,
 		duplicateWindow: duplicateWindow,
-		rateLimiter: &ipRateLimiter{
-			entries:     make(map[string]*ipRateEntry),
-			window:      window,
-			maxInWindow: max(1, cfg.ContactRateLimitMaxPerWindow),
-			maxPerDay:   max(1, cfg.ContactRateLimitMaxPerDay),
+		limiter:         limiter,
+		ratePolicy: ratelimit.RatePolicy{
+			Window:      window,
+			MaxInWindow: max(1, cfg.ContactRateLimitMaxPerWindow),
+			MaxPerDay:   max(1, cfg.ContactRateLimitMaxPerDay),
 		},
 		httpClient: &http.Client{Timeout: 6 * time.Second},
 	}
@@ -89,8 +76,15 @@ func (p *MessageProtection) IsSubmissionTooFast(submittedAtMs int64, now time.Ti
 	return now.Sub(submittedAt) < p.minSubmitDelay
 }
 
-func (p *MessageProtection) Allow(clientIP string, now time.Time) bool {
-	return p.rateLimiter.Allow(clientIP, now)
+// Allow reports whether clientIP may submit another message. If the limiter
+// store is unavailable the submission is allowed, since the other spam checks still apply.
+func (p *MessageProtection) Allow(ctx context.Context, clientIP string, now time.Time) bool {
+	allowed, err := p.limiter.AllowRequest(ctx, "contact:"+normalizeClientIP(clientIP), now, p.ratePolicy)
+	if err != nil {
+		log.Printf("Failed to check contact rate limit: %v", err)
+		return true
+	}
+	return allowed
 }
 
 func (p *MessageProtection) DuplicateWindow() time.Duration {
@@ -164,61 +158,3 @@ func normalizeText(value string) string {
 	}
 	return strings.Join(strings.Fields(trimmed), " ")
 }
-
-func (l *ipRateLimiter) Allow(clientIP string, now time.Time) bool {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	ip := strings.TrimSpace(clientIP)
-	if ip == "" {
-		ip = "unknown"
-	}
-
-	l.cleanup(now)
-
-	entry, exists := l.entries[ip]
-	if !exists {
-		entry = &ipRateEntry{}
-		l.entries[ip] = entry
-	}
-
-	cutoff := now.Add(-l.window)
-	filtered := entry.recentRequests[:0]
-	for _, timestamp := range entry.recentRequests {
-		if !timestamp.Before(cutoff) {
-			filtered = append(filtered, timestamp)
-		}
-	}
-	entry.recentRequests = filtered
-
-	currentDay := now.UTC().Format("2006-01-02")
-	if entry.dayKey != currentDay {
-		entry.dayKey = currentDay
-		entry.dayCount = 0
-	}
-
-	if len(entry.recentRequests) >= l.maxInWindow {
-		entry.lastSeen = now
-		return false
-	}
-	if entry.dayCount >= l.maxPerDay {
-		entry.lastSeen = now
-		return false
-	}
-
-	entry.recentRequests = append(entry.recentRequests, now)
-	entry.dayCount++
-	entry.lastSeen = now
-
-	return true
-}
-
-func (l *ipRateLimiter) cleanup(now time.Time) {
-	staleThreshold := now.Add(-48 * time.Hour)
-	for ip, entry := range l.entries {
-		if entry.lastSeen.Before(staleThreshold) {
-			delete(l.entries, ip)
-		}
-	}
-}
-
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -10,19 +11,26 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/portfolio/backend/internal/config"
+	"github.com/portfolio/backend/internal/locale"
 	"github.com/portfolio/backend/internal/model"
-	"github.com/portfolio/backend/internal/repository/postgres"
+	"github.com/portfolio/backend/internal/problem"
+	"github.com/portfolio/backend/internal/ratelimit"
+	"github.com/portfolio/backend/internal/repository"
 )
 
+// PortfolioHandler serves the content sections; it only needs the content
+// aggregates and the history that records changes to them.
 type PortfolioHandler struct {
-	repo              *postgres.Repository
+	repo              repository.ContentStore
 	messageProtection *MessageProtection
+	locales           locale.Settings
 }
 
-func NewPortfolioHandler(repo *postgres.Repository, cfg *config.Config) *PortfolioHandler {
+func NewPortfolioHandler(repo repository.ContentStore, cfg *config.Config, limiter ratelimit.Store, locales locale.Settings) *PortfolioHandler {
 	return &PortfolioHandler{
 		repo:              repo,
-		messageProtection: NewMessageProtection(cfg),
+		messageProtection: NewMessageProtection(cfg, limiter),
+		locales:           locales,
 	}
 }
 
@@ -31,34 +39,42 @@ func (h *PortfolioHandler) GetPortfolio(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
-	skills, err := h.repo.GetSkills(ctx)
+	// A preview shows drafts and scheduled items as they will appear once live
+	getSkills, getProjects, getExperiences, getEducation, getHobbies :=
+		h.repo.GetSkills, h.repo.GetProjects, h.repo.GetExperiences, h.repo.GetEducation, h.repo.GetHobbies
+	if inPreview(c) {
+		getSkills, getProjects, getExperiences, getEducation, getHobbies =
+			h.repo.GetAllSkills, h.repo.GetAllProjects, h.repo.GetAllExperiences, h.repo.GetAllEducation, h.repo.GetAllHobbies
+	}
+
+	skills, err := getSkills(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch skills"})
+		problem.WriteCode(c, http.StatusInternalServerError, "skills_fetch_failed")
 		return
 	}
-	projects, err := h.repo.GetProjects(ctx)
+	projects, err := getProjects(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch projects"})
+		problem.WriteCode(c, http.StatusInternalServerError, "projects_fetch_failed")
 		return
 	}
-	experience, err := h.repo.GetExperiences(ctx)
+	experience, err := getExperiences(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch experience"})
+		problem.WriteCode(c, http.StatusInternalServerError, "experience_fetch_failed")
 		return
 	}
-	education, err := h.repo.GetEducation(ctx)
+	education, err := getEducation(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch education"})
+		problem.WriteCode(c, http.StatusInternalServerError, "education_fetch_failed")
 		return
 	}
-	hobbies, err := h.repo.GetHobbies(ctx)
+	hobbies, err := getHobbies(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch hobbies"})
+		problem.WriteCode(c, http.StatusInternalServerError, "hobbies_fetch_failed")
 		return
 	}
 	testimonials, err := h.repo.GetApprovedTestimonials(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch testimonials"})
+		problem.WriteCode(c, http.StatusInternalServerError, "testimonials_fetch_failed")
 		return
 	}
 
@@ -70,25 +86,79 @@ func (h *PortfolioHandler) GetPortfolio(c *gin.Context) {
 		"hobbies":      hobbies,
 		"testimonials": testimonials,
 	}
+
+	// Translatable sections are resolved to the requested language
+	chain := contentLocales(c, h.locales)
+	sections := map[string]string{
+		"projects":   model.AuditEntityProject,
+		"experience": model.AuditEntityExperience,
+		"education":  model.AuditEntityEducation,
+		"hobbies":    model.AuditEntityHobby,
+	}
+	used := map[string]bool{}
+	for key, entityType := range sections {
+		localized, err := localize(entityType, chain, h.locales.Default(), portfolio[key], used)
+		if err != nil {
+			problem.FromError(c, err)
+			return
+		}
+		portfolio[key] = localized
+	}
+	setContentLanguage(c, chain, used)
 	c.JSON(http.StatusOK, portfolio)
 }
 
 // Skills CRUD
 func (h *PortfolioHandler) GetSkills(c *gin.Context) {
-	skills, err := h.repo.GetSkills(c.Request.Context())
+	get := h.repo.GetSkills
+	if inPreview(c) {
+		get = h.repo.GetAllSkills
+	}
+	skills, err := get(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, skills)
 }
 
+// GetAllSkills includes drafts and scheduled items for the admin dashboard.
+func (h *PortfolioHandler) GetAllSkills(c *gin.Context) {
+	skills, err := h.repo.GetAllSkills(c.Request.Context())
+	if err != nil {
+		problem.FromError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, skills)
+}
+
+// GetSkillByID returns a skill entry for editing, with its version as ETag.
+func (h *PortfolioHandler) GetSkillByID(c *gin.Context) {
+	skill, err := h.repo.GetSkillByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "skill_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "skill_fetch_failed")
+		return
+	}
+	setETag(c, skill.Version)
+	c.JSON(http.StatusOK, skill)
+}
+
 func (h *PortfolioHandler) CreateSkill(c *gin.Context) {
 	var req model.CreateSkillRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Validation(c, err)
+		return
+	}
+	publication, err := resolvePublication(req.PublicationRequest, true, time.Now())
+	if err != nil {
+		problem.Validation(c, err)
 		return
 	}
+
 	skill := model.Skill{
 		Name:            req.Name,
 		Icon:            req.Icon,
@@ -96,367 +166,898 @@ func (h *PortfolioHandler) CreateSkill(c *gin.Context) {
 		Category:        req.Category,
 		SortOrder:       req.SortOrder,
 		ShowInPortfolio: req.ShowInPortfolio,
+		Publication:     publication,
 	}
 	createdSkill, err := h.repo.CreateSkill(c.Request.Context(), skill)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionCreate, model.AuditEntitySkill, createdSkill.ID, nil)
+	setETag(c, createdSkill.Version)
 	c.JSON(http.StatusCreated, createdSkill)
 }
 
 func (h *PortfolioHandler) UpdateSkill(c *gin.Context) {
-	id := c.Param("id")
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	var req model.UpdateSkillRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Validation(c, err)
+		return
+	}
+	h.saveSkill(c, c.Param("id"), version, req)
+}
+
+// PatchSkill applies a JSON Merge Patch to a skill.
+func (h *PortfolioHandler) PatchSkill(c *gin.Context) {
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	id := c.Param("id")
+	skill, err := h.repo.GetSkillByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "skill_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "skill_fetch_failed")
+		return
+	}
+	if skill.Version != version {
+		writeVersionedError(c, repository.ErrVersionConflict, "skill_not_found")
+		return
+	}
+
+	req := model.UpdateSkillRequest{
+		Name:            skill.Name,
+		Icon:            skill.Icon,
+		Proficiency:     skill.Proficiency,
+		Category:        skill.Category,
+		SortOrder:       skill.SortOrder,
+		ShowInPortfolio: skill.ShowInPortfolio,
+		PublicationRequest: model.PublicationRequest{
+			Status:    skill.Status,
+			PublishAt: skill.PublishAt,
+		},
+	}
+	if !bindMergePatch(c, &req) {
 		return
 	}
+	h.saveSkill(c, id, version, req)
+}
+
+func (h *PortfolioHandler) saveSkill(c *gin.Context, id string, version int, req model.UpdateSkillRequest) {
+	publication, err := resolvePublication(req.PublicationRequest, false, time.Now())
+	if err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
 	skill := model.Skill{
 		ID:              id,
+		Version:         version,
 		Name:            req.Name,
 		Icon:            req.Icon,
 		Proficiency:     req.Proficiency,
 		Category:        req.Category,
 		SortOrder:       req.SortOrder,
 		ShowInPortfolio: req.ShowInPortfolio,
+		Publication:     publication,
 	}
+	before := snapshotForAudit(c, h.repo, model.AuditEntitySkill, id)
 	updatedSkill, err := h.repo.UpdateSkill(c.Request.Context(), skill)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeVersionedError(c, err, "skill_not_found")
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionUpdate, model.AuditEntitySkill, id, before)
+	recordRevision(c, h.repo, model.AuditEntitySkill, id, before)
+	setETag(c, updatedSkill.Version)
 	c.JSON(http.StatusOK, updatedSkill)
 }
 
 func (h *PortfolioHandler) DeleteSkill(c *gin.Context) {
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	id := c.Param("id")
-	if err := h.repo.DeleteSkill(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	before := snapshotForAudit(c, h.repo, model.AuditEntitySkill, id)
+	if err := h.repo.DeleteSkill(c.Request.Context(), id, version); err != nil {
+		writeVersionedError(c, err, "skill_not_found")
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionDelete, model.AuditEntitySkill, id, before)
 	c.JSON(http.StatusOK, gin.H{"message": "Skill deleted", "id": id})
 }
 
 // Projects CRUD
 func (h *PortfolioHandler) GetProjects(c *gin.Context) {
-	projects, err := h.repo.GetProjects(c.Request.Context())
+	get := h.repo.GetProjects
+	if inPreview(c) {
+		get = h.repo.GetAllProjects
+	}
+	projects, err := get(c.Request.Context())
+	if err != nil {
+		problem.FromError(c, err)
+		return
+	}
+	h.respondLocalized(c, model.AuditEntityProject, projects)
+}
+
+// GetAllProjects includes drafts and scheduled items for the admin dashboard.
+func (h *PortfolioHandler) GetAllProjects(c *gin.Context) {
+	projects, err := h.repo.GetAllProjects(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, projects)
 }
 
+// GetProjectByID returns a project entry for editing, with its version as ETag.
+func (h *PortfolioHandler) GetProjectByID(c *gin.Context) {
+	project, err := h.repo.GetProjectByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "project_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "project_fetch_failed")
+		return
+	}
+	setETag(c, project.Version)
+	c.JSON(http.StatusOK, project)
+}
+
 func (h *PortfolioHandler) CreateProject(c *gin.Context) {
 	var req model.CreateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Validation(c, err)
+		return
+	}
+	publication, err := resolvePublication(req.PublicationRequest, true, time.Now())
+	if err != nil {
+		problem.Validation(c, err)
 		return
 	}
+
+	translations, ok := bindTranslations(c, h.locales, model.AuditEntityProject, &req, nil)
+	if !ok {
+		return
+	}
+
 	project := model.Project{
-		Title:         req.Title,
-		TitleFr:       req.TitleFr,
-		Description:   req.Description,
-		DescriptionFr: req.DescriptionFr,
-		ImageURL:      req.ImageURL,
-		LiveURL:       req.LiveURL,
-		CodeURL:       req.CodeURL,
-		Tags:          req.Tags,
-		Featured:      req.Featured,
-		SortOrder:     req.SortOrder,
+		Title:        req.Title,
+		Description:  req.Description,
+		ImageURL:     req.ImageURL,
+		LiveURL:      req.LiveURL,
+		CodeURL:      req.CodeURL,
+		Tags:         req.Tags,
+		Featured:     req.Featured,
+		SortOrder:    req.SortOrder,
+		Translations: translations,
+		Publication:  publication,
 	}
 	createdProject, err := h.repo.CreateProject(c.Request.Context(), project)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionCreate, model.AuditEntityProject, createdProject.ID, nil)
+	setETag(c, createdProject.Version)
 	c.JSON(http.StatusCreated, createdProject)
 }
 
 func (h *PortfolioHandler) UpdateProject(c *gin.Context) {
-	id := c.Param("id")
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	var req model.UpdateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Validation(c, err)
 		return
 	}
-	project := model.Project{
-		ID:            id,
-		Title:         req.Title,
-		TitleFr:       req.TitleFr,
-		Description:   req.Description,
-		DescriptionFr: req.DescriptionFr,
-		ImageURL:      req.ImageURL,
-		LiveURL:       req.LiveURL,
-		CodeURL:       req.CodeURL,
-		Tags:          req.Tags,
-		Featured:      req.Featured,
-		SortOrder:     req.SortOrder,
+	h.saveProject(c, c.Param("id"), version, req, nil)
+}
+
+// PatchProject applies a JSON Merge Patch to a project.
+func (h *PortfolioHandler) PatchProject(c *gin.Context) {
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
 	}
+
+	id := c.Param("id")
+	project, err := h.repo.GetProjectByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "project_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "project_fetch_failed")
+		return
+	}
+	if project.Version != version {
+		writeVersionedError(c, repository.ErrVersionConflict, "project_not_found")
+		return
+	}
+
+	previous := project.Translations.Omit(model.LegacyLocale)
+	req := model.UpdateProjectRequest{
+		Title:         project.Title,
+		TitleFr:       project.TitleFr,
+		Description:   project.Description,
+		DescriptionFr: project.DescriptionFr,
+		ImageURL:      project.ImageURL,
+		LiveURL:       project.LiveURL,
+		CodeURL:       project.CodeURL,
+		Tags:          project.Tags,
+		Featured:      project.Featured,
+		SortOrder:     project.SortOrder,
+		Translations:  previous,
+		PublicationRequest: model.PublicationRequest{
+			Status:    project.Status,
+			PublishAt: project.PublishAt,
+		},
+	}
+	if !bindMergePatch(c, &req) {
+		return
+	}
+	h.saveProject(c, id, version, req, previous)
+}
+
+func (h *PortfolioHandler) saveProject(c *gin.Context, id string, version int, req model.UpdateProjectRequest, previous model.Translations) {
+	publication, err := resolvePublication(req.PublicationRequest, false, time.Now())
+	if err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	translations, ok := bindTranslations(c, h.locales, model.AuditEntityProject, &req, previous)
+	if !ok {
+		return
+	}
+
+	project := model.Project{
+		ID:           id,
+		Version:      version,
+		Title:        req.Title,
+		Description:  req.Description,
+		ImageURL:     req.ImageURL,
+		LiveURL:      req.LiveURL,
+		CodeURL:      req.CodeURL,
+		Tags:         req.Tags,
+		Featured:     req.Featured,
+		SortOrder:    req.SortOrder,
+		Translations: translations,
+		Publication:  publication,
+	}
+	before := snapshotForAudit(c, h.repo, model.AuditEntityProject, id)
 	updatedProject, err := h.repo.UpdateProject(c.Request.Context(), project)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeVersionedError(c, err, "project_not_found")
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionUpdate, model.AuditEntityProject, id, before)
+	recordRevision(c, h.repo, model.AuditEntityProject, id, before)
+	setETag(c, updatedProject.Version)
 	c.JSON(http.StatusOK, updatedProject)
 }
 
 func (h *PortfolioHandler) DeleteProject(c *gin.Context) {
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	id := c.Param("id")
-	if err := h.repo.DeleteProject(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	before := snapshotForAudit(c, h.repo, model.AuditEntityProject, id)
+	if err := h.repo.DeleteProject(c.Request.Context(), id, version); err != nil {
+		writeVersionedError(c, err, "project_not_found")
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionDelete, model.AuditEntityProject, id, before)
 	c.JSON(http.StatusOK, gin.H{"message": "Project deleted", "id": id})
 }
 
 // Experience CRUD
 func (h *PortfolioHandler) GetExperience(c *gin.Context) {
-	exps, err := h.repo.GetExperiences(c.Request.Context())
+	get := h.repo.GetExperiences
+	if inPreview(c) {
+		get = h.repo.GetAllExperiences
+	}
+	exps, err := get(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
+		return
+	}
+	h.respondLocalized(c, model.AuditEntityExperience, exps)
+}
+
+// GetAllExperience includes drafts and scheduled items for the admin dashboard.
+func (h *PortfolioHandler) GetAllExperience(c *gin.Context) {
+	exps, err := h.repo.GetAllExperiences(c.Request.Context())
+	if err != nil {
+		problem.FromError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, exps)
 }
 
+// GetExperienceByID returns an experience entry for editing, with its version as ETag.
+func (h *PortfolioHandler) GetExperienceByID(c *gin.Context) {
+	exp, err := h.repo.GetExperienceByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "experience_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "experience_fetch_failed")
+		return
+	}
+	setETag(c, exp.Version)
+	c.JSON(http.StatusOK, exp)
+}
+
 func (h *PortfolioHandler) CreateExperience(c *gin.Context) {
 	var req model.CreateExperienceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Validation(c, err)
 		return
 	}
 	
-	startDate, _ := time.Parse("2006-01-02", req.StartDate)
-	var endDate time.Time
-	if req.EndDate != "" {
-		endDate, _ = time.Parse("2006-01-02", req.EndDate)
+	if err := model.ValidateDateRange(req.StartDate, req.EndDate, req.Current); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	publication, err := resolvePublication(req.PublicationRequest, true, time.Now())
+	if err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	translations, ok := bindTranslations(c, h.locales, model.AuditEntityExperience, &req, nil)
+	if !ok {
+		return
 	}
 
 	exp := model.Experience{
-		Title:         req.Title,
-		TitleFr:       req.TitleFr,
-		Company:       req.Company,
-		CompanyFr:     req.CompanyFr,
-		Location:      req.Location,
-		LocationFr:    req.LocationFr,
-		StartDate:     startDate,
-		EndDate:       endDate,
-		Current:       req.Current,
-		Description:   req.Description,
-		DescriptionFr: req.DescriptionFr,
-		SortOrder:     req.SortOrder,
+		Title:        req.Title,
+		Company:      req.Company,
+		Location:     req.Location,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		Current:      req.Current,
+		Description:  req.Description,
+		SortOrder:    req.SortOrder,
+		Translations: translations,
+		Publication:  publication,
 	}
 
 	createdExp, err := h.repo.CreateExperience(c.Request.Context(), exp)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionCreate, model.AuditEntityExperience, createdExp.ID, nil)
+	setETag(c, createdExp.Version)
 	c.JSON(http.StatusCreated, createdExp)
 }
 
 func (h *PortfolioHandler) UpdateExperience(c *gin.Context) {
-	id := c.Param("id")
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	var req model.UpdateExperienceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Validation(c, err)
 		return
 	}
+	h.saveExperience(c, c.Param("id"), version, req, nil)
+}
 
-	var startDate, endDate time.Time
-	if req.StartDate != "" {
-		startDate, _ = time.Parse("2006-01-02", req.StartDate)
+// PatchExperience applies a JSON Merge Patch to an experience entry.
+func (h *PortfolioHandler) PatchExperience(c *gin.Context) {
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
 	}
-	if req.EndDate != "" {
-		endDate, _ = time.Parse("2006-01-02", req.EndDate)
+
+	id := c.Param("id")
+	exp, err := h.repo.GetExperienceByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "experience_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "experience_fetch_failed")
+		return
+	}
+	if exp.Version != version {
+		writeVersionedError(c, repository.ErrVersionConflict, "experience_not_found")
+		return
 	}
 
-	exp := model.Experience{
-		ID:            id,
-		Title:         req.Title,
-		TitleFr:       req.TitleFr,
-		Company:       req.Company,
-		CompanyFr:     req.CompanyFr,
-		Location:      req.Location,
-		LocationFr:    req.LocationFr,
-		StartDate:     startDate,
-		EndDate:       endDate,
-		Current:       req.Current,
-		Description:   req.Description,
-		DescriptionFr: req.DescriptionFr,
-		SortOrder:     req.SortOrder,
+	previous := exp.Translations.Omit(model.LegacyLocale)
+	req := model.UpdateExperienceRequest{
+		Title:         exp.Title,
+		TitleFr:       exp.TitleFr,
+		Company:       exp.Company,
+		CompanyFr:     exp.CompanyFr,
+		Location:      exp.Location,
+		LocationFr:    exp.LocationFr,
+		StartDate:     exp.StartDate,
+		EndDate:       exp.EndDate,
+		Current:       exp.Current,
+		Description:   exp.Description,
+		DescriptionFr: exp.DescriptionFr,
+		SortOrder:     exp.SortOrder,
+		Translations:  previous,
+		PublicationRequest: model.PublicationRequest{
+			Status:    exp.Status,
+			PublishAt: exp.PublishAt,
+		},
 	}
+	if !bindMergePatch(c, &req) {
+		return
+	}
+	h.saveExperience(c, id, version, req, previous)
+}
 
+func (h *PortfolioHandler) saveExperience(c *gin.Context, id string, version int, req model.UpdateExperienceRequest, previous model.Translations) {
+	if err := model.ValidateDateRange(req.StartDate, req.EndDate, req.Current); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	publication, err := resolvePublication(req.PublicationRequest, false, time.Now())
+	if err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	translations, ok := bindTranslations(c, h.locales, model.AuditEntityExperience, &req, previous)
+	if !ok {
+		return
+	}
+
+	exp := model.Experience{
+		ID:           id,
+		Version:      version,
+		Title:        req.Title,
+		Company:      req.Company,
+		Location:     req.Location,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		Current:      req.Current,
+		Description:  req.Description,
+		SortOrder:    req.SortOrder,
+		Translations: translations,
+		Publication:  publication,
+	}
+
+	before := snapshotForAudit(c, h.repo, model.AuditEntityExperience, id)
 	updatedExp, err := h.repo.UpdateExperience(c.Request.Context(), exp)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeVersionedError(c, err, "experience_not_found")
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionUpdate, model.AuditEntityExperience, id, before)
+	recordRevision(c, h.repo, model.AuditEntityExperience, id, before)
+	setETag(c, updatedExp.Version)
 	c.JSON(http.StatusOK, updatedExp)
 }
 
 func (h *PortfolioHandler) DeleteExperience(c *gin.Context) {
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	id := c.Param("id")
-	if err := h.repo.DeleteExperience(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	before := snapshotForAudit(c, h.repo, model.AuditEntityExperience, id)
+	if err := h.repo.DeleteExperience(c.Request.Context(), id, version); err != nil {
+		writeVersionedError(c, err, "experience_not_found")
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionDelete, model.AuditEntityExperience, id, before)
 	c.JSON(http.StatusOK, gin.H{"message": "Experience deleted", "id": id})
 }
 
 // Education CRUD
 func (h *PortfolioHandler) GetEducation(c *gin.Context) {
-	edus, err := h.repo.GetEducation(c.Request.Context())
+	get := h.repo.GetEducation
+	if inPreview(c) {
+		get = h.repo.GetAllEducation
+	}
+	edus, err := get(c.Request.Context())
+	if err != nil {
+		problem.FromError(c, err)
+		return
+	}
+	h.respondLocalized(c, model.AuditEntityEducation, edus)
+}
+
+// GetAllEducation includes drafts and scheduled items for the admin dashboard.
+func (h *PortfolioHandler) GetAllEducation(c *gin.Context) {
+	edus, err := h.repo.GetAllEducation(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, edus)
 }
 
+// GetEducationByID returns an education entry for editing, with its version as ETag.
+func (h *PortfolioHandler) GetEducationByID(c *gin.Context) {
+	edu, err := h.repo.GetEducationByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "education_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "education_fetch_failed")
+		return
+	}
+	setETag(c, edu.Version)
+	c.JSON(http.StatusOK, edu)
+}
+
 func (h *PortfolioHandler) CreateEducation(c *gin.Context) {
 	var req model.CreateEducationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Validation(c, err)
 		return
 	}
 
-	startDate, _ := time.Parse("2006-01-02", req.StartDate)
-	var endDate time.Time
-	if req.EndDate != "" {
-		endDate, _ = time.Parse("2006-01-02", req.EndDate)
+	if err := model.ValidateDateRange(req.StartDate, req.EndDate, false); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	publication, err := resolvePublication(req.PublicationRequest, true, time.Now())
+	if err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	translations, ok := bindTranslations(c, h.locales, model.AuditEntityEducation, &req, nil)
+	if !ok {
+		return
 	}
 
 	edu := model.Education{
-		Degree:        req.Degree,
-		DegreeFr:      req.DegreeFr,
-		School:        req.School,
-		SchoolFr:      req.SchoolFr,
-		Location:      req.Location,
-		LocationFr:    req.LocationFr,
-		StartDate:     startDate,
-		EndDate:       endDate,
-		Description:   req.Description,
-		DescriptionFr: req.DescriptionFr,
-		SortOrder:     req.SortOrder,
+		Degree:       req.Degree,
+		School:       req.School,
+		Location:     req.Location,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		Description:  req.Description,
+		SortOrder:    req.SortOrder,
+		Translations: translations,
+		Publication:  publication,
 	}
 
 	createdEdu, err := h.repo.CreateEducation(c.Request.Context(), edu)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionCreate, model.AuditEntityEducation, createdEdu.ID, nil)
+	setETag(c, createdEdu.Version)
 	c.JSON(http.StatusCreated, createdEdu)
 }
 
 func (h *PortfolioHandler) UpdateEducation(c *gin.Context) {
-	id := c.Param("id")
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	var req model.UpdateEducationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Validation(c, err)
 		return
 	}
+	h.saveEducation(c, c.Param("id"), version, req, nil)
+}
 
-	var startDate, endDate time.Time
-	if req.StartDate != "" {
-		startDate, _ = time.Parse("2006-01-02", req.StartDate)
+// PatchEducation applies a JSON Merge Patch to an education entry.
+func (h *PortfolioHandler) PatchEducation(c *gin.Context) {
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
 	}
-	if req.EndDate != "" {
-		endDate, _ = time.Parse("2006-01-02", req.EndDate)
+
+	id := c.Param("id")
+	edu, err := h.repo.GetEducationByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "education_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "education_fetch_failed")
+		return
+	}
+	if edu.Version != version {
+		writeVersionedError(c, repository.ErrVersionConflict, "education_not_found")
+		return
 	}
 
-	edu := model.Education{
-		ID:            id,
-		Degree:        req.Degree,
-		DegreeFr:      req.DegreeFr,
-		School:        req.School,
-		SchoolFr:      req.SchoolFr,
-		Location:      req.Location,
-		LocationFr:    req.LocationFr,
-		StartDate:     startDate,
-		EndDate:       endDate,
-		Description:   req.Description,
-		DescriptionFr: req.DescriptionFr,
-		SortOrder:     req.SortOrder,
+	previous := edu.Translations.Omit(model.LegacyLocale)
+	req := model.UpdateEducationRequest{
+		Degree:        edu.Degree,
+		DegreeFr:      edu.DegreeFr,
+		School:        edu.School,
+		SchoolFr:      edu.SchoolFr,
+		Location:      edu.Location,
+		LocationFr:    edu.LocationFr,
+		StartDate:     edu.StartDate,
+		EndDate:       edu.EndDate,
+		Description:   edu.Description,
+		DescriptionFr: edu.DescriptionFr,
+		SortOrder:     edu.SortOrder,
+		Translations:  previous,
+		PublicationRequest: model.PublicationRequest{
+			Status:    edu.Status,
+			PublishAt: edu.PublishAt,
+		},
+	}
+	if !bindMergePatch(c, &req) {
+		return
+	}
+	h.saveEducation(c, id, version, req, previous)
+}
+
+func (h *PortfolioHandler) saveEducation(c *gin.Context, id string, version int, req model.UpdateEducationRequest, previous model.Translations) {
+	if err := model.ValidateDateRange(req.StartDate, req.EndDate, false); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	publication, err := resolvePublication(req.PublicationRequest, false, time.Now())
+	if err != nil {
+		problem.Validation(c, err)
+		return
 	}
 
+	translations, ok := bindTranslations(c, h.locales, model.AuditEntityEducation, &req, previous)
+	if !ok {
+		return
+	}
+
+	edu := model.Education{
+		ID:           id,
+		Version:      version,
+		Degree:       req.Degree,
+		School:       req.School,
+		Location:     req.Location,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		Description:  req.Description,
+		SortOrder:    req.SortOrder,
+		Translations: translations,
+		Publication:  publication,
+	}
+
+	before := snapshotForAudit(c, h.repo, model.AuditEntityEducation, id)
 	updatedEdu, err := h.repo.UpdateEducation(c.Request.Context(), edu)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeVersionedError(c, err, "education_not_found")
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionUpdate, model.AuditEntityEducation, id, before)
+	recordRevision(c, h.repo, model.AuditEntityEducation, id, before)
+	setETag(c, updatedEdu.Version)
 	c.JSON(http.StatusOK, updatedEdu)
 }
 
 func (h *PortfolioHandler) DeleteEducation(c *gin.Context) {
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	id := c.Param("id")
-	if err := h.repo.DeleteEducation(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	before := snapshotForAudit(c, h.repo, model.AuditEntityEducation, id)
+	if err := h.repo.DeleteEducation(c.Request.Context(), id, version); err != nil {
+		writeVersionedError(c, err, "education_not_found")
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionDelete, model.AuditEntityEducation, id, before)
 	c.JSON(http.StatusOK, gin.H{"message": "Education deleted", "id": id})
 }
 
 // Hobbies CRUD
 func (h *PortfolioHandler) GetHobbies(c *gin.Context) {
-	hobbies, err := h.repo.GetHobbies(c.Request.Context())
+	get := h.repo.GetHobbies
+	if inPreview(c) {
+		get = h.repo.GetAllHobbies
+	}
+	hobbies, err := get(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
+		return
+	}
+	h.respondLocalized(c, model.AuditEntityHobby, hobbies)
+}
+
+// GetAllHobbies includes drafts and scheduled items for the admin dashboard.
+func (h *PortfolioHandler) GetAllHobbies(c *gin.Context) {
+	hobbies, err := h.repo.GetAllHobbies(c.Request.Context())
+	if err != nil {
+		problem.FromError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, hobbies)
 }
 
+// GetHobbyByID returns a hobby entry for editing, with its version as ETag.
+func (h *PortfolioHandler) GetHobbyByID(c *gin.Context) {
+	hobby, err := h.repo.GetHobbyByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "hobby_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "hobby_fetch_failed")
+		return
+	}
+	setETag(c, hobby.Version)
+	c.JSON(http.StatusOK, hobby)
+}
+
 func (h *PortfolioHandler) CreateHobby(c *gin.Context) {
 	var req model.CreateHobbyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Validation(c, err)
+		return
+	}
+	publication, err := resolvePublication(req.PublicationRequest, true, time.Now())
+	if err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	translations, ok := bindTranslations(c, h.locales, model.AuditEntityHobby, &req, nil)
+	if !ok {
 		return
 	}
+
 	hobby := model.Hobby{
-		Name:        req.Name,
-		Icon:        req.Icon,
-		Description: req.Description,
-		SortOrder:   req.SortOrder,
+		Name:         req.Name,
+		Icon:         req.Icon,
+		Description:  req.Description,
+		SortOrder:    req.SortOrder,
+		Translations: translations,
+		Publication:  publication,
 	}
 	createdHobby, err := h.repo.CreateHobby(c.Request.Context(), hobby)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionCreate, model.AuditEntityHobby, createdHobby.ID, nil)
+	setETag(c, createdHobby.Version)
 	c.JSON(http.StatusCreated, createdHobby)
 }
 
 func (h *PortfolioHandler) UpdateHobby(c *gin.Context) {
-	id := c.Param("id")
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	var req model.UpdateHobbyRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Validation(c, err)
 		return
 	}
-	hobby := model.Hobby{
-		ID:          id,
-		Name:        req.Name,
-		Icon:        req.Icon,
-		Description: req.Description,
-		SortOrder:   req.SortOrder,
+	h.saveHobby(c, c.Param("id"), version, req, nil)
+}
+
+// PatchHobby applies a JSON Merge Patch to a hobby.
+func (h *PortfolioHandler) PatchHobby(c *gin.Context) {
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	id := c.Param("id")
+	hobby, err := h.repo.GetHobbyByID(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "hobby_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "hobby_fetch_failed")
+		return
+	}
+	if hobby.Version != version {
+		writeVersionedError(c, repository.ErrVersionConflict, "hobby_not_found")
+		return
+	}
+
+	previous := hobby.Translations.Omit(model.LegacyLocale)
+	req := model.UpdateHobbyRequest{
+		Name:          hobby.Name,
+		NameFr:        hobby.NameFr,
+		Icon:          hobby.Icon,
+		Description:   hobby.Description,
+		DescriptionFr: hobby.DescriptionFr,
+		SortOrder:     hobby.SortOrder,
+		Translations:  previous,
+		PublicationRequest: model.PublicationRequest{
+			Status:    hobby.Status,
+			PublishAt: hobby.PublishAt,
+		},
+	}
+	if !bindMergePatch(c, &req) {
+		return
+	}
+	h.saveHobby(c, id, version, req, previous)
+}
+
+func (h *PortfolioHandler) saveHobby(c *gin.Context, id string, version int, req model.UpdateHobbyRequest, previous model.Translations) {
+	publication, err := resolvePublication(req.PublicationRequest, false, time.Now())
+	if err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	translations, ok := bindTranslations(c, h.locales, model.AuditEntityHobby, &req, previous)
+	if !ok {
+		return
 	}
+
+	hobby := model.Hobby{
+		ID:           id,
+		Version:      version,
+		Name:         req.Name,
+		Icon:         req.Icon,
+		Description:  req.Description,
+		SortOrder:    req.SortOrder,
+		Translations: translations,
+		Publication:  publication,
+	}
+	before := snapshotForAudit(c, h.repo, model.AuditEntityHobby, id)
 	updatedHobby, err := h.repo.UpdateHobby(c.Request.Context(), hobby)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeVersionedError(c, err, "hobby_not_found")
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionUpdate, model.AuditEntityHobby, id, before)
+	recordRevision(c, h.repo, model.AuditEntityHobby, id, before)
+	setETag(c, updatedHobby.Version)
 	c.JSON(http.StatusOK, updatedHobby)
 }
 
 func (h *PortfolioHandler) DeleteHobby(c *gin.Context) {
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	id := c.Param("id")
-	if err := h.repo.DeleteHobby(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	before := snapshotForAudit(c, h.repo, model.AuditEntityHobby, id)
+	if err := h.repo.DeleteHobby(c.Request.Context(), id, version); err != nil {
+		writeVersionedError(c, err, "hobby_not_found")
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionDelete, model.AuditEntityHobby, id, before)
 	c.JSON(http.StatusOK, gin.H{"message": "Hobby deleted", "id": id})
 }
 
@@ -464,7 +1065,7 @@ func (h *PortfolioHandler) DeleteHobby(c *gin.Context) {
 func (h *PortfolioHandler) GetApprovedTestimonials(c *gin.Context) {
 	testimonials, err := h.repo.GetApprovedTestimonials(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, testimonials)
@@ -473,7 +1074,7 @@ func (h *PortfolioHandler) GetApprovedTestimonials(c *gin.Context) {
 func (h *PortfolioHandler) GetAllTestimonials(c *gin.Context) {
 	testimonials, err := h.repo.GetAllTestimonials(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, testimonials)
@@ -482,7 +1083,7 @@ func (h *PortfolioHandler) GetAllTestimonials(c *gin.Context) {
 func (h *PortfolioHandler) SubmitTestimonial(c *gin.Context) {
 	var req model.CreateTestimonialRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.Validation(c, err)
 		return
 	}
 	
@@ -497,7 +1098,7 @@ func (h *PortfolioHandler) SubmitTestimonial(c *gin.Context) {
 
 	createdTestimonial, err := h.repo.CreateTestimonial(c.Request.Context(), testimonial)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, createdTestimonial)
@@ -505,28 +1106,46 @@ func (h *PortfolioHandler) SubmitTestimonial(c *gin.Context) {
 
 func (h *PortfolioHandler) ApproveTestimonial(c *gin.Context) {
 	id := c.Param("id")
+	before := snapshotForAudit(c, h.repo, model.AuditEntityTestimonial, id)
 	if err := h.repo.UpdateTestimonialStatus(c.Request.Context(), id, "approved"); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "testimonial_not_found")
+			return
+		}
+		problem.FromError(c, err)
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionApprove, model.AuditEntityTestimonial, id, before)
 	c.JSON(http.StatusOK, gin.H{"message": "Testimonial approved", "id": id, "status": "approved"})
 }
 
 func (h *PortfolioHandler) RejectTestimonial(c *gin.Context) {
 	id := c.Param("id")
+	before := snapshotForAudit(c, h.repo, model.AuditEntityTestimonial, id)
 	if err := h.repo.UpdateTestimonialStatus(c.Request.Context(), id, "rejected"); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "testimonial_not_found")
+			return
+		}
+		problem.FromError(c, err)
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionReject, model.AuditEntityTestimonial, id, before)
 	c.JSON(http.StatusOK, gin.H{"message": "Testimonial rejected", "id": id, "status": "rejected"})
 }
 
 func (h *PortfolioHandler) DeleteTestimonial(c *gin.Context) {
 	id := c.Param("id")
+	before := snapshotForAudit(c, h.repo, model.AuditEntityTestimonial, id)
 	if err := h.repo.DeleteTestimonial(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "testimonial_not_found")
+			return
+		}
+		problem.FromError(c, err)
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionDelete, model.AuditEntityTestimonial, id, before)
 	c.JSON(http.StatusOK, gin.H{"message": "Testimonial deleted", "id": id})
 }
 
@@ -534,19 +1153,7 @@ func (h *PortfolioHandler) DeleteTestimonial(c *gin.Context) {
 func (h *PortfolioHandler) SubmitMessage(c *gin.Context) {
 	var req model.CreateMessageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		errorText := err.Error()
-		switch {
-		case strings.Contains(errorText, "CreateMessageRequest.Name"):
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Please enter your name (at least 2 characters)."})
-		case strings.Contains(errorText, "CreateMessageRequest.Email"):
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Please enter a valid email address."})
-		case strings.Contains(errorText, "CreateMessageRequest.Content") && strings.Contains(errorText, "min"):
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Message must be at least 10 characters."})
-		case strings.Contains(errorText, "CreateMessageRequest.Content"):
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Please enter a valid message."})
-		default:
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid message payload."})
-		}
+		problem.Validation(c, err)
 		return
 	}
 
@@ -556,18 +1163,18 @@ func (h *PortfolioHandler) SubmitMessage(c *gin.Context) {
 	}
 
 	now := time.Now().UTC()
-	if !h.messageProtection.Allow(c.ClientIP(), now) {
-		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many contact attempts. Please try again later."})
+	if !h.messageProtection.Allow(c.Request.Context(), c.ClientIP(), now) {
+		problem.WriteCode(c, http.StatusTooManyRequests, "contact_rate_limited")
 		return
 	}
 
 	if h.messageProtection.IsSubmissionTooFast(req.SubmittedAtMs, now) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Please wait a moment before submitting the form."})
+		problem.WriteCode(c, http.StatusBadRequest, "form_submitted_too_fast")
 		return
 	}
 
 	if err := h.messageProtection.VerifyTurnstile(c.Request.Context(), req.TurnstileToken, c.ClientIP()); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Captcha verification failed."})
+		problem.WriteCode(c, http.StatusBadRequest, "captcha_failed")
 		return
 	}
 
@@ -583,7 +1190,7 @@ func (h *PortfolioHandler) SubmitMessage(c *gin.Context) {
 		h.messageProtection.DuplicateWindow(),
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate duplicate message"})
+		problem.WriteCode(c, http.StatusInternalServerError, "duplicate_check_failed")
 		return
 	}
 	if isDuplicate {
@@ -602,7 +1209,7 @@ func (h *PortfolioHandler) SubmitMessage(c *gin.Context) {
 
 	createdMsg, err := h.repo.CreateMessage(c.Request.Context(), msg)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
 		return
 	}
 	c.JSON(http.StatusCreated, createdMsg)
@@ -611,7 +1218,7 @@ func (h *PortfolioHandler) SubmitMessage(c *gin.Context) {
 func (h *PortfolioHandler) GetMessages(c *gin.Context) {
 	messages, err := h.repo.GetMessages(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.FromError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, messages)
@@ -619,19 +1226,31 @@ func (h *PortfolioHandler) GetMessages(c *gin.Context) {
 
 func (h *PortfolioHandler) MarkMessageRead(c *gin.Context) {
 	id := c.Param("id")
+	before := snapshotForAudit(c, h.repo, model.AuditEntityMessage, id)
 	if err := h.repo.MarkMessageRead(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "message_not_found")
+			return
+		}
+		problem.FromError(c, err)
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionMarkRead, model.AuditEntityMessage, id, before)
 	c.JSON(http.StatusOK, gin.H{"message": "Message marked as read", "id": id})
 }
 
 func (h *PortfolioHandler) DeleteMessage(c *gin.Context) {
 	id := c.Param("id")
+	before := snapshotForAudit(c, h.repo, model.AuditEntityMessage, id)
 	if err := h.repo.DeleteMessage(c.Request.Context(), id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "message_not_found")
+			return
+		}
+		problem.FromError(c, err)
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionDelete, model.AuditEntityMessage, id, before)
 	c.JSON(http.StatusOK, gin.H{"message": "Message deleted", "id": id})
 }
 
@@ -639,30 +1258,30 @@ func (h *PortfolioHandler) DeleteMessage(c *gin.Context) {
 func (h *PortfolioHandler) GetContactInfo(c *gin.Context) {
 	info, err := h.repo.GetContactInfo(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch contact info"})
+		problem.WriteCode(c, http.StatusInternalServerError, "contact_info_fetch_failed")
 		return
 	}
-	c.JSON(http.StatusOK, info)
+	h.respondLocalized(c, model.AuditEntityContactInfo, info)
 }
 
 // Resume Handlers
 func (h *PortfolioHandler) UploadResume(c *gin.Context) {
 	lang := c.Query("lang")
 	if lang != "en" && lang != "fr" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid language. Must be 'en' or 'fr'"})
+		problem.WriteCode(c, http.StatusBadRequest, "invalid_language")
 		return
 	}
 
 	file, err := c.FormFile("resume")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		problem.WriteCode(c, http.StatusBadRequest, "file_missing")
 		return
 	}
 
 	// Validate file extension
 	ext := filepath.Ext(file.Filename)
 	if strings.ToLower(ext) != ".pdf" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file type. Only PDF files are allowed."})
+		problem.WriteCode(c, http.StatusBadRequest, "file_type_not_pdf")
 		return
 	}
 
@@ -675,9 +1294,10 @@ func (h *PortfolioHandler) UploadResume(c *gin.Context) {
 	filepath := "./uploads/" + filename
 
 	if err := c.SaveUploadedFile(file, filepath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+		problem.WriteCode(c, http.StatusInternalServerError, "file_save_failed")
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionUpload, model.AuditEntityResume, lang, nil)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Resume uploaded successfully", "lang": lang})
 }
@@ -689,7 +1309,7 @@ func (h *PortfolioHandler) GetResume(c *gin.Context) {
 	}
 	
 	if lang != "en" && lang != "fr" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid language. Must be 'en' or 'fr'"})
+		problem.WriteCode(c, http.StatusBadRequest, "invalid_language")
 		return
 	}
 
@@ -698,15 +1318,7 @@ func (h *PortfolioHandler) GetResume(c *gin.Context) {
 
 	// Check if file exists
 	if _, err := os.Stat(targetPath); os.IsNotExist(err) {
-		pwd, _ := os.Getwd()
-		absPath, _ := filepath.Abs(targetPath)
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":    "Resume not found",
-			"rel_path": targetPath,
-			"abs_path": absPath,
-			"pwd":      pwd,
-			"os_err":   err.Error(),
-		})
+		problem.WriteCode(c, http.StatusNotFound, "resume_not_found")
 		return
 	}
 
@@ -716,7 +1328,7 @@ func (h *PortfolioHandler) GetResume(c *gin.Context) {
 func (h *PortfolioHandler) UploadProfilePicture(c *gin.Context) {
 	file, err := c.FormFile("profile_picture")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		problem.WriteCode(c, http.StatusBadRequest, "file_missing")
 		return
 	}
 
@@ -743,9 +1355,10 @@ func (h *PortfolioHandler) UploadProfilePicture(c *gin.Context) {
 	filepath := filepath.Join(uploadDir, filename)
 
 	if err := c.SaveUploadedFile(file, filepath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+		problem.WriteCode(c, http.StatusInternalServerError, "file_save_failed")
 		return
 	}
+	recordAudit(c, h.repo, model.AuditActionUpload, model.AuditEntityProfilePicture, filename, nil)
 
 	c.JSON(http.StatusOK, gin.H{"message": "Profile picture uploaded successfully", "filename": filename})
 }
@@ -768,7 +1381,7 @@ func (h *PortfolioHandler) GetProfilePicture(c *gin.Context) {
 	}
 
 	if !found {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Profile picture not found"})
+		problem.WriteCode(c, http.StatusNotFound, "profile_picture_not_found")
 		return
 	}
 
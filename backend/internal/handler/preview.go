@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/middleware"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/problem"
+)
+
+const defaultPreviewHours = 24
+
+// CreatePreviewToken mints a signed, expiring token for sharing a preview of
+// the portfolio with drafts included. The public site passes it back through
+// the ?preview= query parameter or the X-Preview-Token header.
+func (h *AdminHandler) CreatePreviewToken(c *gin.Context) {
+	var req model.CreatePreviewTokenRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Validation(c, err)
+			return
+		}
+	}
+	hours := req.ExpiresInHours
+	if hours == 0 {
+		hours = defaultPreviewHours
+	}
+
+	admin, err := h.repo.GetAdminByID(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "admin_load_failed")
+		return
+	}
+
+	token, expiresAt, err := middleware.GeneratePreviewToken(&admin, time.Duration(hours)*time.Hour)
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "preview_token_create_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, model.PreviewTokenResponse{Token: token, ExpiresAt: expiresAt})
+}
+
+// inPreview reports whether PreviewMiddleware accepted a preview token.
+func inPreview(c *gin.Context) bool {
+	return c.GetBool("preview")
+}
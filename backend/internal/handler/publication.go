@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/portfolio/backend/internal/model"
+)
+
+var errPublishAtRequired = &model.FieldError{Field: "publishAt", Code: "required", Message: "is required for scheduled content"}
+
+// resolvePublication turns the status and publishAt of a create/update request
+// into what is stored. Published content records when it went live, and a
+// schedule that is already due is published straight away. On update an empty
+// status is passed through so the repository keeps the current state.
+func resolvePublication(req model.PublicationRequest, creating bool, now time.Time) (model.Publication, error) {
+	status := req.Status
+	if status == "" {
+		if !creating {
+			return model.Publication{}, nil
+		}
+		status = model.StatusPublished
+	}
+
+	switch status {
+	case model.StatusDraft:
+		return model.Publication{Status: model.StatusDraft}, nil
+	case model.StatusScheduled:
+		if req.PublishAt == nil {
+			return model.Publication{}, errPublishAtRequired
+		}
+		if req.PublishAt.After(now) {
+			return model.Publication{Status: model.StatusScheduled, PublishAt: req.PublishAt}, nil
+		}
+		return model.Publication{Status: model.StatusPublished, PublishAt: req.PublishAt}, nil
+	default:
+		publishAt := req.PublishAt
+		if publishAt == nil {
+			publishAt = &now
+		}
+		return model.Publication{Status: model.StatusPublished, PublishAt: publishAt}, nil
+	}
+}
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/problem"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// currentRevision names the live row in diff requests.
+const currentRevision = "current"
+
+// diffIgnoredFields change on every write and would drown out real changes.
+var diffIgnoredFields = map[string]bool{"updated_at": true}
+
+// recordRevision keeps before, the row as it was prior to an update, so the
+// update can be rolled back later. Failures are logged, not surfaced.
+func recordRevision(c *gin.Context, repo repository.RevisionStore, entityType, id string, before json.RawMessage) {
+	if before == nil || !repository.IsRevisioned(entityType) {
+		return
+	}
+
+	var actorID *string
+	if userID := c.GetString("userID"); userID != "" {
+		actorID = &userID
+	}
+	if err := repo.CreateRevision(c.Request.Context(), entityType, id, before, actorID); err != nil {
+		log.Printf("Failed to save revision of %s %s: %v", entityType, id, err)
+	}
+}
+
+func (h *AdminHandler) GetRevisions(c *gin.Context) {
+	entityType, entityID := c.Param("entityType"), c.Param("entityId")
+	if !repository.IsRevisioned(entityType) {
+		problem.WriteCode(c, http.StatusNotFound, "unknown_entity_type")
+		return
+	}
+
+	revisions, err := h.repo.GetRevisions(c.Request.Context(), entityType, entityID)
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "revisions_fetch_failed")
+		return
+	}
+	c.JSON(http.StatusOK, revisions)
+}
+
+// DiffRevisions compares revision ?from= with revision ?to=. Either may be
+// "current" for the live row; to defaults to "current".
+func (h *AdminHandler) DiffRevisions(c *gin.Context) {
+	entityType, entityID := c.Param("entityType"), c.Param("entityId")
+	if !repository.IsRevisioned(entityType) {
+		problem.WriteCode(c, http.StatusNotFound, "unknown_entity_type")
+		return
+	}
+
+	from := c.Query("from")
+	if from == "" {
+		problem.WriteCode(c, http.StatusBadRequest, "revision_from_required")
+		return
+	}
+	to := c.DefaultQuery("to", currentRevision)
+
+	fromSnapshot, ok := h.loadRevisionSnapshot(c, entityType, entityID, from)
+	if !ok {
+		return
+	}
+	toSnapshot, ok := h.loadRevisionSnapshot(c, entityType, entityID, to)
+	if !ok {
+		return
+	}
+
+	changes, err := diffSnapshots(fromSnapshot, toSnapshot)
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "revision_compare_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, model.RevisionDiff{
+		EntityType: entityType,
+		EntityID:   entityID,
+		From:       from,
+		To:         to,
+		Changes:    changes,
+	})
+}
+
+// RestoreRevision writes an earlier revision back onto the live row, provided
+// it is still at the version in If-Match. The state it replaces becomes a new
+// revision, so a restore can itself be undone.
+func (h *AdminHandler) RestoreRevision(c *gin.Context) {
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	entityType, entityID := c.Param("entityType"), c.Param("entityId")
+	if !repository.IsRevisioned(entityType) {
+		problem.WriteCode(c, http.StatusNotFound, "unknown_entity_type")
+		return
+	}
+
+	number, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		problem.WriteCode(c, http.StatusBadRequest, "invalid_revision_number")
+		return
+	}
+	revision, err := h.repo.GetRevision(c.Request.Context(), entityType, entityID, number)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "revision_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "revision_fetch_failed")
+		return
+	}
+
+	before := snapshotForAudit(c, h.repo, entityType, entityID)
+	if err := h.repo.RestoreSnapshot(c.Request.Context(), entityType, entityID, version, revision.Snapshot); err != nil {
+		writeVersionedError(c, err, "entity_gone")
+		return
+	}
+	recordRevision(c, h.repo, entityType, entityID, before)
+	recordAudit(c, h.repo, model.AuditActionRestore, entityType, entityID, before)
+	setETag(c, version+1)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Revision restored",
+		"revision": revision.Revision,
+		"entity":   snapshotForAudit(c, h.repo, entityType, entityID),
+	})
+}
+
+// loadRevisionSnapshot resolves a revision number or "current" to a snapshot,
+// writing the error response itself when it cannot.
+func (h *AdminHandler) loadRevisionSnapshot(c *gin.Context, entityType, entityID, ref string) (json.RawMessage, bool) {
+	ctx := c.Request.Context()
+
+	if ref == currentRevision {
+		snapshot, err := h.repo.SnapshotEntity(ctx, entityType, entityID)
+		if err != nil {
+			problem.WriteCode(c, http.StatusInternalServerError, "entity_fetch_failed")
+			return nil, false
+		}
+		if snapshot == nil {
+			problem.WriteCode(c, http.StatusNotFound, "entity_not_found")
+			return nil, false
+		}
+		return snapshot, true
+	}
+
+	number, err := strconv.Atoi(ref)
+	if err != nil {
+		problem.WriteCode(c, http.StatusBadRequest, "invalid_revision_ref")
+		return nil, false
+	}
+	revision, err := h.repo.GetRevision(ctx, entityType, entityID, number)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCodeParam(c, http.StatusNotFound, "revision_ref_not_found", ref)
+			return nil, false
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "revision_fetch_failed")
+		return nil, false
+	}
+	return revision.Snapshot, true
+}
+
+// diffSnapshots lists the top-level fields whose values differ, sorted by name.
+func diffSnapshots(from, to json.RawMessage) ([]model.FieldChange, error) {
+	var fromFields, toFields map[string]json.RawMessage
+	if err := json.Unmarshal(from, &fromFields); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(to, &toFields); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]bool, len(fromFields))
+	for field := range fromFields {
+		fields[field] = true
+	}
+	for field := range toFields {
+		fields[field] = true
+	}
+
+	changes := []model.FieldChange{}
+	for field := range fields {
+		if diffIgnoredFields[field] || bytes.Equal(fromFields[field], toFields[field]) {
+			continue
+		}
+		changes = append(changes, model.FieldChange{Field: field, From: fromFields[field], To: toFields[field]})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/locale"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/problem"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// bindTranslations collects and validates the translations carried by req.
+// For a merge patch, previous holds the translations req started from: those
+// the patch removed are returned as empty values so they are deleted. It
+// writes the error response itself and returns false when they are invalid.
+func bindTranslations(c *gin.Context, locales locale.Settings, entityType string, req any, previous model.Translations) (model.Translations, bool) {
+	translations := model.CollectTranslations(req)
+	for l, fields := range previous {
+		for field := range fields {
+			if _, kept := translations[l][field]; !kept {
+				translations.Set(l, field, "")
+			}
+		}
+	}
+
+	if err := validateTranslations(locales, entityType, translations); err != nil {
+		problem.Validation(c, err)
+		return nil, false
+	}
+	return translations, true
+}
+
+// validateTranslations checks that every translation is for a translatable
+// field and, unless it clears the field, for a translated locale and in the
+// field's shape.
+func validateTranslations(locales locale.Settings, entityType string, t model.Translations) error {
+	kinds := model.TranslatableFields[entityType]
+	translated := locales.Translated()
+	for l, fields := range t {
+		for field, value := range fields {
+			name := "translations." + l + "." + field
+			kind, ok := kinds[field]
+			if !ok {
+				return &model.FieldError{Field: name, Code: "unknown_field", Message: "is not a translatable field"}
+			}
+			// Clearing is always allowed, so the flat French fields can be sent
+			// empty even when French is not configured.
+			if model.IsEmptyTranslation(value) {
+				continue
+			}
+			if !slices.Contains(translated, l) {
+				return &model.FieldError{Field: "translations." + l, Code: "unsupported_locale", Message: "is not a supported translation locale"}
+			}
+			valid := false
+			switch kind {
+			case model.TextField:
+				_, valid = value.(string)
+			case model.ListField:
+				valid = t.List(l, field) != nil
+			}
+			if !valid {
+				return &model.FieldError{Field: name, Code: "invalid_type", Message: "has an invalid value"}
+			}
+		}
+	}
+	return nil
+}
+
+// GetTranslationStatus reports translation coverage per locale, with the
+// fields that lack a translation or whose translation is stale. ?locale=
+// narrows the report to one locale.
+func (h *AdminHandler) GetTranslationStatus(c *gin.Context) {
+	locales := h.locales.Translated()
+	if l := c.Query("locale"); l != "" {
+		if !slices.Contains(locales, l) {
+			problem.WriteCode(c, http.StatusBadRequest, "unknown_locale")
+			return
+		}
+		locales = []string{l}
+	}
+
+	status, err := h.repo.TranslationStatus(c.Request.Context(), locales)
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "translation_status_fetch_failed")
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// PrefillTranslations machine-translates the fields of a project, experience
+// or education entry that have no translation in ?locale= (French by
+// default). The new translations are flagged as needing review until an
+// editor changes them; existing translations are left alone.
+func (h *AdminHandler) PrefillTranslations(c *gin.Context) {
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+	if h.translator == nil {
+		problem.WriteCode(c, http.StatusServiceUnavailable, "machine_translation_unavailable")
+		return
+	}
+
+	target := c.DefaultQuery("locale", model.LegacyLocale)
+	if !slices.Contains(h.locales.Translated(), target) {
+		problem.WriteCode(c, http.StatusBadRequest, "unknown_locale")
+		return
+	}
+
+	entityType, id := c.Param("entityType"), c.Param("entityId")
+	entity, err := h.getTranslatable(c.Request.Context(), entityType, id)
+	if err != nil {
+		if errors.Is(err, errNotTranslatable) {
+			problem.WriteCode(c, http.StatusNotFound, "machine_translation_unsupported")
+			return
+		}
+		writeVersionedError(c, err, "entity_not_found")
+		return
+	}
+	if entity.version != version {
+		writeVersionedError(c, repository.ErrVersionConflict, "entity_not_found")
+		return
+	}
+
+	// Gather the source text of every untranslated field into one request,
+	// list items included.
+	source := h.locales.Default()
+	type span struct {
+		field      string
+		kind       model.FieldKind
+		start, end int
+	}
+	var texts []string
+	var spans []span
+	for field, kind := range model.TranslatableFields[entityType] {
+		if !model.IsEmptyTranslation(entity.translations[target][field]) {
+			continue
+		}
+		start := len(texts)
+		switch kind {
+		case model.TextField:
+			if text := entity.source.Text(source, field); text != "" {
+				texts = append(texts, text)
+			}
+		case model.ListField:
+			texts = append(texts, entity.source.List(source, field)...)
+		}
+		if len(texts) > start {
+			spans = append(spans, span{field, kind, start, len(texts)})
+		}
+	}
+	if len(texts) == 0 {
+		setETag(c, entity.version)
+		c.JSON(http.StatusOK, entity.value)
+		return
+	}
+
+	translated, err := h.translator.Translate(c.Request.Context(), texts, source, target)
+	if err != nil {
+		log.Printf("Failed to machine-translate %s %s to %s: %v", entityType, id, target, err)
+		problem.WriteCode(c, http.StatusBadGateway, "machine_translation_failed")
+		return
+	}
+	drafts := model.Translations{}
+	for _, s := range spans {
+		if s.kind == model.ListField {
+			drafts.Set(target, s.field, translated[s.start:s.end])
+		} else {
+			drafts.Set(target, s.field, translated[s.start])
+		}
+	}
+
+	before := snapshotForAudit(c, h.repo, entityType, id)
+	if err := h.repo.AddMachineTranslations(c.Request.Context(), entityType, id, version, drafts); err != nil {
+		writeVersionedError(c, err, "entity_not_found")
+		return
+	}
+	recordAudit(c, h.repo, model.AuditActionUpdate, entityType, id, before)
+	recordRevision(c, h.repo, entityType, id, before)
+
+	updated, err := h.getTranslatable(c.Request.Context(), entityType, id)
+	if err != nil {
+		problem.FromError(c, err)
+		return
+	}
+	setETag(c, updated.version)
+	c.JSON(http.StatusOK, updated.value)
+}
+
+// MarkTranslationsReviewed is for translations an editor checked and kept as
+// they are: saving unchanged text leaves them stale and flagged for review,
+// so this records them as up to date with the current source text instead.
+// ?locale= picks the locale (French by default).
+func (h *AdminHandler) MarkTranslationsReviewed(c *gin.Context) {
+	var req model.MarkTranslationsReviewedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Validation(c, err)
+		return
+	}
+
+	target := c.DefaultQuery("locale", model.LegacyLocale)
+	if !slices.Contains(h.locales.Translated(), target) {
+		problem.WriteCode(c, http.StatusBadRequest, "unknown_locale")
+		return
+	}
+
+	entityType, id := c.Param("entityType"), c.Param("entityId")
+	kinds, ok := model.TranslatableFields[entityType]
+	if !ok {
+		problem.WriteCode(c, http.StatusNotFound, "unknown_entity_type")
+		return
+	}
+	for _, field := range req.Fields {
+		if _, ok := kinds[field]; !ok {
+			problem.Validation(c, &model.FieldError{Field: "fields", Code: "unknown_field", Message: "is not a translatable field"})
+			return
+		}
+	}
+
+	before := snapshotForAudit(c, h.repo, entityType, id)
+	if err := h.repo.MarkTranslationsReviewed(c.Request.Context(), entityType, id, target, req.Fields); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "translation_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "translation_review_failed")
+		return
+	}
+	recordAudit(c, h.repo, model.AuditActionUpdate, entityType, id, before)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Translations marked as reviewed", "locale": target, "fields": req.Fields})
+}
+
+var errNotTranslatable = errors.New("entity type cannot be machine-translated")
+
+// translatable is an entity loaded for machine translation: the entity
+// itself, its version and translations, and its untranslated field values
+// under the default locale.
+type translatable struct {
+	value        any
+	version      int
+	translations model.Translations
+	source       model.Translations
+}
+
+func (h *AdminHandler) getTranslatable(ctx context.Context, entityType, id string) (translatable, error) {
+	var entity translatable
+	switch entityType {
+	case model.AuditEntityProject:
+		project, err := h.repo.GetProjectByID(ctx, id)
+		if err != nil {
+			return entity, err
+		}
+		entity = translatable{value: project, version: project.Version, translations: project.Translations}
+	case model.AuditEntityExperience:
+		exp, err := h.repo.GetExperienceByID(ctx, id)
+		if err != nil {
+			return entity, err
+		}
+		entity = translatable{value: exp, version: exp.Version, translations: exp.Translations}
+	case model.AuditEntityEducation:
+		edu, err := h.repo.GetEducationByID(ctx, id)
+		if err != nil {
+			return entity, err
+		}
+		entity = translatable{value: edu, version: edu.Version, translations: edu.Translations}
+	default:
+		return entity, errNotTranslatable
+	}
+
+	encoded, err := json.Marshal(entity.value)
+	if err != nil {
+		return entity, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return entity, err
+	}
+	entity.source = model.Translations{}
+	for field := range model.TranslatableFields[entityType] {
+		entity.source.Set(h.locales.Default(), field, fields[field])
+	}
+	return entity, nil
+}
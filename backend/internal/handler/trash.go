@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/auth"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/problem"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// trashPermissions maps each trashable entity type to the permission that
+// lets a user delete it; the same permission is needed to see or restore it.
+var trashPermissions = map[string]auth.Permission{
+	model.AuditEntitySkill:       auth.PermContentDelete,
+	model.AuditEntityProject:     auth.PermContentDelete,
+	model.AuditEntityExperience:  auth.PermContentDelete,
+	model.AuditEntityEducation:   auth.PermContentDelete,
+	model.AuditEntityHobby:       auth.PermContentDelete,
+	model.AuditEntityTestimonial: auth.PermTestimonialsModerate,
+	model.AuditEntityMessage:     auth.PermMessagesManage,
+}
+
+// GetTrash lists trashed items the caller could have deleted. ?type= narrows
+// the list to one entity type.
+func (h *AdminHandler) GetTrash(c *gin.Context) {
+	role := c.GetString("role")
+
+	var entityTypes []string
+	if entityType := c.Query("type"); entityType != "" {
+		perm, ok := trashPermissions[entityType]
+		if !ok {
+			problem.WriteCode(c, http.StatusBadRequest, "unknown_entity_type")
+			return
+		}
+		if !auth.RoleHasPermission(role, perm) {
+			problem.WriteCode(c, http.StatusForbidden, "permission_denied")
+			return
+		}
+		entityTypes = []string{entityType}
+	} else {
+		for _, entityType := range repository.TrashEntityTypes {
+			if auth.RoleHasPermission(role, trashPermissions[entityType]) {
+				entityTypes = append(entityTypes, entityType)
+			}
+		}
+	}
+
+	items, err := h.repo.GetTrash(c.Request.Context(), entityTypes)
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "trash_fetch_failed")
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+func (h *AdminHandler) RestoreFromTrash(c *gin.Context) {
+	entityType, id := c.Param("entityType"), c.Param("id")
+	perm, ok := trashPermissions[entityType]
+	if !ok {
+		problem.WriteCode(c, http.StatusNotFound, "unknown_entity_type")
+		return
+	}
+	if !auth.RoleHasPermission(c.GetString("role"), perm) {
+		problem.WriteCode(c, http.StatusForbidden, "permission_denied")
+		return
+	}
+
+	before := snapshotForAudit(c, h.repo, entityType, id)
+	if err := h.repo.RestoreFromTrash(c.Request.Context(), entityType, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			problem.WriteCode(c, http.StatusNotFound, "trash_item_not_found")
+			return
+		}
+		problem.WriteCode(c, http.StatusInternalServerError, "trash_restore_failed")
+		return
+	}
+	recordAudit(c, h.repo, model.AuditActionRestore, entityType, id, before)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Item restored", "entityType": entityType, "id": id})
+}
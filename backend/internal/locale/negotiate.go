@@ -0,0 +1,68 @@
+package locale
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Negotiate picks the best of supported for an Accept-Language header value,
+// honouring q-values and matching "fr-CA" to "fr". It returns supported[0]
+// when nothing matches.
+func Negotiate(acceptLanguage string, supported []string) string {
+	if len(supported) == 0 {
+		return ""
+	}
+
+	type candidate struct {
+		tag string
+		q   float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag: strings.ToLower(tag), q: q})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+
+	for _, cand := range candidates {
+		if cand.tag == "*" {
+			return supported[0]
+		}
+		if match := Match(cand.tag, supported); match != "" {
+			return match
+		}
+	}
+	return supported[0]
+}
+
+// Match returns the supported locale for tag, either exactly or by its
+// primary language subtag, or "" when there is none.
+func Match(tag string, supported []string) string {
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	primary, _, _ := strings.Cut(tag, "-")
+	for _, s := range supported {
+		if strings.EqualFold(s, tag) {
+			return s
+		}
+	}
+	for _, s := range supported {
+		if strings.EqualFold(s, primary) {
+			return s
+		}
+	}
+	return ""
+}
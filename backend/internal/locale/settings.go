@@ -0,0 +1,87 @@
+package locale
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Settings is the configured set of content locales. The first supported
+// locale is the default: its text lives in each entity's own fields, while
+// every other locale is stored as translations.
+type Settings struct {
+	supported []string
+	fallbacks map[string]string
+}
+
+// NewSettings validates a locale configuration. fallbacks maps a locale to the
+// one tried next when it has no translation ("pt-br" -> "pt"); every chain
+// ends at the default locale.
+func NewSettings(supported []string, fallbacks map[string]string) (Settings, error) {
+	s := Settings{fallbacks: map[string]string{}}
+	for _, tag := range supported {
+		tag = normalize(tag)
+		if tag != "" && !s.IsSupported(tag) {
+			s.supported = append(s.supported, tag)
+		}
+	}
+	if len(s.supported) == 0 {
+		return Settings{}, fmt.Errorf("no supported locales configured")
+	}
+
+	for from, to := range fallbacks {
+		from, to = normalize(from), normalize(to)
+		if !s.IsSupported(from) || !s.IsSupported(to) {
+			return Settings{}, fmt.Errorf("locale fallback %s -> %s uses an unsupported locale", from, to)
+		}
+		if from == to {
+			return Settings{}, fmt.Errorf("locale %s cannot fall back to itself", from)
+		}
+		s.fallbacks[from] = to
+	}
+	return s, nil
+}
+
+// Default is the locale of the untranslated content.
+func (s Settings) Default() string {
+	return s.supported[0]
+}
+
+// Supported lists the configured locales, default first.
+func (s Settings) Supported() []string {
+	return append([]string(nil), s.supported...)
+}
+
+// Translated lists the locales that are stored as translations.
+func (s Settings) Translated() []string {
+	return append([]string(nil), s.supported[1:]...)
+}
+
+func (s Settings) IsSupported(tag string) bool {
+	tag = normalize(tag)
+	for _, supported := range s.supported {
+		if supported == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Chain lists the locales to try, in order, for content in tag: tag itself,
+// its configured fallbacks, then the default. An unsupported tag resolves to
+// its closest supported match first.
+func (s Settings) Chain(tag string) []string {
+	seen := map[string]bool{}
+	var chain []string
+	for current := Match(tag, s.supported); current != "" && !seen[current]; current = s.fallbacks[current] {
+		seen[current] = true
+		chain = append(chain, current)
+	}
+	if !seen[s.Default()] {
+		chain = append(chain, s.Default())
+	}
+	return chain
+}
+
+func normalize(tag string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+}
@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
@@ -9,16 +12,47 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/portfolio/backend/internal/auth"
 	"github.com/portfolio/backend/internal/config"
 	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/problem"
+	"github.com/portfolio/backend/internal/repository"
 )
 
 type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	SessionID string `json:"sid,omitempty"`
+	Role      string `json:"role,omitempty"`
+	// TokenUse is empty for access tokens; other values mark single-purpose
+	// tokens that AuthMiddleware must not accept.
+	TokenUse string `json:"use,omitempty"`
 	jwt.RegisteredClaims
 }
 
+const (
+	tokenUseTwoFactorChallenge = "2fa_challenge"
+	tokenUsePreview            = "preview"
+)
+
+// TokenDenylist reports whether an access token has been revoked before its expiry.
+type TokenDenylist interface {
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+// APIKeyStore resolves API keys by hash and records their use. An unknown
+// hash is reported as repository.ErrNotFound.
+type APIKeyStore interface {
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (model.APIKey, error)
+	TouchAPIKey(ctx context.Context, id string) error
+}
+
+// CredentialStore is everything AuthMiddleware needs to check a request's credentials.
+type CredentialStore interface {
+	TokenDenylist
+	APIKeyStore
+}
+
 var jwtSecret []byte
 
 // InitAuth initializes the auth middleware with the secret from config
@@ -26,15 +60,52 @@ func InitAuth(cfg *config.Config) {
 	jwtSecret = []byte(cfg.JWTSecret)
 }
 
-// GenerateToken generates a new JWT token for a user that lasts for the specified duration
-func GenerateToken(user *model.Admin, duration time.Duration) (string, error) {
+// GenerateToken generates a new JWT token for a user that lasts for the specified duration.
+// It returns the signed token together with its unique ID (jti) so the caller can revoke it later.
+func GenerateToken(user *model.Admin, sessionID string, duration time.Duration) (string, string, error) {
+	if len(jwtSecret) == 0 {
+		return "", "", errors.New("JWT secret not initialized")
+	}
+
+	jti, err := newTokenID()
+	if err != nil {
+		return "", "", err
+	}
+
+	claims := Claims{
+		UserID:    user.ID,
+		Email:     user.Email,
+		SessionID: sessionID,
+		Role:      user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    "portfolio-backend",
+			Subject:   user.ID,
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(jwtSecret)
+	if err != nil {
+		return "", "", err
+	}
+	return signed, jti, nil
+}
+
+// GenerateChallengeToken issues the short-lived token that bridges the password
+// step and the second-factor step of a two-factor login.
+func GenerateChallengeToken(user *model.Admin, duration time.Duration) (string, error) {
 	if len(jwtSecret) == 0 {
 		return "", errors.New("JWT secret not initialized")
 	}
 
 	claims := Claims{
-		UserID: user.ID,
-		Email:  user.Email,
+		UserID:   user.ID,
+		Email:    user.Email,
+		TokenUse: tokenUseTwoFactorChallenge,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -48,49 +119,231 @@ func GenerateToken(user *model.Admin, duration time.Duration) (string, error) {
 	return token.SignedString(jwtSecret)
 }
 
+// ParseChallengeToken validates a token from GenerateChallengeToken and returns its claims.
+func ParseChallengeToken(tokenString string) (*Claims, error) {
+	claims, err := parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenUse != tokenUseTwoFactorChallenge {
+		return nil, errors.New("not a two-factor challenge token")
+	}
+	return claims, nil
+}
+
+// GeneratePreviewToken issues a token that lets whoever holds it see draft and
+// scheduled content on the public endpoints until it expires.
+func GeneratePreviewToken(user *model.Admin, duration time.Duration) (string, time.Time, error) {
+	if len(jwtSecret) == 0 {
+		return "", time.Time{}, errors.New("JWT secret not initialized")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(duration)
+	claims := Claims{
+		UserID:   user.ID,
+		TokenUse: tokenUsePreview,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "portfolio-backend",
+			Subject:   user.ID,
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(jwtSecret)
+	return signed, expiresAt, err
+}
+
+// ParsePreviewToken validates a token from GeneratePreviewToken and returns its claims.
+func ParsePreviewToken(tokenString string) (*Claims, error) {
+	claims, err := parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenUse != tokenUsePreview {
+		return nil, errors.New("not a preview token")
+	}
+	return claims, nil
+}
+
+func parseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return jwtSecret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+func newTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
 // AuthMiddleware validates the JWT token in the Authorization header
-func AuthMiddleware() gin.HandlerFunc {
+// and rejects tokens whose jti is on the denylist. An API key, sent either
+// as X-API-Key or as a Bearer token with the APIKeyPrefix, is accepted instead.
+func AuthMiddleware(store CredentialStore) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
+
+		if apiKey := apiKeyFromRequest(c, authHeader); apiKey != "" {
+			authenticateAPIKey(c, store, apiKey)
+			return
+		}
+
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
+			problem.WriteCode(c, http.StatusUnauthorized, "authorization_required")
 			c.Abort()
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be Bearer {token}"})
+			problem.WriteCode(c, http.StatusUnauthorized, "authorization_malformed")
 			c.Abort()
 			return
 		}
 
 		tokenString := parts[1]
 
-		claims := &Claims{}
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return jwtSecret, nil
-		})
-
+		claims, err := parseToken(tokenString)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			problem.WriteCode(c, http.StatusUnauthorized, "token_expired")
 			c.Abort()
 			return
 		}
 
-		if !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		if claims.TokenUse != "" {
+			problem.WriteCode(c, http.StatusUnauthorized, "invalid_token")
 			c.Abort()
 			return
 		}
 
+		if claims.ID != "" {
+			revoked, err := store.IsAccessTokenRevoked(c.Request.Context(), claims.ID)
+			if err != nil {
+				problem.WriteCode(c, http.StatusInternalServerError, "token_verification_failed")
+				c.Abort()
+				return
+			}
+			if revoked {
+				problem.WriteCode(c, http.StatusUnauthorized, "token_revoked")
+				c.Abort()
+				return
+			}
+		}
+
 		// Store user info in context
 		c.Set("userID", claims.UserID)
 		c.Set("email", claims.Email)
+		c.Set("sessionID", claims.SessionID)
+		c.Set("role", claims.Role)
+		c.Set("tokenID", claims.ID)
+		if claims.ExpiresAt != nil {
+			c.Set("tokenExpiresAt", claims.ExpiresAt.Time)
+		}
+
+		c.Next()
+	}
+}
 
+func apiKeyFromRequest(c *gin.Context, authHeader string) string {
+	if key := strings.TrimSpace(c.GetHeader("X-API-Key")); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(authHeader, "Bearer "); ok && strings.HasPrefix(token, auth.APIKeyPrefix) {
+		return token
+	}
+	return ""
+}
+
+func authenticateAPIKey(c *gin.Context, store APIKeyStore, rawKey string) {
+	ctx := c.Request.Context()
+
+	key, err := store.GetAPIKeyByHash(ctx, auth.HashAPIKey(rawKey))
+	if errors.Is(err, repository.ErrNotFound) {
+		problem.WriteCode(c, http.StatusUnauthorized, "invalid_api_key")
+		c.Abort()
+		return
+	}
+	if err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "api_key_verification_failed")
+		c.Abort()
+		return
+	}
+	if key.ExpiresAt != nil && time.Now().After(*key.ExpiresAt) {
+		problem.WriteCode(c, http.StatusUnauthorized, "api_key_expired")
+		c.Abort()
+		return
+	}
+
+	if err := store.TouchAPIKey(ctx, key.ID); err != nil {
+		problem.WriteCode(c, http.StatusInternalServerError, "api_key_verification_failed")
+		c.Abort()
+		return
+	}
+
+	scopes := make([]auth.Scope, len(key.Scopes))
+	for i, scope := range key.Scopes {
+		scopes[i] = auth.Scope(scope)
+	}
+
+	c.Set("userID", key.AdminID)
+	c.Set("role", key.AdminRole)
+	c.Set("apiKeyID", key.ID)
+	c.Set("apiKeyScopes", scopes)
+
+	c.Next()
+}
+
+// RequirePermission must run after AuthMiddleware. It rejects callers whose role
+// claim does not grant perm. Role changes take effect at the next token refresh.
+// API keys are additionally limited to routes that list one of their scopes;
+// a route with no scopes cannot be called with an API key at all.
+func RequirePermission(perm auth.Permission, scopes ...auth.Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if granted, isAPIKey := c.Get("apiKeyScopes"); isAPIKey {
+			keyScopes, _ := granted.([]auth.Scope)
+			if !auth.HasAnyScope(keyScopes, scopes...) {
+				problem.WriteCode(c, http.StatusForbidden, "api_key_scope_missing")
+				c.Abort()
+				return
+			}
+		}
+
+		if !auth.RoleHasPermission(c.GetString("role"), perm) {
+			problem.WriteCode(c, http.StatusForbidden, "permission_denied")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireUserSession rejects API keys on routes that manage the signed-in
+// account itself (sessions, second factors, passkeys).
+func RequireUserSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, isAPIKey := c.Get("apiKeyScopes"); isAPIKey {
+			problem.WriteCode(c, http.StatusForbidden, "interactive_login_required")
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
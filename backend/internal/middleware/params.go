@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/problem"
+)
+
+// UUIDParams rejects requests whose named path parameters are present but not
+// UUIDs, so a bad id is a 400 instead of a Postgres cast error.
+func UUIDParams(names ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, name := range names {
+			value, ok := c.Params.Get(name)
+			if ok && !IsUUID(value) {
+				problem.Validation(c, &model.FieldError{Field: name, Code: "uuid", Message: "must be a valid UUID"})
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// IsUUID reports whether s is a UUID in its canonical hyphenated form.
+func IsUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch {
+		case i == 8 || i == 13 || i == 18 || i == 23:
+			if s[i] != '-' {
+				return false
+			}
+		case '0' <= s[i] && s[i] <= '9', 'a' <= s[i] && s[i] <= 'f', 'A' <= s[i] && s[i] <= 'F':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/backend/internal/problem"
+)
+
+// PreviewTokenHeader carries a preview token; the "preview" query parameter
+// works too, so a shared link needs nothing but the URL.
+const PreviewTokenHeader = "X-Preview-Token"
+
+// PreviewMiddleware marks requests holding a valid preview token with
+// c.Set("preview", true). Requests without one pass through unchanged; an
+// invalid or expired token is rejected so reviewers know the link is dead.
+// It answers 403 rather than 401 so the admin client does not drop its session.
+// Preview responses include drafts, so they are marked uncacheable for shared
+// caches.
+func PreviewMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(PreviewTokenHeader)
+		if token == "" {
+			token = c.Query("preview")
+		}
+		if token == "" {
+			c.Next()
+			return
+		}
+
+		if _, err := ParsePreviewToken(token); err != nil {
+			problem.WriteCode(c, http.StatusForbidden, "preview_link_invalid")
+			c.Abort()
+			return
+		}
+
+		c.Set("preview", true)
+		c.Header("Cache-Control", "private, no-store")
+		c.Writer.Header().Add("Vary", PreviewTokenHeader)
+		c.Next()
+	}
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// APIKey lets scripts call admin routes without an interactive login. It acts
+// with the role of the admin who created it, narrowed to Scopes.
+type APIKey struct {
+	ID         string     `json:"id"`
+	AdminID    string     `json:"adminId"`
+	AdminRole  string     `json:"-"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// CreateAPIKeyResponse carries the plaintext key. It is only ever returned here.
+type CreateAPIKeyResponse struct {
+	APIKey
+	Key string `json:"key"`
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Actions recorded in the audit log.
+const (
+	AuditActionCreate   = "create"
+	AuditActionUpdate   = "update"
+	AuditActionDelete   = "delete"
+	AuditActionApprove  = "approve"
+	AuditActionReject   = "reject"
+	AuditActionMarkRead = "mark_read"
+	AuditActionUpload   = "upload"
+	AuditActionRestore  = "restore"
+)
+
+// Entity types recorded in the audit log.
+const (
+	AuditEntitySkill          = "skill"
+	AuditEntityProject        = "project"
+	AuditEntityExperience     = "experience"
+	AuditEntityEducation      = "education"
+	AuditEntityHobby          = "hobby"
+	AuditEntityTestimonial    = "testimonial"
+	AuditEntityMessage        = "message"
+	AuditEntityContactInfo    = "contact_info"
+	AuditEntityResume         = "resume"
+	AuditEntityProfilePicture = "profile_picture"
+)
+
+// AuditEntry is one mutating admin action. Before and After hold the row as
+// stored in the database; either is null when the row did not exist.
+type AuditEntry struct {
+	ID         string          `json:"id"`
+	ActorID    *string         `json:"actorId"`
+	ActorEmail string          `json:"actorEmail"`
+	APIKeyID   *string         `json:"apiKeyId"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entityType"`
+	EntityID   string          `json:"entityId"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	IPAddress  string          `json:"ipAddress"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+// AuditLogFilter narrows GET /admin/audit-log. Empty fields match everything.
+type AuditLogFilter struct {
+	ActorID    string
+	Action     string
+	EntityType string
+	EntityID   string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+type AuditLogPage struct {
+	Items  []AuditEntry `json:"items"`
+	Total  int          `json:"total"`
+	Limit  int          `json:"limit"`
+	Offset int          `json:"offset"`
+}
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// DatePrecision says how much of a Date is known.
+type DatePrecision string
+
+const (
+	DatePrecisionDay   DatePrecision = "day"
+	DatePrecisionMonth DatePrecision = "month"
+)
+
+const (
+	dayLayout   = "2006-01-02"
+	monthLayout = "2006-01"
+)
+
+var ErrInvalidDate = errors.New("must be a date formatted as YYYY-MM-DD or YYYY-MM")
+
+// Date is a calendar date used for experience and education ranges. It may be
+// known only to the month ("2023-05"). The zero Date means "no date" and is
+// encoded as JSON null and stored as SQL NULL.
+type Date struct {
+	Time      time.Time
+	Precision DatePrecision
+
+	// invalid keeps malformed JSON input so validation can report it against
+	// the right field instead of failing the whole decode.
+	invalid string
+}
+
+// ParseDate parses "YYYY-MM-DD" or "YYYY-MM". An empty string gives the zero Date.
+func ParseDate(s string) (Date, error) {
+	if s == "" {
+		return Date{}, nil
+	}
+	if t, err := time.Parse(dayLayout, s); err == nil {
+		return Date{Time: t, Precision: DatePrecisionDay}, nil
+	}
+	if t, err := time.Parse(monthLayout, s); err == nil {
+		return Date{Time: t, Precision: DatePrecisionMonth}, nil
+	}
+	return Date{}, ErrInvalidDate
+}
+
+// NewDate builds a Date from a stored value; a nil t gives the zero Date.
+func NewDate(t *time.Time, precision DatePrecision) Date {
+	if t == nil {
+		return Date{}
+	}
+	if precision != DatePrecisionMonth {
+		precision = DatePrecisionDay
+	}
+	return Date{Time: *t, Precision: precision}
+}
+
+func (d Date) IsZero() bool {
+	return d.Time.IsZero()
+}
+
+// Ptr returns the date for storage, nil when it is unset.
+func (d Date) Ptr() *time.Time {
+	if d.IsZero() {
+		return nil
+	}
+	return &d.Time
+}
+
+// StoredPrecision is the precision to store alongside the date; unset and
+// day-precision dates both store "day".
+func (d Date) StoredPrecision() DatePrecision {
+	if d.Precision == DatePrecisionMonth {
+		return DatePrecisionMonth
+	}
+	return DatePrecisionDay
+}
+
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	if d.Precision == DatePrecisionMonth {
+		return d.Time.Format(monthLayout)
+	}
+	return d.Time.Format(dayLayout)
+}
+
+// Before reports whether d falls before other, comparing only as precisely as
+// the less precise of the two: "2023-05" is not before "2023-05-17".
+func (d Date) Before(other Date) bool {
+	if d.Precision == DatePrecisionMonth || other.Precision == DatePrecisionMonth {
+		return d.Time.Format(monthLayout) < other.Time.Format(monthLayout)
+	}
+	return d.Time.Before(other.Time)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON accepts null, "" and the formats of ParseDate. Anything else
+// decodes to an invalid Date that ValidateDateRange rejects.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*d = Date{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		*d = Date{invalid: string(data)}
+		return nil
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		*d = Date{invalid: s}
+		return nil
+	}
+	*d = parsed
+	return nil
+}
+
+// FieldError reports a request field that failed validation. Code is stable
+// and used to localize the message; Message is the English fallback.
+type FieldError struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidateDateRange checks a start/end pair: the start is required, the end
+// may not come before it, and an entry that is still current has no end.
+func ValidateDateRange(start, end Date, current bool) error {
+	if start.invalid != "" {
+		return &FieldError{Field: "startDate", Code: "invalid_date", Message: ErrInvalidDate.Error()}
+	}
+	if end.invalid != "" {
+		return &FieldError{Field: "endDate", Code: "invalid_date", Message: ErrInvalidDate.Error()}
+	}
+	if start.IsZero() {
+		return &FieldError{Field: "startDate", Code: "required", Message: "is required"}
+	}
+	if !end.IsZero() && end.Before(start) {
+		return &FieldError{Field: "endDate", Code: "before_start", Message: "must not be before startDate"}
+	}
+	if current && !end.IsZero() {
+		return &FieldError{Field: "endDate", Code: "not_empty_when_current", Message: "must be empty when current is true"}
+	}
+	return nil
+}
@@ -3,25 +3,40 @@ package model
 import "time"
 
 type Hobby struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Icon        string    `json:"icon"`
-	Description string    `json:"description"`
-	SortOrder   int       `json:"sortOrder"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID            string       `json:"id"`
+	Name          string       `json:"name"`
+	NameFr        string       `json:"nameFr"`
+	Icon          string       `json:"icon"`
+	Description   string       `json:"description"`
+	DescriptionFr string       `json:"descriptionFr"`
+	SortOrder     int          `json:"sortOrder"`
+	Translations  Translations `json:"translations,omitempty"`
+	Publication
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
 
 type CreateHobbyRequest struct {
-	Name        string `json:"name" binding:"required"`
-	Icon        string `json:"icon"`
-	Description string `json:"description"`
-	SortOrder   int    `json:"sortOrder"`
+	Name          string       `json:"name" binding:"required"`
+	NameFr        string       `json:"nameFr"`
+	Icon          string       `json:"icon"`
+	Description   string       `json:"description"`
+	DescriptionFr string       `json:"descriptionFr"`
+	SortOrder     int          `json:"sortOrder"`
+	Translations  Translations `json:"translations,omitempty"`
+
+	PublicationRequest
 }
 
 type UpdateHobbyRequest struct {
-	Name        string `json:"name"`
-	Icon        string `json:"icon"`
-	Description string `json:"description"`
-	SortOrder   int    `json:"sortOrder"`
+	Name          string       `json:"name"`
+	NameFr        string       `json:"nameFr"`
+	Icon          string       `json:"icon"`
+	Description   string       `json:"description"`
+	DescriptionFr string       `json:"descriptionFr"`
+	SortOrder     int          `json:"sortOrder"`
+	Translations  Translations `json:"translations,omitempty"`
+
+	PublicationRequest
 }
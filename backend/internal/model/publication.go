@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// Publication states of portfolio content. Scheduled content goes live on its
+// own once PublishAt has passed.
+const (
+	StatusDraft     = "draft"
+	StatusPublished = "published"
+	StatusScheduled = "scheduled"
+)
+
+// Publication is embedded in content that has a draft/published workflow.
+type Publication struct {
+	Status    string     `json:"status"`
+	PublishAt *time.Time `json:"publishAt"`
+}
+
+// PublicationRequest is embedded in create/update requests. An empty status
+// means "published" on create and "leave unchanged" on update.
+type PublicationRequest struct {
+	Status    string     `json:"status" binding:"omitempty,oneof=draft published scheduled"`
+	PublishAt *time.Time `json:"publishAt"`
+}
+
+// CreatePreviewTokenRequest sets how long a preview link stays valid. It
+// defaults to 24 hours and is capped at a week.
+type CreatePreviewTokenRequest struct {
+	ExpiresInHours int `json:"expiresInHours" binding:"omitempty,min=1,max=168"`
+}
+
+type PreviewTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
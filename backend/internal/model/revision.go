@@ -0,0 +1,35 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Revision is the state of a content row just before one of its updates.
+// Revision numbers start at 1 and increase per entity.
+type Revision struct {
+	ID         string          `json:"id"`
+	EntityType string          `json:"entityType"`
+	EntityID   string          `json:"entityId"`
+	Revision   int             `json:"revision"`
+	ActorID    *string         `json:"actorId"`
+	ActorEmail string          `json:"actorEmail"`
+	Snapshot   json.RawMessage `json:"snapshot"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+// FieldChange is one column that differs between two revisions.
+type FieldChange struct {
+	Field string          `json:"field"`
+	From  json.RawMessage `json:"from"`
+	To    json.RawMessage `json:"to"`
+}
+
+// RevisionDiff compares two revisions; "current" stands for the live row.
+type RevisionDiff struct {
+	EntityType string        `json:"entityType"`
+	EntityID   string        `json:"entityId"`
+	From       string        `json:"from"`
+	To         string        `json:"to"`
+	Changes    []FieldChange `json:"changes"`
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// RefreshToken is one link in a session's rotation chain. Only the SHA-256
+// hash of the opaque token is stored; the raw value is returned to the client once.
+type RefreshToken struct {
+	ID            string
+	AdminID       string
+	SessionID     string
+	TokenHash     string
+	AccessTokenID string
+	AccessExpires time.Time
+	UserAgent     string
+	IPAddress     string
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+	RevokedAt     *time.Time
+}
+
+// Session groups every refresh token rotated from a single login.
+type Session struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"userAgent"`
+	IPAddress  string    `json:"ipAddress"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Current    bool      `json:"current"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
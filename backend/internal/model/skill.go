@@ -4,15 +4,17 @@ import "time"
 
 // Skill - no longer needs UserID since single admin
 type Skill struct {
-	ID              string    `json:"id"`
-	Name            string    `json:"name"`
-	Icon            string    `json:"icon"`
-	Proficiency     int       `json:"proficiency"`
-	Category        string    `json:"category"`
-	SortOrder       int       `json:"sortOrder"`
-	ShowInPortfolio bool      `json:"showInPortfolio"` // New field
-	CreatedAt       time.Time `json:"createdAt"`
-	UpdatedAt       time.Time `json:"updatedAt"`
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Icon            string `json:"icon"`
+	Proficiency     int    `json:"proficiency"`
+	Category        string `json:"category"`
+	SortOrder       int    `json:"sortOrder"`
+	ShowInPortfolio bool   `json:"showInPortfolio"` // New field
+	Publication
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
 
 type CreateSkillRequest struct {
@@ -22,6 +24,8 @@ type CreateSkillRequest struct {
 	Category        string `json:"category"`
 	SortOrder       int    `json:"sortOrder"`
 	ShowInPortfolio bool   `json:"showInPortfolio"`
+
+	PublicationRequest
 }
 
 type UpdateSkillRequest struct {
@@ -31,4 +35,6 @@ type UpdateSkillRequest struct {
 	Category        string `json:"category"`
 	SortOrder       int    `json:"sortOrder"`
 	ShowInPortfolio bool   `json:"showInPortfolio"`
+
+	PublicationRequest
 }
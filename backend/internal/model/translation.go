@@ -0,0 +1,224 @@
+package model
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Translations holds localized field values by locale, then by JSON field
+// name: {"fr": {"title": "..."}}. Values are strings, or string lists for list
+// fields. On writes an empty value removes the translation, and locales or
+// fields left out are kept as they are.
+type Translations map[string]map[string]any
+
+// FieldKind is the shape of a translatable field's value.
+type FieldKind int
+
+const (
+	TextField FieldKind = iota
+	ListField
+)
+
+// TranslatableFields lists, per entity type, the fields that can be
+// translated and their shape.
+var TranslatableFields = map[string]map[string]FieldKind{
+	AuditEntityProject: {
+		"title":       TextField,
+		"description": TextField,
+	},
+	AuditEntityExperience: {
+		"title":       TextField,
+		"company":     TextField,
+		"location":    TextField,
+		"description": ListField,
+	},
+	AuditEntityEducation: {
+		"degree":      TextField,
+		"school":      TextField,
+		"location":    TextField,
+		"description": TextField,
+	},
+	AuditEntityHobby: {
+		"name":        TextField,
+		"description": TextField,
+	},
+	AuditEntityContactInfo: {
+		"bio":        TextField,
+		"aboutTitle": TextField,
+		"location":   TextField,
+	},
+}
+
+// LegacyLocale is the locale that is also exposed through the flat
+// "<field>Fr" JSON fields clients used before Translations existed.
+const LegacyLocale = "fr"
+
+const legacySuffix = "Fr"
+
+// Text returns a text translation, or "" when there is none.
+func (t Translations) Text(locale, field string) string {
+	s, _ := t[locale][field].(string)
+	return s
+}
+
+// List returns a list translation, or nil when there is none.
+func (t Translations) List(locale, field string) []string {
+	switch v := t[locale][field].(type) {
+	case []string:
+		return v
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil
+			}
+			list = append(list, s)
+		}
+		return list
+	}
+	return nil
+}
+
+// Set stores value for field in locale.
+func (t Translations) Set(locale, field string, value any) {
+	if t[locale] == nil {
+		t[locale] = map[string]any{}
+	}
+	t[locale][field] = value
+}
+
+// Omit returns a copy of t without locale.
+func (t Translations) Omit(locale string) Translations {
+	out := Translations{}
+	for l, fields := range t {
+		if l != locale {
+			out[l] = fields
+		}
+	}
+	return out
+}
+
+// IsEmptyTranslation reports whether value clears a translation.
+func IsEmptyTranslation(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []string:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// SetTranslations stores t on entity, a pointer to a struct with a
+// Translations field, and mirrors its LegacyLocale values into the flat
+// "<field>Fr" fields.
+func SetTranslations(entity any, t Translations) {
+	v := reflect.ValueOf(entity).Elem()
+	if field := v.FieldByName("Translations"); field.IsValid() {
+		field.Set(reflect.ValueOf(t))
+	}
+	eachLegacyField(v, func(name string, field reflect.Value) {
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(t.Text(LegacyLocale, name))
+		case reflect.Slice:
+			list := t.List(LegacyLocale, name)
+			if list == nil {
+				list = []string{}
+			}
+			field.Set(reflect.ValueOf(list))
+		}
+	})
+}
+
+// ReviewFlags lists, per locale, the machine-translated fields no one has
+// edited yet.
+type ReviewFlags map[string][]string
+
+// SetNeedsReview stores flags on entity when it has a NeedsReview field.
+func SetNeedsReview(entity any, flags ReviewFlags) {
+	if field := reflect.ValueOf(entity).Elem().FieldByName("NeedsReview"); field.IsValid() {
+		field.Set(reflect.ValueOf(flags))
+	}
+}
+
+// CollectTranslations returns the translations carried by req, a pointer to
+// a request struct: its Translations field, completed with the flat
+// "<field>Fr" fields. An explicit translation wins over the flat field.
+func CollectTranslations(req any) Translations {
+	v := reflect.ValueOf(req).Elem()
+	collected := Translations{}
+	if field := v.FieldByName("Translations"); field.IsValid() {
+		for locale, fields := range field.Interface().(Translations) {
+			for name, value := range fields {
+				collected.Set(locale, name, value)
+			}
+		}
+	}
+	eachLegacyField(v, func(name string, field reflect.Value) {
+		if _, explicit := collected[LegacyLocale][name]; explicit {
+			return
+		}
+		value := field.Interface()
+		if list, ok := value.([]string); ok && list == nil {
+			value = []string{}
+		}
+		collected.Set(LegacyLocale, name, value)
+	})
+	return collected
+}
+
+// eachLegacyField calls fn with the translated field name of every flat
+// "<field>Fr" field of the struct v.
+func eachLegacyField(v reflect.Value, fn func(name string, field reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		base, ok := strings.CutSuffix(name, legacySuffix)
+		if !ok || base == "" {
+			continue
+		}
+		fn(base, v.Field(i))
+	}
+}
+
+// TranslationStatus reports how complete the translations of the content are.
+type TranslationStatus struct {
+	Locales []LocaleCoverage `json:"locales"`
+	Missing []TranslationGap `json:"missing"`
+	Stale   []TranslationGap `json:"stale"`
+}
+
+// LocaleCoverage counts, for one locale, the translatable fields that have
+// text to translate and how many of them are translated. Coverage is the
+// translated share as a percentage; stale translations count as translated.
+type LocaleCoverage struct {
+	Locale     string  `json:"locale"`
+	Total      int     `json:"total"`
+	Translated int     `json:"translated"`
+	Missing    int     `json:"missing"`
+	Stale      int     `json:"stale"`
+	Coverage   float64 `json:"coverage"`
+}
+
+// TranslationGap is a field that lacks a translation, or whose translation
+// was last updated before its source text changed.
+type TranslationGap struct {
+	EntityType   string     `json:"entityType"`
+	EntityID     string     `json:"entityId"`
+	Locale       string     `json:"locale"`
+	Field        string     `json:"field"`
+	TranslatedAt *time.Time `json:"translatedAt,omitempty"`
+}
+
+// MarkTranslationsReviewedRequest names the translated fields an editor
+// checked and kept as they are.
+type MarkTranslationsReviewedRequest struct {
+	Fields []string `json:"fields" binding:"required,min=1"`
+}
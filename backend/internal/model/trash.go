@@ -0,0 +1,14 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TrashItem is a soft-deleted row waiting to be restored or purged.
+type TrashItem struct {
+	EntityType string          `json:"entityType"`
+	ID         string          `json:"id"`
+	Data       json.RawMessage `json:"data"`
+	DeletedAt  time.Time       `json:"deletedAt"`
+}
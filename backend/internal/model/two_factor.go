@@ -0,0 +1,40 @@
+package model
+
+// AdminLoginChallengeResponse is returned instead of AdminLoginResponse when the
+// password was correct but the account has two-factor authentication enabled.
+type AdminLoginChallengeResponse struct {
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	ChallengeToken    string `json:"challengeToken"`
+	ExpiresIn         int    `json:"expiresIn"`
+}
+
+// TwoFactorLoginRequest completes a login; Code is a TOTP code or a recovery code.
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningUri"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recoveryCodesRemaining"`
+}
+
+// RecoveryCodesResponse carries freshly generated recovery codes. They are shown once;
+// only their hashes are stored.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
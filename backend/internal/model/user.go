@@ -2,14 +2,18 @@ package model
 
 import "time"
 
-// Admin represents the single admin user
+// Admin represents an admin user. Role decides which admin routes they can use.
 type Admin struct {
-	ID           string    `json:"id"`
-	Email        string    `json:"email"`
-	PasswordHash string    `json:"-"`
-	Name         string    `json:"name"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	ID               string    `json:"id"`
+	Email            string    `json:"email"`
+	PasswordHash     string    `json:"-"`
+	Name             string    `json:"name"`
+	Role             string    `json:"role"`
+	TOTPSecret       string    `json:"-"`
+	TOTPLastStep     int64     `json:"-"`
+	TwoFactorEnabled bool      `json:"twoFactorEnabled"`
+	CreatedAt        time.Time `json:"createdAt"`
+	UpdatedAt        time.Time `json:"updatedAt"`
 }
 
 type AdminLoginRequest struct {
@@ -17,38 +21,57 @@ type AdminLoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type CreateAdminUserRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Name     string `json:"name" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Role     string `json:"role" binding:"required"`
+}
+
+type UpdateAdminUserRequest struct {
+	Name string `json:"name" binding:"required"`
+	Role string `json:"role" binding:"required"`
+}
+
 type AdminLoginResponse struct {
-	Token string `json:"token"`
-	Admin Admin  `json:"admin"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int    `json:"expiresIn"` // access token lifetime in seconds
+	Admin        Admin  `json:"admin"`
 }
 
 // ContactInfo represents the portfolio owner's contact information
 type ContactInfo struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	Phone     string    `json:"phone"`
-	Location  string    `json:"location"`
-	LinkedIn  string    `json:"linkedin"`
-	GitHub    string    `json:"github"`
-	Twitter   string    `json:"twitter"`
-	Website   string    `json:"website"`
-	Bio       string    `json:"bio"`
-	BioFr     string    `json:"bioFr"`
-	AboutTitle   string    `json:"aboutTitle"`
-	AboutTitleFr string    `json:"aboutTitleFr"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID           string       `json:"id"`
+	Email        string       `json:"email"`
+	Phone        string       `json:"phone"`
+	Location     string       `json:"location"`
+	LocationFr   string       `json:"locationFr"`
+	LinkedIn     string       `json:"linkedin"`
+	GitHub       string       `json:"github"`
+	Twitter      string       `json:"twitter"`
+	Website      string       `json:"website"`
+	Bio          string       `json:"bio"`
+	BioFr        string       `json:"bioFr"`
+	AboutTitle   string       `json:"aboutTitle"`
+	AboutTitleFr string       `json:"aboutTitleFr"`
+	Translations Translations `json:"translations,omitempty"`
+	Version      int          `json:"version"`
+	UpdatedAt    time.Time    `json:"updatedAt"`
 }
 
 type UpdateContactInfoRequest struct {
-	Email    string `json:"email"`
-	Phone    string `json:"phone"`
-	Location string `json:"location"`
-	LinkedIn string `json:"linkedin"`
-	GitHub   string `json:"github"`
-	Twitter  string `json:"twitter"`
-	Website  string `json:"website"`
-	Bio      string `json:"bio"`
-	BioFr    string `json:"bioFr"`
-	AboutTitle   string `json:"aboutTitle"`
-	AboutTitleFr string `json:"aboutTitleFr"`
+	Email        string       `json:"email"`
+	Phone        string       `json:"phone"`
+	Location     string       `json:"location"`
+	LocationFr   string       `json:"locationFr"`
+	LinkedIn     string       `json:"linkedin"`
+	GitHub       string       `json:"github"`
+	Twitter      string       `json:"twitter"`
+	Website      string       `json:"website"`
+	Bio          string       `json:"bio"`
+	BioFr        string       `json:"bioFr"`
+	AboutTitle   string       `json:"aboutTitle"`
+	AboutTitleFr string       `json:"aboutTitleFr"`
+	Translations Translations `json:"translations,omitempty"`
 }
@@ -0,0 +1,128 @@
+package model
+
+import "time"
+
+// WebAuthnCredential is a passkey registered to an admin. CredentialID is the
+// base64url credential ID the browser reports; PublicKey is the raw COSE key.
+type WebAuthnCredential struct {
+	ID           string     `json:"id"`
+	AdminID      string     `json:"-"`
+	CredentialID string     `json:"credentialId"`
+	PublicKey    []byte     `json:"-"`
+	SignCount    uint32     `json:"-"`
+	Transports   []string   `json:"transports"`
+	Name         string     `json:"name"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	LastUsedAt   *time.Time `json:"lastUsedAt"`
+}
+
+// WebAuthnChallenge is a single-use challenge issued for a registration or login ceremony.
+// AdminID is empty for login challenges, since the admin is not known until the assertion arrives.
+type WebAuthnChallenge struct {
+	ID        string
+	AdminID   string
+	Challenge string
+	Purpose   string
+	ExpiresAt time.Time
+}
+
+const (
+	WebAuthnPurposeRegister = "register"
+	WebAuthnPurposeLogin    = "login"
+)
+
+// The option structs mirror PublicKeyCredentialCreationOptions and
+// PublicKeyCredentialRequestOptions with binary fields base64url encoded,
+// matching PublicKeyCredential.parseCreationOptionsFromJSON in the browser.
+
+type WebAuthnRelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type WebAuthnUser struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type WebAuthnCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type WebAuthnCredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+type WebAuthnAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+type WebAuthnCreationOptions struct {
+	Challenge              string                         `json:"challenge"`
+	RP                     WebAuthnRelyingParty           `json:"rp"`
+	User                   WebAuthnUser                   `json:"user"`
+	PubKeyCredParams       []WebAuthnCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int                            `json:"timeout"`
+	Attestation            string                         `json:"attestation"`
+	AuthenticatorSelection WebAuthnAuthenticatorSelection `json:"authenticatorSelection"`
+	ExcludeCredentials     []WebAuthnCredentialDescriptor `json:"excludeCredentials"`
+}
+
+type WebAuthnRequestOptions struct {
+	Challenge        string                         `json:"challenge"`
+	RPID             string                         `json:"rpId"`
+	Timeout          int                            `json:"timeout"`
+	UserVerification string                         `json:"userVerification"`
+	AllowCredentials []WebAuthnCredentialDescriptor `json:"allowCredentials"`
+}
+
+type WebAuthnRegistrationOptionsResponse struct {
+	ChallengeID string                  `json:"challengeId"`
+	PublicKey   WebAuthnCreationOptions `json:"publicKey"`
+}
+
+type WebAuthnLoginOptionsResponse struct {
+	ChallengeID string                 `json:"challengeId"`
+	PublicKey   WebAuthnRequestOptions `json:"publicKey"`
+}
+
+// WebAuthnAttestationCredential is PublicKeyCredential.toJSON() of a create() result.
+type WebAuthnAttestationCredential struct {
+	ID       string `json:"id" binding:"required"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type" binding:"required"`
+	Response struct {
+		ClientDataJSON    string   `json:"clientDataJSON" binding:"required"`
+		AttestationObject string   `json:"attestationObject" binding:"required"`
+		Transports        []string `json:"transports"`
+	} `json:"response" binding:"required"`
+}
+
+// WebAuthnAssertionCredential is PublicKeyCredential.toJSON() of a get() result.
+type WebAuthnAssertionCredential struct {
+	ID       string `json:"id" binding:"required"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type" binding:"required"`
+	Response struct {
+		ClientDataJSON    string `json:"clientDataJSON" binding:"required"`
+		AuthenticatorData string `json:"authenticatorData" binding:"required"`
+		Signature         string `json:"signature" binding:"required"`
+		UserHandle        string `json:"userHandle"`
+	} `json:"response" binding:"required"`
+}
+
+type WebAuthnRegistrationRequest struct {
+	ChallengeID string                        `json:"challengeId" binding:"required,uuid"`
+	Name        string                        `json:"name"`
+	Credential  WebAuthnAttestationCredential `json:"credential" binding:"required"`
+}
+
+type WebAuthnLoginRequest struct {
+	ChallengeID string                      `json:"challengeId" binding:"required,uuid"`
+	Credential  WebAuthnAssertionCredential `json:"credential" binding:"required"`
+}
@@ -0,0 +1,436 @@
+package problem
+
+import (
+	"net/http"
+	"strings"
+)
+
+// supportedLanguages lists the catalogs below; the first is the fallback.
+var supportedLanguages = []string{"en", "fr"}
+
+var titles = map[string]map[int]string{
+	"fr": {
+		http.StatusBadRequest:            "Requête invalide",
+		http.StatusUnauthorized:          "Non authentifié",
+		http.StatusForbidden:             "Accès refusé",
+		http.StatusNotFound:              "Introuvable",
+		http.StatusConflict:              "Conflit",
+		http.StatusPreconditionFailed:    "Échec de la précondition",
+		http.StatusUnsupportedMediaType:  "Type de média non pris en charge",
+		http.StatusRequestEntityTooLarge: "Requête trop volumineuse",
+		http.StatusPreconditionRequired:  "Précondition requise",
+		http.StatusTooManyRequests:       "Trop de requêtes",
+		http.StatusInternalServerError:   "Erreur interne du serveur",
+		http.StatusServiceUnavailable:    "Service indisponible",
+	},
+}
+
+// messages are the problem details, keyed by problem code. {param} is
+// replaced by the value passed to WriteCodeParam.
+var messages = map[string]map[string]string{
+	"en": {
+		CodeValidationFailed:     "The request contains invalid fields",
+		CodeInvalidBody:          "The request body is not valid JSON",
+		CodeNotFound:             "The requested resource was not found",
+		CodeConflict:             "The request conflicts with existing data",
+		CodeVersionConflict:      "The resource has changed since it was read; reload it and try again",
+		CodePreconditionRequired: "If-Match header with the ETag of the resource is required",
+		CodeInternal:             "An unexpected error occurred",
+		"invalid_input":          "The request contains a value the server cannot store",
+
+		// Authentication
+		"authorization_required":            "Authorization header is required",
+		"authorization_malformed":           "Authorization header format must be Bearer {token}",
+		"invalid_token":                     "Invalid token",
+		"token_expired":                     "Invalid or expired token",
+		"token_revoked":                     "Token has been revoked",
+		"token_verification_failed":         "Failed to verify token",
+		"invalid_api_key":                   "Invalid API key",
+		"api_key_expired":                   "API key has expired",
+		"api_key_verification_failed":       "Failed to verify API key",
+		"api_key_scope_missing":             "API key is missing the required scope",
+		"interactive_login_required":        "This endpoint requires an interactive login",
+		"permission_denied":                 "You do not have permission to perform this action",
+		"preview_link_invalid":              "Preview link is invalid or has expired",
+		"invalid_credentials":               "Invalid credentials",
+		"credentials_verification_failed":   "Failed to verify credentials",
+		"invalid_password":                  "Invalid password",
+		"token_generation_failed":           "Failed to generate token",
+		"refresh_token_invalid":             "Invalid or expired refresh token",
+		"login_protection_unavailable":      "Sign-in is temporarily unavailable. Please try again later.",
+		"refresh_token_reused":              "Refresh token has already been used; session revoked",
+		"refresh_token_verification_failed": "Failed to verify refresh token",
+		"admin_load_failed":                 "Failed to load admin",
+		"admin_not_found":                   "Admin not found",
+		"profile_fetch_failed":              "Failed to fetch profile",
+		"login_rate_limited":                "Too many failed login attempts. Try again in {param} seconds.",
+
+		// Sessions
+		"sessions_fetch_failed":  "Failed to fetch sessions",
+		"session_not_found":      "Session not found",
+		"session_revoke_failed":  "Failed to revoke session",
+		"sessions_revoke_failed": "Failed to revoke sessions",
+		"token_revoke_failed":    "Failed to revoke token",
+
+		// Two-factor authentication
+		"two_factor_already_enabled":       "Two-factor authentication is already enabled",
+		"two_factor_not_enabled":           "Two-factor authentication is not enabled",
+		"two_factor_setup_required":        "Start two-factor setup first",
+		"invalid_verification_code":        "Invalid verification code",
+		"code_verification_failed":         "Failed to verify code",
+		"login_challenge_invalid":          "Invalid or expired challenge. Please sign in again.",
+		"two_factor_status_fetch_failed":   "Failed to fetch two-factor status",
+		"secret_generation_failed":         "Failed to generate secret",
+		"secret_save_failed":               "Failed to save secret",
+		"two_factor_enable_failed":         "Failed to enable two-factor authentication",
+		"two_factor_disable_failed":        "Failed to disable two-factor authentication",
+		"recovery_codes_generation_failed": "Failed to generate recovery codes",
+		"recovery_codes_save_failed":       "Failed to save recovery codes",
+
+		// Passkeys
+		"passkey_challenge_invalid":   "Invalid or expired challenge. Please try again.",
+		"challenge_generation_failed": "Failed to generate challenge",
+		"passkey_response_malformed":  "Malformed passkey response",
+		"passkey_verification_failed": "Passkey verification failed",
+		"passkey_verification_error":  "Failed to verify passkey",
+		"passkey_already_registered":  "This passkey is already registered",
+		"passkey_save_failed":         "Failed to save passkey",
+		"passkeys_fetch_failed":       "Failed to fetch passkeys",
+		"passkey_not_found":           "Passkey not found",
+		"passkey_delete_failed":       "Failed to delete passkey",
+
+		// Users
+		"users_fetch_failed": "Failed to fetch users",
+		"user_not_found":     "User not found",
+		"invalid_role":       "Invalid role",
+		"user_email_taken":   "A user with this email already exists",
+		"owner_required":     "At least one owner is required",
+		"user_create_failed": "Failed to create user",
+		"user_update_failed": "Failed to update user",
+		"user_delete_failed": "Failed to delete user",
+
+		// API keys
+		"expires_at_in_past":        "expiresAt must be in the future",
+		"api_key_generation_failed": "Failed to generate API key",
+		"api_key_create_failed":     "Failed to create API key",
+		"api_keys_fetch_failed":     "Failed to fetch API keys",
+		"api_key_not_found":         "API key not found",
+		"api_key_delete_failed":     "Failed to delete API key",
+		"invalid_scope":             "Invalid scope: {param}",
+
+		// Content
+		"skills_fetch_failed":        "Failed to fetch skills",
+		"skill_fetch_failed":         "Failed to fetch skill",
+		"skill_not_found":            "Skill not found",
+		"projects_fetch_failed":      "Failed to fetch projects",
+		"project_fetch_failed":       "Failed to fetch project",
+		"project_not_found":          "Project not found",
+		"experience_fetch_failed":    "Failed to fetch experience",
+		"experience_not_found":       "Experience not found",
+		"education_fetch_failed":     "Failed to fetch education",
+		"education_not_found":        "Education not found",
+		"hobbies_fetch_failed":       "Failed to fetch hobbies",
+		"hobby_fetch_failed":         "Failed to fetch hobby",
+		"hobby_not_found":            "Hobby not found",
+		"testimonials_fetch_failed":  "Failed to fetch testimonials",
+		"testimonial_not_found":      "Testimonial not found",
+		"contact_info_fetch_failed":  "Failed to fetch contact info",
+		"contact_info_update_failed": "Failed to update contact info",
+		"contact_info_not_found":     "Contact info not found",
+		"unknown_entity_type":        "Unknown entity type",
+		"entity_not_found":           "Entity not found",
+		"entity_fetch_failed":        "Failed to fetch entity",
+
+		// Files
+		"file_missing":              "No file uploaded",
+		"file_type_not_pdf":         "Invalid file type. Only PDF files are allowed.",
+		"invalid_language":          "Invalid language. Must be 'en' or 'fr'",
+		"file_save_failed":          "Failed to save file",
+		"resume_not_found":          "Resume not found",
+		"profile_picture_not_found": "Profile picture not found",
+
+		// Contact form
+		"form_submitted_too_fast": "Please wait a moment before submitting the form.",
+		"captcha_failed":          "Captcha verification failed.",
+		"contact_rate_limited":    "Too many contact attempts. Please try again later.",
+		"duplicate_check_failed":  "Failed to validate duplicate message",
+		"message_not_found":       "Message not found",
+
+		// Revisions, trash and audit log
+		"revisions_fetch_failed":  "Failed to fetch revisions",
+		"revision_fetch_failed":   "Failed to fetch revision",
+		"revision_not_found":      "Revision not found",
+		"invalid_revision_number": "Invalid revision number",
+		"revision_from_required":  "from is required",
+		"revision_compare_failed": "Failed to compare revisions",
+		"entity_gone":             "Entity no longer exists",
+		"trash_fetch_failed":      "Failed to fetch trash",
+		"trash_item_not_found":    "Item not found in trash",
+		"trash_restore_failed":    "Failed to restore item",
+		"audit_log_fetch_failed":  "Failed to fetch audit log",
+		"invalid_offset":          "offset must be a non-negative integer",
+		"invalid_revision_ref":    "Revision must be a number or \"current\"",
+		"revision_ref_not_found":  "Revision {param} not found",
+		"invalid_limit":           "limit must be between 1 and {param}",
+		"invalid_timestamp":       "{param} must be an RFC 3339 timestamp",
+
+		// Translations
+		"unknown_locale":                  "Unknown translation locale",
+		"machine_translation_unavailable": "Machine translation is not configured",
+		"machine_translation_unsupported": "Machine translation is not available for this entity type",
+		"machine_translation_failed":      "Machine translation failed",
+		"translation_not_found":           "Translation not found",
+		"translation_review_failed":       "Failed to mark translations as reviewed",
+		"translation_status_fetch_failed": "Failed to fetch translation status",
+		"preview_token_create_failed":     "Failed to create preview token",
+
+		// Requests
+		"body_read_failed":         "Failed to read request body",
+		"patch_not_object":         "Patch must be a JSON object",
+		"if_match_invalid":         "If-Match does not match the current version",
+		"unsupported_content_type": "Content-Type must be {param}",
+		"unknown_patch_fields":     "Unknown fields: {param}",
+	},
+	"fr": {
+		CodeValidationFailed:     "La requête contient des champs invalides",
+		CodeInvalidBody:          "Le corps de la requête n'est pas un JSON valide",
+		CodeNotFound:             "La ressource demandée est introuvable",
+		CodeConflict:             "La requête est en conflit avec des données existantes",
+		CodeVersionConflict:      "La ressource a été modifiée depuis sa lecture ; rechargez-la et réessayez",
+		CodePreconditionRequired: "L'en-tête If-Match avec l'ETag de la ressource est obligatoire",
+		CodeInternal:             "Une erreur inattendue s'est produite",
+		"invalid_input":          "La requête contient une valeur que le serveur ne peut pas enregistrer",
+
+		// Authentication
+		"authorization_required":            "L'en-tête Authorization est obligatoire",
+		"authorization_malformed":           "L'en-tête Authorization doit être au format Bearer {token}",
+		"invalid_token":                     "Jeton invalide",
+		"token_expired":                     "Jeton invalide ou expiré",
+		"token_revoked":                     "Le jeton a été révoqué",
+		"token_verification_failed":         "Impossible de vérifier le jeton",
+		"invalid_api_key":                   "Clé d'API invalide",
+		"api_key_expired":                   "La clé d'API a expiré",
+		"api_key_verification_failed":       "Impossible de vérifier la clé d'API",
+		"api_key_scope_missing":             "La clé d'API n'a pas la portée requise",
+		"interactive_login_required":        "Ce point d'accès nécessite une connexion interactive",
+		"permission_denied":                 "Vous n'avez pas la permission d'effectuer cette action",
+		"preview_link_invalid":              "Le lien d'aperçu est invalide ou a expiré",
+		"invalid_credentials":               "Identifiants invalides",
+		"credentials_verification_failed":   "Impossible de vérifier les identifiants",
+		"invalid_password":                  "Mot de passe invalide",
+		"token_generation_failed":           "Impossible de générer le jeton",
+		"refresh_token_invalid":             "Jeton de rafraîchissement invalide ou expiré",
+		"login_protection_unavailable":      "La connexion est momentanément indisponible. Veuillez réessayer plus tard.",
+		"refresh_token_reused":              "Le jeton de rafraîchissement a déjà été utilisé ; la session a été révoquée",
+		"refresh_token_verification_failed": "Impossible de vérifier le jeton de rafraîchissement",
+		"admin_load_failed":                 "Impossible de charger l'administrateur",
+		"admin_not_found":                   "Administrateur introuvable",
+		"profile_fetch_failed":              "Impossible de récupérer le profil",
+		"login_rate_limited":                "Trop de tentatives de connexion échouées. Réessayez dans {param} secondes.",
+
+		// Sessions
+		"sessions_fetch_failed":  "Impossible de récupérer les sessions",
+		"session_not_found":      "Session introuvable",
+		"session_revoke_failed":  "Impossible de révoquer la session",
+		"sessions_revoke_failed": "Impossible de révoquer les sessions",
+		"token_revoke_failed":    "Impossible de révoquer le jeton",
+
+		// Two-factor authentication
+		"two_factor_already_enabled":       "L'authentification à deux facteurs est déjà activée",
+		"two_factor_not_enabled":           "L'authentification à deux facteurs n'est pas activée",
+		"two_factor_setup_required":        "Commencez d'abord la configuration de l'authentification à deux facteurs",
+		"invalid_verification_code":        "Code de vérification invalide",
+		"code_verification_failed":         "Impossible de vérifier le code",
+		"login_challenge_invalid":          "Défi invalide ou expiré. Veuillez vous reconnecter.",
+		"two_factor_status_fetch_failed":   "Impossible de récupérer l'état de l'authentification à deux facteurs",
+		"secret_generation_failed":         "Impossible de générer le secret",
+		"secret_save_failed":               "Impossible d'enregistrer le secret",
+		"two_factor_enable_failed":         "Impossible d'activer l'authentification à deux facteurs",
+		"two_factor_disable_failed":        "Impossible de désactiver l'authentification à deux facteurs",
+		"recovery_codes_generation_failed": "Impossible de générer les codes de récupération",
+		"recovery_codes_save_failed":       "Impossible d'enregistrer les codes de récupération",
+
+		// Passkeys
+		"passkey_challenge_invalid":   "Défi invalide ou expiré. Veuillez réessayer.",
+		"challenge_generation_failed": "Impossible de générer le défi",
+		"passkey_response_malformed":  "Réponse de clé d'accès mal formée",
+		"passkey_verification_failed": "La vérification de la clé d'accès a échoué",
+		"passkey_verification_error":  "Impossible de vérifier la clé d'accès",
+		"passkey_already_registered":  "Cette clé d'accès est déjà enregistrée",
+		"passkey_save_failed":         "Impossible d'enregistrer la clé d'accès",
+		"passkeys_fetch_failed":       "Impossible de récupérer les clés d'accès",
+		"passkey_not_found":           "Clé d'accès introuvable",
+		"passkey_delete_failed":       "Impossible de supprimer la clé d'accès",
+
+		// Users
+		"users_fetch_failed": "Impossible de récupérer les utilisateurs",
+		"user_not_found":     "Utilisateur introuvable",
+		"invalid_role":       "Rôle invalide",
+		"user_email_taken":   "Un utilisateur avec cette adresse e-mail existe déjà",
+		"owner_required":     "Au moins un propriétaire est requis",
+		"user_create_failed": "Impossible de créer l'utilisateur",
+		"user_update_failed": "Impossible de mettre à jour l'utilisateur",
+		"user_delete_failed": "Impossible de supprimer l'utilisateur",
+
+		// API keys
+		"expires_at_in_past":        "expiresAt doit être dans le futur",
+		"api_key_generation_failed": "Impossible de générer la clé d'API",
+		"api_key_create_failed":     "Impossible de créer la clé d'API",
+		"api_keys_fetch_failed":     "Impossible de récupérer les clés d'API",
+		"api_key_not_found":         "Clé d'API introuvable",
+		"api_key_delete_failed":     "Impossible de supprimer la clé d'API",
+		"invalid_scope":             "Portée invalide : {param}",
+
+		// Content
+		"skills_fetch_failed":        "Impossible de récupérer les compétences",
+		"skill_fetch_failed":         "Impossible de récupérer la compétence",
+		"skill_not_found":            "Compétence introuvable",
+		"projects_fetch_failed":      "Impossible de récupérer les projets",
+		"project_fetch_failed":       "Impossible de récupérer le projet",
+		"project_not_found":          "Projet introuvable",
+		"experience_fetch_failed":    "Impossible de récupérer l'expérience",
+		"experience_not_found":       "Expérience introuvable",
+		"education_fetch_failed":     "Impossible de récupérer la formation",
+		"education_not_found":        "Formation introuvable",
+		"hobbies_fetch_failed":       "Impossible de récupérer les loisirs",
+		"hobby_fetch_failed":         "Impossible de récupérer le loisir",
+		"hobby_not_found":            "Loisir introuvable",
+		"testimonials_fetch_failed":  "Impossible de récupérer les témoignages",
+		"testimonial_not_found":      "Témoignage introuvable",
+		"contact_info_fetch_failed":  "Impossible de récupérer les coordonnées",
+		"contact_info_update_failed": "Impossible de mettre à jour les coordonnées",
+		"contact_info_not_found":     "Coordonnées introuvables",
+		"unknown_entity_type":        "Type d'entité inconnu",
+		"entity_not_found":           "Entité introuvable",
+		"entity_fetch_failed":        "Impossible de récupérer l'entité",
+
+		// Files
+		"file_missing":              "Aucun fichier envoyé",
+		"file_type_not_pdf":         "Type de fichier invalide. Seuls les fichiers PDF sont acceptés.",
+		"invalid_language":          "Langue invalide. Elle doit être « en » ou « fr »",
+		"file_save_failed":          "Impossible d'enregistrer le fichier",
+		"resume_not_found":          "CV introuvable",
+		"profile_picture_not_found": "Photo de profil introuvable",
+
+		// Contact form
+		"form_submitted_too_fast": "Veuillez patienter un instant avant d'envoyer le formulaire.",
+		"captcha_failed":          "La vérification du captcha a échoué.",
+		"contact_rate_limited":    "Trop de tentatives de contact. Veuillez réessayer plus tard.",
+		"duplicate_check_failed":  "Impossible de vérifier si le message est un doublon",
+		"message_not_found":       "Message introuvable",
+
+		// Revisions, trash and audit log
+		"revisions_fetch_failed":  "Impossible de récupérer les révisions",
+		"revision_fetch_failed":   "Impossible de récupérer la révision",
+		"revision_not_found":      "Révision introuvable",
+		"invalid_revision_number": "Numéro de révision invalide",
+		"revision_from_required":  "from est obligatoire",
+		"revision_compare_failed": "Impossible de comparer les révisions",
+		"entity_gone":             "L'entité n'existe plus",
+		"trash_fetch_failed":      "Impossible de récupérer la corbeille",
+		"trash_item_not_found":    "Élément introuvable dans la corbeille",
+		"trash_restore_failed":    "Impossible de restaurer l'élément",
+		"audit_log_fetch_failed":  "Impossible de récupérer le journal d'audit",
+		"invalid_offset":          "offset doit être un entier positif ou nul",
+		"invalid_revision_ref":    "La révision doit être un nombre ou « current »",
+		"revision_ref_not_found":  "Révision {param} introuvable",
+		"invalid_limit":           "limit doit être compris entre 1 et {param}",
+		"invalid_timestamp":       "{param} doit être un horodatage RFC 3339",
+
+		// Translations
+		"unknown_locale":                  "Langue de traduction inconnue",
+		"machine_translation_unavailable": "La traduction automatique n'est pas configurée",
+		"machine_translation_unsupported": "La traduction automatique n'est pas disponible pour ce type d'entité",
+		"machine_translation_failed":      "La traduction automatique a échoué",
+		"translation_not_found":           "Traduction introuvable",
+		"translation_review_failed":       "Impossible de marquer les traductions comme relues",
+		"translation_status_fetch_failed": "Impossible de récupérer l'état des traductions",
+		"preview_token_create_failed":     "Impossible de créer le jeton d'aperçu",
+
+		// Requests
+		"body_read_failed":         "Impossible de lire le corps de la requête",
+		"patch_not_object":         "Le correctif doit être un objet JSON",
+		"if_match_invalid":         "If-Match ne correspond pas à la version actuelle",
+		"unsupported_content_type": "Content-Type doit être {param}",
+		"unknown_patch_fields":     "Champs inconnus : {param}",
+	},
+}
+
+// fieldMessages are keyed by validation code; {param} is replaced by the
+// validator tag parameter.
+var fieldMessages = map[string]map[string]string{
+	"en": {
+		"required":               "is required",
+		"email":                  "must be a valid email address",
+		"url":                    "must be a valid URL",
+		"uuid":                   "must be a valid UUID",
+		"oneof":                  "must be one of: {param}",
+		"min":                    "must be at least {param}",
+		"max":                    "must be at most {param}",
+		"len":                    "must be exactly {param}",
+		"min_length":             "must be at least {param} characters long",
+		"max_length":             "must be at most {param} characters long",
+		"len_length":             "must be exactly {param} characters long",
+		"gte":                    "must be at least {param}",
+		"lte":                    "must be at most {param}",
+		"invalid_type":           "has an invalid value",
+		"invalid_date":           "must be a date formatted as YYYY-MM-DD or YYYY-MM",
+		"before_start":           "must not be before startDate",
+		"not_empty_when_current": "must be empty when current is true",
+		"unsupported_locale":     "is not a supported translation locale",
+		"unknown_field":          "is not a translatable field",
+		"invalid":                "is invalid",
+	},
+	"fr": {
+		"required":               "est obligatoire",
+		"email":                  "doit être une adresse e-mail valide",
+		"url":                    "doit être une URL valide",
+		"uuid":                   "doit être un UUID valide",
+		"oneof":                  "doit être l'une des valeurs : {param}",
+		"min":                    "doit être supérieur ou égal à {param}",
+		"max":                    "doit être inférieur ou égal à {param}",
+		"len":                    "doit être égal à {param}",
+		"min_length":             "doit contenir au moins {param} caractères",
+		"max_length":             "doit contenir au plus {param} caractères",
+		"len_length":             "doit contenir exactement {param} caractères",
+		"gte":                    "doit être supérieur ou égal à {param}",
+		"lte":                    "doit être inférieur ou égal à {param}",
+		"invalid_type":           "a une valeur invalide",
+		"invalid_date":           "doit être une date au format AAAA-MM-JJ ou AAAA-MM",
+		"before_start":           "ne doit pas précéder startDate",
+		"not_empty_when_current": "doit être vide lorsque current est vrai",
+		"unsupported_locale":     "n'est pas une langue de traduction prise en charge",
+		"unknown_field":          "n'est pas un champ traduisible",
+		"invalid":                "est invalide",
+	},
+}
+
+func title(lang string, status int) string {
+	if t, ok := titles[lang][status]; ok {
+		return t
+	}
+	return http.StatusText(status)
+}
+
+func message(lang, code string) string {
+	if m, ok := messages[lang][code]; ok {
+		return m
+	}
+	return messages[supportedLanguages[0]][code]
+}
+
+// localizeField returns the message for a field validation code, falling back
+// to English, then to fallback, then to a generic message.
+func localizeField(lang, code, param, fallback string) string {
+	m, ok := fieldMessages[lang][code]
+	if !ok {
+		m, ok = fieldMessages[supportedLanguages[0]][code]
+	}
+	if !ok {
+		if fallback != "" {
+			return fallback
+		}
+		m = fieldMessages[lang]["invalid"]
+	}
+	return strings.ReplaceAll(m, "{param}", param)
+}
@@ -0,0 +1,200 @@
+// Package problem renders API errors as RFC 7807 problem details
+// (application/problem+json), with stable codes and messages in the
+// caller's language.
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/portfolio/backend/internal/locale"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// ContentType is the media type of problem responses.
+const ContentType = "application/problem+json"
+
+// Stable problem codes shared by many failures. Handlers answer with codes
+// of their own, listed with their messages in the catalog; clients should
+// branch on codes rather than on the (localized) title or detail.
+const (
+	CodeBadRequest           = "bad_request"
+	CodeValidationFailed     = "validation_failed"
+	CodeInvalidBody          = "invalid_body"
+	CodeNotFound             = "not_found"
+	CodeConflict             = "conflict"
+	CodeVersionConflict      = "version_conflict"
+	CodePreconditionRequired = "precondition_required"
+	CodeInternal             = "internal_error"
+)
+
+// Details is an RFC 7807 problem document. Code and Errors are extension
+// members.
+type Details struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError is one invalid request field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// WriteCode answers with a problem for status. code names the failure, and
+// its entry in the message catalog is the detail, in the caller's language.
+func WriteCode(c *gin.Context, status int, code string) {
+	WriteCodeParam(c, status, code, "")
+}
+
+// WriteCodeParam is WriteCode for a catalog message with a {param}
+// placeholder, such as the name of the offending value.
+func WriteCodeParam(c *gin.Context, status int, code, param string) {
+	detail := strings.ReplaceAll(message(language(c), code), "{param}", param)
+	respond(c, Details{Status: status, Code: code, Detail: detail})
+}
+
+// Validation answers 400 for a request body that failed to decode or
+// validate, listing every offending field.
+func Validation(c *gin.Context, err error) {
+	lang := language(c)
+
+	var validationErrs validator.ValidationErrors
+	var fieldErr *model.FieldError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &validationErrs):
+		fields := make([]FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, fromValidator(fe, lang))
+		}
+		writeFields(c, lang, fields)
+	case errors.As(err, &fieldErr):
+		writeFields(c, lang, []FieldError{{
+			Field:   fieldErr.Field,
+			Code:    fieldErr.Code,
+			Message: localizeField(lang, fieldErr.Code, "", fieldErr.Message),
+		}})
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		writeFields(c, lang, []FieldError{{
+			Field:   typeErr.Field,
+			Code:    "invalid_type",
+			Message: localizeField(lang, "invalid_type", "", ""),
+		}})
+	default:
+		// Malformed JSON and whatever else the binder rejects; its text names
+		// Go types, so it is not passed on.
+		respond(c, Details{Status: http.StatusBadRequest, Code: CodeInvalidBody, Detail: message(lang, CodeInvalidBody)})
+	}
+}
+
+// FromError answers for an error coming back from the repository. Sentinel and
+// constraint errors become 4xx problems; anything else is logged and reported
+// as a bare 500 so SQL text never reaches the client.
+func FromError(c *gin.Context, err error) {
+	lang := language(c)
+
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, repository.ErrNotFound), errors.Is(err, pgx.ErrNoRows):
+		respond(c, Details{Status: http.StatusNotFound, Code: CodeNotFound, Detail: message(lang, CodeNotFound)})
+	case errors.Is(err, repository.ErrVersionConflict):
+		respond(c, Details{Status: http.StatusPreconditionFailed, Code: CodeVersionConflict, Detail: message(lang, CodeVersionConflict)})
+	case errors.As(err, &pgErr) && (pgErr.Code == "23505" || pgErr.Code == "23503"):
+		// unique_violation, foreign_key_violation
+		respond(c, Details{Status: http.StatusConflict, Code: CodeConflict, Detail: message(lang, CodeConflict)})
+	case errors.As(err, &pgErr) && (strings.HasPrefix(pgErr.Code, "22") || pgErr.Code == "23502" || pgErr.Code == "23514"):
+		// data exceptions, not_null_violation, check_violation
+		respond(c, Details{Status: http.StatusBadRequest, Code: CodeBadRequest, Detail: message(lang, "invalid_input")})
+	default:
+		log.Printf("%s %s: %v", c.Request.Method, c.FullPath(), err)
+		respond(c, Details{Status: http.StatusInternalServerError, Code: CodeInternal, Detail: message(lang, CodeInternal)})
+	}
+}
+
+func writeFields(c *gin.Context, lang string, fields []FieldError) {
+	respond(c, Details{
+		Status: http.StatusBadRequest,
+		Code:   CodeValidationFailed,
+		Detail: message(lang, CodeValidationFailed),
+		Errors: fields,
+	})
+}
+
+func respond(c *gin.Context, details Details) {
+	lang := language(c)
+	details.Type = "about:blank"
+	details.Title = title(lang, details.Status)
+	details.Instance = c.Request.URL.Path
+
+	body, err := json.Marshal(details)
+	if err != nil {
+		c.Status(details.Status)
+		return
+	}
+	c.Header("Content-Language", lang)
+	c.Data(details.Status, ContentType, body)
+}
+
+func language(c *gin.Context) string {
+	return locale.Negotiate(c.GetHeader("Accept-Language"), supportedLanguages)
+}
+
+func fromValidator(fe validator.FieldError, lang string) FieldError {
+	field := fe.Field()
+	// Namespace is "Struct.json.path"; drop the struct name so nested fields
+	// read "publication.status" rather than just "status".
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		field = path
+	}
+
+	code := fe.Tag()
+	key := code
+	switch fe.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		if code == "min" || code == "max" || code == "len" {
+			key = code + "_length"
+		}
+	}
+	return FieldError{Field: field, Code: code, Message: localizeField(lang, key, fe.Param(), "")}
+}
+
+var registerTagNames sync.Once
+
+// UseJSONFieldNames makes validation errors name fields by their JSON key, as
+// clients know them. Call it once at startup, before requests are served.
+func UseJSONFieldNames() {
+	registerTagNames.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return field.Name
+			}
+			return name
+		})
+	})
+}
@@ -0,0 +1,128 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps limiter state in process memory. It is lost on restart and
+// not shared between replicas, which is fine for a single instance.
+type MemoryStore struct {
+	mu       sync.Mutex
+	logins   map[string]*memoryLoginEntry
+	requests map[string]*memoryRateEntry
+}
+
+type memoryLoginEntry struct {
+	state      LoginState
+	lastSeenAt time.Time
+}
+
+type memoryRateEntry struct {
+	recentRequests []time.Time
+	dayStart       time.Time
+	dayCount       int
+	lastSeen       time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		logins:   make(map[string]*memoryLoginEntry),
+		requests: make(map[string]*memoryRateEntry),
+	}
+}
+
+func (s *MemoryStore) LoginBlockedUntil(_ context.Context, key string, now time.Time) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cleanupLocked(now)
+
+	entry, exists := s.logins[key]
+	if !exists {
+		return time.Time{}, nil
+	}
+
+	entry.lastSeenAt = now
+	return entry.state.BlockedUntil, nil
+}
+
+func (s *MemoryStore) RegisterLoginFailure(_ context.Context, key string, now time.Time, policy LoginPolicy) (time.Duration, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cleanupLocked(now)
+
+	entry, exists := s.logins[key]
+	if !exists {
+		entry = &memoryLoginEntry{}
+		s.logins[key] = entry
+	}
+
+	var remaining time.Duration
+	var blocked bool
+	entry.state, remaining, blocked = policy.RegisterFailure(entry.state, now)
+	entry.lastSeenAt = now
+	return remaining, blocked, nil
+}
+
+func (s *MemoryStore) ResetLoginFailures(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.logins, key)
+	return nil
+}
+
+func (s *MemoryStore) AllowRequest(_ context.Context, key string, now time.Time, policy RatePolicy) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cleanupLocked(now)
+
+	entry, exists := s.requests[key]
+	if !exists {
+		entry = &memoryRateEntry{}
+		s.requests[key] = entry
+	}
+
+	cutoff := now.Add(-policy.Window)
+	filtered := entry.recentRequests[:0]
+	for _, timestamp := range entry.recentRequests {
+		if !timestamp.Before(cutoff) {
+			filtered = append(filtered, timestamp)
+		}
+	}
+	entry.recentRequests = filtered
+
+	if currentDay := DayStart(now); !entry.dayStart.Equal(currentDay) {
+		entry.dayStart = currentDay
+		entry.dayCount = 0
+	}
+
+	entry.lastSeen = now
+	if len(entry.recentRequests) >= policy.MaxInWindow || entry.dayCount >= policy.MaxPerDay {
+		return false, nil
+	}
+
+	entry.recentRequests = append(entry.recentRequests, now)
+	entry.dayCount++
+	return true, nil
+}
+
+func (s *MemoryStore) cleanupLocked(now time.Time) {
+	loginThreshold := now.Add(-72 * time.Hour)
+	for key, entry := range s.logins {
+		if entry.lastSeenAt.Before(loginThreshold) {
+			delete(s.logins, key)
+		}
+	}
+
+	requestThreshold := now.Add(-48 * time.Hour)
+	for key, entry := range s.requests {
+		if entry.lastSeen.Before(requestThreshold) {
+			delete(s.requests, key)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Package ratelimit holds the admin login lockout and request rate limiting
+// policies, and the Store interface that keeps their state.
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Store keeps limiter state. MemoryStore is the default; a shared store such as
+// the Postgres repository keeps counters consistent across instances and restarts.
+// Keys are namespaced by the caller, e.g. "admin-login:<ip>".
+type Store interface {
+	// LoginBlockedUntil returns when the block on key ends, or the zero time.
+	LoginBlockedUntil(ctx context.Context, key string, now time.Time) (time.Time, error)
+	// RegisterLoginFailure counts a failed attempt and reports whether key is now blocked.
+	RegisterLoginFailure(ctx context.Context, key string, now time.Time, policy LoginPolicy) (time.Duration, bool, error)
+	// ResetLoginFailures forgets failures and blocks for key after a successful login.
+	ResetLoginFailures(ctx context.Context, key string) error
+	// AllowRequest records a request for key if the policy still allows one.
+	AllowRequest(ctx context.Context, key string, now time.Time, policy RatePolicy) (bool, error)
+}
+
+// LoginPolicy blocks a key for BlockDuration after MaxAttempts failures that are
+// each no more than AttemptWindow apart.
+type LoginPolicy struct {
+	MaxAttempts   int
+	AttemptWindow time.Duration
+	BlockDuration time.Duration
+}
+
+// LoginState is the persisted failure state of one key.
+type LoginState struct {
+	FailedCount  int
+	LastFailedAt time.Time
+	BlockedUntil time.Time
+}
+
+// RegisterFailure applies one failed attempt to state. Stores call it so every
+// backend counts failures the same way.
+func (p LoginPolicy) RegisterFailure(state LoginState, now time.Time) (LoginState, time.Duration, bool) {
+	// Already blocked.
+	if state.BlockedUntil.After(now) {
+		return state, state.BlockedUntil.Sub(now), true
+	}
+
+	// Reset rolling failure count if outside the configured window.
+	if !state.LastFailedAt.IsZero() && now.Sub(state.LastFailedAt) > p.AttemptWindow {
+		state.FailedCount = 0
+	}
+
+	state.FailedCount++
+	state.LastFailedAt = now
+
+	if state.FailedCount >= p.MaxAttempts {
+		state.BlockedUntil = now.Add(p.BlockDuration)
+		state.FailedCount = 0
+		return state, p.BlockDuration, true
+	}
+
+	return state, 0, false
+}
+
+// RatePolicy allows MaxInWindow requests per sliding Window and MaxPerDay per UTC day.
+type RatePolicy struct {
+	Window      time.Duration
+	MaxInWindow int
+	MaxPerDay   int
+}
+
+// DayStart returns the start of the UTC day containing now, where the daily quota resets.
+func DayStart(now time.Time) time.Time {
+	return now.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,632 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// === Admin ===
+
+func (s *Store) CountAdmins(_ context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.admins), nil
+}
+
+func (s *Store) GetAdminByEmail(_ context.Context, email string) (model.Admin, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range s.admins {
+		if strings.EqualFold(a.Email, email) {
+			return *a, nil
+		}
+	}
+	return model.Admin{}, repository.ErrNotFound
+}
+
+func (s *Store) GetAdminByID(_ context.Context, id string) (model.Admin, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a := s.admin(id); a != nil {
+		return *a, nil
+	}
+	return model.Admin{}, repository.ErrNotFound
+}
+
+func (s *Store) admin(id string) *model.Admin {
+	for _, a := range s.admins {
+		if a.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+func (s *Store) CreateAdmin(_ context.Context, a model.Admin) (model.Admin, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a.Email = strings.ToLower(a.Email)
+	for _, existing := range s.admins {
+		if existing.Email == a.Email {
+			return a, repository.ErrAdminExists
+		}
+	}
+	now := time.Now()
+	a.ID = newID()
+	a.TOTPSecret, a.TOTPLastStep, a.TwoFactorEnabled = "", 0, false
+	a.CreatedAt, a.UpdatedAt = now, now
+	stored := a
+	s.admins = append(s.admins, &stored)
+	return a, nil
+}
+
+func (s *Store) GetAdmins(_ context.Context) ([]model.Admin, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	admins := []model.Admin{}
+	for _, a := range s.admins {
+		admins = append(admins, *a)
+	}
+	return admins, nil
+}
+
+func (s *Store) UpdateAdminUser(_ context.Context, id, name, role string) (model.Admin, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if role != "owner" {
+		if err := s.ensureOtherOwner(id); err != nil {
+			return model.Admin{}, err
+		}
+	}
+	a := s.admin(id)
+	if a == nil {
+		return model.Admin{}, repository.ErrNotFound
+	}
+	a.Name, a.Role, a.UpdatedAt = name, role, time.Now()
+	return *a, nil
+}
+
+// DeleteAdmin removes the admin along with everything that belongs to
+// them, after denylisting their live access tokens.
+func (s *Store) DeleteAdmin(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureOtherOwner(id); err != nil {
+		return err
+	}
+	if s.admin(id) == nil {
+		return repository.ErrNotFound
+	}
+
+	now := time.Now()
+	for _, t := range s.refreshTokens {
+		if t.AdminID == id {
+			s.denylist(t, now)
+		}
+	}
+	s.admins = deleteWhere(s.admins, func(a *model.Admin) bool { return a.ID == id })
+	s.refreshTokens = deleteWhere(s.refreshTokens, func(t *model.RefreshToken) bool { return t.AdminID == id })
+	s.recoveryCodes = deleteWhere(s.recoveryCodes, func(c *recoveryCode) bool { return c.adminID == id })
+	s.challenges = deleteWhere(s.challenges, func(c *model.WebAuthnChallenge) bool { return c.AdminID == id })
+	s.credentials = deleteWhere(s.credentials, func(c *model.WebAuthnCredential) bool { return c.AdminID == id })
+	s.apiKeys = deleteWhere(s.apiKeys, func(k *model.APIKey) bool { return k.AdminID == id })
+	for _, entry := range s.auditLog {
+		if entry.ActorID != nil && *entry.ActorID == id {
+			entry.ActorID = nil
+		}
+	}
+	for _, rev := range s.revisions {
+		if rev.ActorID != nil && *rev.ActorID == id {
+			rev.ActorID = nil
+		}
+	}
+	return nil
+}
+
+// ensureOtherOwner returns ErrLastOwner if id is the only owner.
+func (s *Store) ensureOtherOwner(id string) error {
+	owners := 0
+	for _, a := range s.admins {
+		if a.Role != "owner" {
+			continue
+		}
+		if a.ID != id {
+			return nil
+		}
+		owners++
+	}
+	if owners == 0 {
+		return nil
+	}
+	return repository.ErrLastOwner
+}
+
+func (s *Store) UpdateAdminPassword(_ context.Context, id, passwordHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a := s.admin(id)
+	if a == nil {
+		return repository.ErrNotFound
+	}
+	a.PasswordHash, a.UpdatedAt = passwordHash, time.Now()
+	return nil
+}
+
+// === Two-factor authentication ===
+
+type recoveryCode struct {
+	adminID  string
+	codeHash string
+	used     bool
+}
+
+func (s *Store) SetPendingTOTPSecret(_ context.Context, adminID, secret string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a := s.admin(adminID)
+	if a == nil || a.TwoFactorEnabled {
+		return repository.ErrNotFound
+	}
+	a.TOTPSecret, a.TOTPLastStep, a.UpdatedAt = secret, 0, time.Now()
+	return nil
+}
+
+func (s *Store) EnableTOTP(_ context.Context, adminID string, step int64, codeHashes []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a := s.admin(adminID)
+	if a == nil || a.TOTPSecret == "" {
+		return repository.ErrNotFound
+	}
+	a.TwoFactorEnabled, a.TOTPLastStep, a.UpdatedAt = true, step, time.Now()
+	s.replaceRecoveryCodes(adminID, codeHashes)
+	return nil
+}
+
+func (s *Store) DisableTOTP(_ context.Context, adminID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a := s.admin(adminID); a != nil {
+		a.TwoFactorEnabled, a.TOTPSecret, a.TOTPLastStep, a.UpdatedAt = false, "", 0, time.Now()
+	}
+	s.replaceRecoveryCodes(adminID, nil)
+	return nil
+}
+
+func (s *Store) ConsumeTOTPStep(_ context.Context, adminID string, step int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a := s.admin(adminID)
+	if a == nil || a.TOTPLastStep >= step {
+		return false, nil
+	}
+	a.TOTPLastStep = step
+	return true, nil
+}
+
+func (s *Store) ReplaceRecoveryCodes(_ context.Context, adminID string, codeHashes []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replaceRecoveryCodes(adminID, codeHashes)
+	return nil
+}
+
+func (s *Store) replaceRecoveryCodes(adminID string, codeHashes []string) {
+	s.recoveryCodes = deleteWhere(s.recoveryCodes, func(c *recoveryCode) bool { return c.adminID == adminID })
+	for _, hash := range codeHashes {
+		s.recoveryCodes = append(s.recoveryCodes, &recoveryCode{adminID: adminID, codeHash: hash})
+	}
+}
+
+func (s *Store) UseRecoveryCode(_ context.Context, adminID, codeHash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	used := false
+	for _, c := range s.recoveryCodes {
+		if c.adminID == adminID && c.codeHash == codeHash && !c.used {
+			c.used = true
+			used = true
+		}
+	}
+	if !used {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (s *Store) CountUnusedRecoveryCodes(_ context.Context, adminID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for _, c := range s.recoveryCodes {
+		if c.adminID == adminID && !c.used {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// === Sessions ===
+
+func (s *Store) CreateRefreshToken(_ context.Context, t model.RefreshToken) (model.RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.insertRefreshToken(t), nil
+}
+
+func (s *Store) insertRefreshToken(t model.RefreshToken) model.RefreshToken {
+	t.ID = newID()
+	t.CreatedAt = time.Now()
+	t.RevokedAt = nil
+	stored := t
+	s.refreshTokens = append(s.refreshTokens, &stored)
+	return t
+}
+
+func (s *Store) GetRefreshToken(_ context.Context, tokenHash string) (model.RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, t := range s.refreshTokens {
+		if t.TokenHash == tokenHash && t.ExpiresAt.After(now) {
+			found := *t
+			found.RevokedAt = cloneTime(t.RevokedAt)
+			return found, nil
+		}
+	}
+	return model.RefreshToken{}, repository.ErrNotFound
+}
+
+func (s *Store) RotateRefreshToken(_ context.Context, oldID string, next model.RefreshToken) (model.RefreshToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, t := range s.refreshTokens {
+		if t.ID == oldID && t.RevokedAt == nil {
+			t.RevokedAt = &now
+			return s.insertRefreshToken(next), nil
+		}
+	}
+	// A replay, or a lost race with another rotation; treat both as theft.
+	s.revokeSession(next.SessionID, now)
+	return model.RefreshToken{}, repository.ErrRefreshTokenReused
+}
+
+func (s *Store) RevokeSession(_ context.Context, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revokeSession(sessionID, time.Now())
+	return nil
+}
+
+func (s *Store) revokeSession(sessionID string, now time.Time) {
+	for _, t := range s.refreshTokens {
+		if t.SessionID != sessionID {
+			continue
+		}
+		s.denylist(t, now)
+		if t.RevokedAt == nil {
+			revokedAt := now
+			t.RevokedAt = &revokedAt
+		}
+	}
+}
+
+// denylist revokes the access token minted with t, if it is still live.
+func (s *Store) denylist(t *model.RefreshToken, now time.Time) {
+	if !t.AccessExpires.After(now) {
+		return
+	}
+	if _, ok := s.revokedTokens[t.AccessTokenID]; !ok {
+		s.revokedTokens[t.AccessTokenID] = t.AccessExpires
+	}
+}
+
+func (s *Store) RevokeAdminSession(_ context.Context, adminID, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, t := range s.refreshTokens {
+		if t.AdminID == adminID && t.SessionID == sessionID {
+			s.revokeSession(sessionID, time.Now())
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func (s *Store) RevokeAdminSessions(_ context.Context, adminID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, t := range s.refreshTokens {
+		if t.AdminID == adminID && t.RevokedAt == nil {
+			s.revokeSession(t.SessionID, now)
+		}
+	}
+	return nil
+}
+
+func (s *Store) GetActiveSessions(_ context.Context, adminID string) ([]model.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	bySession := map[string]*model.Session{}
+	active := map[string]bool{}
+	var order []string
+	for _, t := range s.refreshTokens {
+		if t.AdminID != adminID {
+			continue
+		}
+		session, ok := bySession[t.SessionID]
+		if !ok {
+			session = &model.Session{ID: t.SessionID, CreatedAt: t.CreatedAt}
+			bySession[t.SessionID] = session
+			order = append(order, t.SessionID)
+		}
+		// Tokens are in creation order, so the last one wins.
+		session.UserAgent, session.IPAddress, session.LastUsedAt = t.UserAgent, t.IPAddress, t.CreatedAt
+		if t.ExpiresAt.After(session.ExpiresAt) {
+			session.ExpiresAt = t.ExpiresAt
+		}
+		if t.RevokedAt == nil && t.ExpiresAt.After(now) {
+			active[t.SessionID] = true
+		}
+	}
+
+	sessions := []model.Session{}
+	for i := len(order) - 1; i >= 0; i-- {
+		if active[order[i]] {
+			sessions = append(sessions, *bySession[order[i]])
+		}
+	}
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt) })
+	return sessions, nil
+}
+
+func (s *Store) IsAccessTokenRevoked(_ context.Context, jti string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, revoked := s.revokedTokens[jti]
+	return revoked, nil
+}
+
+func (s *Store) RevokeAccessToken(_ context.Context, jti string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.revokedTokens[jti]; !ok {
+		s.revokedTokens[jti] = expiresAt
+	}
+	return nil
+}
+
+func (s *Store) PurgeExpiredTokens(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for jti, expiresAt := range s.revokedTokens {
+		if expiresAt.Before(now) {
+			delete(s.revokedTokens, jti)
+		}
+	}
+	s.challenges = deleteWhere(s.challenges, func(c *model.WebAuthnChallenge) bool { return c.ExpiresAt.Before(now) })
+	s.refreshTokens = deleteWhere(s.refreshTokens, func(t *model.RefreshToken) bool { return t.ExpiresAt.Before(now) })
+	return nil
+}
+
+// === WebAuthn ===
+
+func (s *Store) CreateWebAuthnChallenge(_ context.Context, challenge model.WebAuthnChallenge) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	challenge.ID = newID()
+	s.challenges = append(s.challenges, &challenge)
+	return challenge.ID, nil
+}
+
+func (s *Store) ConsumeWebAuthnChallenge(_ context.Context, id, purpose, adminID string) (model.WebAuthnChallenge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for i, c := range s.challenges {
+		if c.ID == id && c.Purpose == purpose && c.AdminID == adminID && c.ExpiresAt.After(now) {
+			s.challenges = append(s.challenges[:i], s.challenges[i+1:]...)
+			return *c, nil
+		}
+	}
+	return model.WebAuthnChallenge{}, repository.ErrNotFound
+}
+
+func (s *Store) CreateWebAuthnCredential(_ context.Context, cred model.WebAuthnCredential) (model.WebAuthnCredential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.credentials {
+		if existing.CredentialID == cred.CredentialID {
+			return model.WebAuthnCredential{}, repository.ErrCredentialExists
+		}
+	}
+	cred.ID = newID()
+	cred.PublicKey = append([]byte{}, cred.PublicKey...)
+	cred.Transports = append([]string{}, cred.Transports...)
+	cred.CreatedAt = time.Now()
+	cred.LastUsedAt = nil
+	stored := cred
+	s.credentials = append(s.credentials, &stored)
+	return copyCredential(&stored), nil
+}
+
+func (s *Store) GetWebAuthnCredentialByCredentialID(_ context.Context, credentialID string) (model.WebAuthnCredential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.credentials {
+		if c.CredentialID == credentialID {
+			return copyCredential(c), nil
+		}
+	}
+	return model.WebAuthnCredential{}, repository.ErrNotFound
+}
+
+func (s *Store) GetWebAuthnCredentials(_ context.Context, adminID string) ([]model.WebAuthnCredential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creds := []model.WebAuthnCredential{}
+	for _, c := range s.credentials {
+		if c.AdminID == adminID {
+			creds = append(creds, copyCredential(c))
+		}
+	}
+	return creds, nil
+}
+
+func (s *Store) UpdateWebAuthnSignCount(_ context.Context, id string, signCount uint32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range s.credentials {
+		if c.ID == id {
+			c.SignCount = max(c.SignCount, signCount)
+			now := time.Now()
+			c.LastUsedAt = &now
+		}
+	}
+	return nil
+}
+
+func (s *Store) DeleteWebAuthnCredential(_ context.Context, adminID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before := len(s.credentials)
+	s.credentials = deleteWhere(s.credentials, func(c *model.WebAuthnCredential) bool { return c.ID == id && c.AdminID == adminID })
+	if len(s.credentials) == before {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func copyCredential(c *model.WebAuthnCredential) model.WebAuthnCredential {
+	cred := *c
+	cred.PublicKey = append([]byte{}, c.PublicKey...)
+	cred.Transports = append([]string{}, c.Transports...)
+	cred.LastUsedAt = cloneTime(c.LastUsedAt)
+	return cred
+}
+
+// === API keys ===
+
+// CreateAPIKey returns ErrNotFound when the creating admin does not exist.
+func (s *Store) CreateAPIKey(_ context.Context, key model.APIKey) (model.APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.admin(key.AdminID) == nil {
+		return model.APIKey{}, repository.ErrNotFound
+	}
+	key.ID = newID()
+	key.Scopes = cloneStrings(key.Scopes)
+	key.ExpiresAt = cloneTime(key.ExpiresAt)
+	key.LastUsedAt = nil
+	key.CreatedAt = time.Now()
+	stored := key
+	s.apiKeys = append(s.apiKeys, &stored)
+	return s.copyAPIKey(&stored), nil
+}
+
+func (s *Store) GetAPIKeys(_ context.Context) ([]model.APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := []model.APIKey{}
+	for i := len(s.apiKeys) - 1; i >= 0; i-- {
+		keys = append(keys, s.copyAPIKey(s.apiKeys[i]))
+	}
+	return keys, nil
+}
+
+func (s *Store) GetAPIKeyByHash(_ context.Context, keyHash string) (model.APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, k := range s.apiKeys {
+		if k.KeyHash == keyHash {
+			return s.copyAPIKey(k), nil
+		}
+	}
+	return model.APIKey{}, repository.ErrNotFound
+}
+
+func (s *Store) TouchAPIKey(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, k := range s.apiKeys {
+		if k.ID == id && (k.LastUsedAt == nil || k.LastUsedAt.Before(now.Add(-time.Minute))) {
+			k.LastUsedAt = &now
+		}
+	}
+	return nil
+}
+
+func (s *Store) DeleteAPIKey(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before := len(s.apiKeys)
+	s.apiKeys = deleteWhere(s.apiKeys, func(k *model.APIKey) bool { return k.ID == id })
+	if len(s.apiKeys) == before {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// copyAPIKey returns k with its creator's current role.
+func (s *Store) copyAPIKey(k *model.APIKey) model.APIKey {
+	key := *k
+	key.Scopes = cloneStrings(k.Scopes)
+	key.ExpiresAt = cloneTime(k.ExpiresAt)
+	key.LastUsedAt = cloneTime(k.LastUsedAt)
+	if a := s.admin(k.AdminID); a != nil {
+		key.AdminRole = a.Role
+	}
+	return key
+}
+
+// deleteWhere removes the elements of list that match.
+func deleteWhere[T any](list []T, match func(T) bool) []T {
+	kept := list[:0]
+	for _, item := range list {
+		if !match(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
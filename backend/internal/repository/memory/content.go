@@ -0,0 +1,465 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// === Content ===
+// The generic helpers below implement the list, get, create, update and
+// delete methods shared by skills, projects, experience, education and
+// hobbies. Callers hold s.mu.
+
+func listContent[T any](s *Store, t *table[T], publishedOnly bool) []T {
+	values := t.list(publishedOnly, time.Now())
+	for i := range values {
+		s.decorate(t.entityType, *t.fields(&values[i]).id, &values[i])
+	}
+	return values
+}
+
+func getContent[T any](s *Store, t *table[T], id string) (T, error) {
+	r := t.live(id)
+	if r == nil {
+		var zero T
+		return zero, repository.ErrNotFound
+	}
+	value := t.clone(r.value)
+	s.decorate(t.entityType, id, &value)
+	return value, nil
+}
+
+func createContent[T any](s *Store, t *table[T], v T) T {
+	now := time.Now()
+	r := t.insert(v, now)
+	id := t.id(r)
+	s.saveTranslations(t.entityType, id, translationsOf(&v), now)
+
+	value := t.clone(r.value)
+	s.decorate(t.entityType, id, &value)
+	return value
+}
+
+func updateContent[T any](s *Store, t *table[T], v T) (T, error) {
+	now := time.Now()
+	r, err := t.update(v, now)
+	if err != nil {
+		return v, err
+	}
+	id := t.id(r)
+	s.saveTranslations(t.entityType, id, translationsOf(&v), now)
+
+	value := t.clone(r.value)
+	s.decorate(t.entityType, id, &value)
+	return value, nil
+}
+
+func deleteContent[T any](t *table[T], id string, version int) error {
+	r, err := t.checkVersion(id, version)
+	if err != nil {
+		return err
+	}
+	t.trash(r, time.Now())
+	return nil
+}
+
+// decorate sets the stored translations on entity when its type has any.
+func (s *Store) decorate(entityType, id string, entity any) {
+	if repository.IsTranslatable(entityType) {
+		s.applyTranslations(entityType, id, entity)
+	}
+}
+
+// translationsOf returns the Translations field of entity, a pointer to a
+// model struct, or nil when it has none.
+func translationsOf(entity any) model.Translations {
+	field := reflect.ValueOf(entity).Elem().FieldByName("Translations")
+	if !field.IsValid() {
+		return nil
+	}
+	return field.Interface().(model.Translations)
+}
+
+// === Skills ===
+
+func (s *Store) GetSkills(_ context.Context) ([]model.Skill, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return listContent(s, s.skills, true), nil
+}
+
+func (s *Store) GetAllSkills(_ context.Context) ([]model.Skill, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return listContent(s, s.skills, false), nil
+}
+
+func (s *Store) GetSkillByID(_ context.Context, id string) (model.Skill, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return getContent(s, s.skills, id)
+}
+
+func (s *Store) CreateSkill(_ context.Context, skill model.Skill) (model.Skill, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return createContent(s, s.skills, skill), nil
+}
+
+func (s *Store) UpdateSkill(_ context.Context, skill model.Skill) (model.Skill, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return updateContent(s, s.skills, skill)
+}
+
+func (s *Store) DeleteSkill(_ context.Context, id string, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return deleteContent(s.skills, id, version)
+}
+
+// === Projects ===
+
+func (s *Store) GetProjects(_ context.Context) ([]model.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return listContent(s, s.projects, true), nil
+}
+
+func (s *Store) GetAllProjects(_ context.Context) ([]model.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return listContent(s, s.projects, false), nil
+}
+
+func (s *Store) GetProjectByID(_ context.Context, id string) (model.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return getContent(s, s.projects, id)
+}
+
+func (s *Store) CreateProject(_ context.Context, p model.Project) (model.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return createContent(s, s.projects, p), nil
+}
+
+func (s *Store) UpdateProject(_ context.Context, p model.Project) (model.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return updateContent(s, s.projects, p)
+}
+
+func (s *Store) DeleteProject(_ context.Context, id string, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return deleteContent(s.projects, id, version)
+}
+
+// === Experience ===
+
+func (s *Store) GetExperiences(_ context.Context) ([]model.Experience, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return listContent(s, s.experiences, true), nil
+}
+
+func (s *Store) GetAllExperiences(_ context.Context) ([]model.Experience, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return listContent(s, s.experiences, false), nil
+}
+
+func (s *Store) GetExperienceByID(_ context.Context, id string) (model.Experience, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return getContent(s, s.experiences, id)
+}
+
+func (s *Store) CreateExperience(_ context.Context, e model.Experience) (model.Experience, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return createContent(s, s.experiences, e), nil
+}
+
+func (s *Store) UpdateExperience(_ context.Context, e model.Experience) (model.Experience, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return updateContent(s, s.experiences, e)
+}
+
+func (s *Store) DeleteExperience(_ context.Context, id string, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return deleteContent(s.experiences, id, version)
+}
+
+// === Education ===
+
+func (s *Store) GetEducation(_ context.Context) ([]model.Education, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return listContent(s, s.education, true), nil
+}
+
+func (s *Store) GetAllEducation(_ context.Context) ([]model.Education, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return listContent(s, s.education, false), nil
+}
+
+func (s *Store) GetEducationByID(_ context.Context, id string) (model.Education, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return getContent(s, s.education, id)
+}
+
+func (s *Store) CreateEducation(_ context.Context, e model.Education) (model.Education, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return createContent(s, s.education, e), nil
+}
+
+func (s *Store) UpdateEducation(_ context.Context, e model.Education) (model.Education, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return updateContent(s, s.education, e)
+}
+
+func (s *Store) DeleteEducation(_ context.Context, id string, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return deleteContent(s.education, id, version)
+}
+
+// === Hobbies ===
+
+func (s *Store) GetHobbies(_ context.Context) ([]model.Hobby, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return listContent(s, s.hobbies, true), nil
+}
+
+func (s *Store) GetAllHobbies(_ context.Context) ([]model.Hobby, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return listContent(s, s.hobbies, false), nil
+}
+
+func (s *Store) GetHobbyByID(_ context.Context, id string) (model.Hobby, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return getContent(s, s.hobbies, id)
+}
+
+func (s *Store) CreateHobby(_ context.Context, h model.Hobby) (model.Hobby, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return createContent(s, s.hobbies, h), nil
+}
+
+func (s *Store) UpdateHobby(_ context.Context, h model.Hobby) (model.Hobby, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return updateContent(s, s.hobbies, h)
+}
+
+func (s *Store) DeleteHobby(_ context.Context, id string, version int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return deleteContent(s.hobbies, id, version)
+}
+
+// === Testimonials ===
+
+// GetApprovedTestimonials leaves out the author's email and the timestamps,
+// which the public site does not show.
+func (s *Store) GetApprovedTestimonials(_ context.Context) ([]model.Testimonial, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	testimonials := []model.Testimonial{}
+	for i := len(s.testimonials.rows) - 1; i >= 0; i-- {
+		r := s.testimonials.rows[i]
+		if r.deletedAt != nil || r.value.Status != "approved" {
+			continue
+		}
+		t := r.value
+		testimonials = append(testimonials, model.Testimonial{
+			ID: t.ID, AuthorName: t.AuthorName, AuthorRole: t.AuthorRole, Content: t.Content, Rating: t.Rating, Status: t.Status,
+		})
+	}
+	return testimonials, nil
+}
+
+func (s *Store) GetAllTestimonials(_ context.Context) ([]model.Testimonial, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	testimonials := []model.Testimonial{}
+	for i := len(s.testimonials.rows) - 1; i >= 0; i-- {
+		if r := s.testimonials.rows[i]; r.deletedAt == nil {
+			testimonials = append(testimonials, r.value)
+		}
+	}
+	return testimonials, nil
+}
+
+func (s *Store) CreateTestimonial(_ context.Context, t model.Testimonial) (model.Testimonial, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.testimonials.insert(t, time.Now()).value, nil
+}
+
+func (s *Store) UpdateTestimonialStatus(_ context.Context, id, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.testimonials.live(id)
+	if r == nil {
+		return repository.ErrNotFound
+	}
+	r.value.Status = status
+	r.value.UpdatedAt = time.Now()
+	return nil
+}
+
+func (s *Store) DeleteTestimonial(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.testimonials.live(id)
+	if r == nil {
+		return repository.ErrNotFound
+	}
+	s.testimonials.trash(r, time.Now())
+	return nil
+}
+
+// === Messages ===
+
+func (s *Store) GetMessages(_ context.Context) ([]model.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := []model.Message{}
+	for i := len(s.messages.rows) - 1; i >= 0; i-- {
+		if r := s.messages.rows[i]; r.deletedAt == nil {
+			m := r.value
+			m.ContentHash = ""
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+func (s *Store) PurgeMessages(_ context.Context, cutoff time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var purged int64
+	kept := s.messages.rows[:0]
+	for _, r := range s.messages.rows {
+		if r.value.CreatedAt.Before(cutoff) {
+			purged++
+			continue
+		}
+		kept = append(kept, r)
+	}
+	s.messages.rows = kept
+	return purged, nil
+}
+
+func (s *Store) CreateMessage(_ context.Context, m model.Message) (model.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.messages.insert(m, time.Now()).value, nil
+}
+
+func (s *Store) HasRecentDuplicateMessage(_ context.Context, email, contentHash string, within time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if within <= 0 {
+		within = 24 * time.Hour
+	}
+	cutoff := time.Now().Add(-within)
+	for _, r := range s.messages.rows {
+		m := r.value
+		if m.Email == email && m.ContentHash == contentHash && !m.CreatedAt.Before(cutoff) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *Store) MarkMessageRead(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.messages.live(id)
+	if r == nil {
+		return repository.ErrNotFound
+	}
+	r.value.Read = true
+	return nil
+}
+
+func (s *Store) DeleteMessage(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.messages.live(id)
+	if r == nil {
+		return repository.ErrNotFound
+	}
+	s.messages.trash(r, time.Now())
+	return nil
+}
+
+// === Contact Info ===
+
+func (s *Store) GetContactInfo(_ context.Context) (model.ContactInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.contactInfoLocked(), nil
+}
+
+func (s *Store) contactInfoLocked() model.ContactInfo {
+	if len(s.contactInfo.rows) == 0 {
+		return model.ContactInfo{}
+	}
+	info := s.contactInfo.rows[0].value
+	s.decorate(model.AuditEntityContactInfo, info.ID, &info)
+	return info
+}
+
+func (s *Store) UpdateContactInfo(_ context.Context, info model.ContactInfo) (model.ContactInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	translations := info.Translations
+	if len(s.contactInfo.rows) == 0 {
+		if info.Version != 0 {
+			return model.ContactInfo{}, repository.ErrVersionConflict
+		}
+		info = s.contactInfo.insert(info, now).value
+	} else {
+		r := s.contactInfo.rows[0]
+		if r.value.Version != info.Version {
+			return model.ContactInfo{}, repository.ErrVersionConflict
+		}
+		info.ID = r.value.ID
+		info.Version++
+		info.UpdatedAt = now
+		model.SetTranslations(&info, nil)
+		r.value = info
+	}
+	s.saveTranslations(model.AuditEntityContactInfo, info.ID, translations, now)
+	return s.contactInfoLocked(), nil
+}
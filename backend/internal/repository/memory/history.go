@@ -0,0 +1,331 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// === Audit log ===
+
+// SnapshotEntity returns the stored row as JSON, or nil when the entity type
+// has no table or the row does not exist. Translatable entities carry their
+// translations under "translations".
+func (s *Store) SnapshotEntity(_ context.Context, entityType, id string) (json.RawMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.snapshot(entityType, id)
+}
+
+func (s *Store) snapshot(entityType, id string) (json.RawMessage, error) {
+	t, ok := s.tables[entityType]
+	if !ok || id == "" {
+		return nil, nil
+	}
+	doc, ok := t.document(id)
+	if !ok {
+		return nil, nil
+	}
+	if repository.IsTranslatable(entityType) {
+		values, _ := s.storedTranslations(entityType, id)
+		if values == nil {
+			values = model.Translations{}
+		}
+		encoded, err := json.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+		doc["translations"] = encoded
+	}
+	return json.Marshal(doc)
+}
+
+func (s *Store) CreateAuditEntry(_ context.Context, entry model.AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry.ID = newID()
+	entry.ActorID = cloneString(entry.ActorID)
+	entry.APIKeyID = cloneString(entry.APIKeyID)
+	entry.Before = cloneRaw(entry.Before)
+	entry.After = cloneRaw(entry.After)
+	entry.CreatedAt = time.Now()
+	s.auditLog = append(s.auditLog, &entry)
+	return nil
+}
+
+// ListAuditEntries returns one page of entries, newest first, plus the number
+// of entries matching the filter.
+func (s *Store) ListAuditEntries(_ context.Context, filter model.AuditLogFilter) ([]model.AuditEntry, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []*model.AuditEntry
+	for _, entry := range s.auditLog {
+		if auditMatches(entry, filter) {
+			matched = append(matched, entry)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID < matched[j].ID
+	})
+
+	entries := []model.AuditEntry{}
+	for i := filter.Offset; i < len(matched) && i < filter.Offset+filter.Limit; i++ {
+		entry := *matched[i]
+		entry.ActorID = cloneString(entry.ActorID)
+		entry.APIKeyID = cloneString(entry.APIKeyID)
+		entry.Before = cloneRaw(entry.Before)
+		entry.After = cloneRaw(entry.After)
+		// API key requests carry no email in the context, so fall back to
+		// the actor's current address.
+		if entry.ActorEmail == "" {
+			entry.ActorEmail = s.actorEmail(entry.ActorID)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, len(matched), nil
+}
+
+func auditMatches(entry *model.AuditEntry, filter model.AuditLogFilter) bool {
+	switch {
+	case filter.ActorID != "" && (entry.ActorID == nil || *entry.ActorID != filter.ActorID):
+		return false
+	case filter.Action != "" && entry.Action != filter.Action:
+		return false
+	case filter.EntityType != "" && entry.EntityType != filter.EntityType:
+		return false
+	case filter.EntityID != "" && entry.EntityID != filter.EntityID:
+		return false
+	case filter.From != nil && entry.CreatedAt.Before(*filter.From):
+		return false
+	case filter.To != nil && !entry.CreatedAt.Before(*filter.To):
+		return false
+	}
+	return true
+}
+
+func (s *Store) actorEmail(actorID *string) string {
+	if actorID == nil {
+		return ""
+	}
+	if a := s.admin(*actorID); a != nil {
+		return a.Email
+	}
+	return ""
+}
+
+// === Revisions ===
+
+// revisionFields lists, per revisioned entity type, the JSON fields a
+// restore writes back, along with any translations. Identity, timestamps
+// and publication status are left alone.
+// Keep these in sync with the Postgres revisionColumns.
+var revisionFields = map[string][]string{
+	model.AuditEntitySkill: {
+		"name", "icon", "proficiency", "category", "sortOrder", "showInPortfolio",
+	},
+	model.AuditEntityProject: {
+		"title", "description", "imageUrl", "liveUrl", "codeUrl",
+		"tags", "featured", "sortOrder",
+	},
+	model.AuditEntityExperience: {
+		"title", "company", "location", "startDate", "endDate",
+		"current", "description", "sortOrder",
+	},
+	model.AuditEntityEducation: {
+		"degree", "school", "location", "startDate", "endDate",
+		"description", "sortOrder",
+	},
+	model.AuditEntityHobby: {
+		"name", "icon", "description", "sortOrder",
+	},
+	model.AuditEntityContactInfo: {
+		"email", "phone", "location", "linkedin", "github", "twitter", "website",
+		"bio", "aboutTitle",
+	},
+}
+
+// CreateRevision stores snapshot as the next revision of the entity.
+func (s *Store) CreateRevision(_ context.Context, entityType, entityID string, snapshot json.RawMessage, actorID *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := 1
+	for _, rev := range s.revisions {
+		if rev.EntityType == entityType && rev.EntityID == entityID && rev.Revision >= next {
+			next = rev.Revision + 1
+		}
+	}
+	s.revisions = append(s.revisions, &model.Revision{
+		ID:         newID(),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Revision:   next,
+		ActorID:    cloneString(actorID),
+		Snapshot:   cloneRaw(snapshot),
+		CreatedAt:  time.Now(),
+	})
+	return nil
+}
+
+// GetRevisions lists an entity's revisions, newest first.
+func (s *Store) GetRevisions(_ context.Context, entityType, entityID string) ([]model.Revision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revisions := []model.Revision{}
+	for _, rev := range s.revisions {
+		if rev.EntityType == entityType && rev.EntityID == entityID {
+			revisions = append(revisions, s.copyRevision(rev))
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision > revisions[j].Revision })
+	return revisions, nil
+}
+
+func (s *Store) GetRevision(_ context.Context, entityType, entityID string, revision int) (model.Revision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rev := range s.revisions {
+		if rev.EntityType == entityType && rev.EntityID == entityID && rev.Revision == revision {
+			return s.copyRevision(rev), nil
+		}
+	}
+	return model.Revision{}, repository.ErrNotFound
+}
+
+// copyRevision returns rev with its actor's current email.
+func (s *Store) copyRevision(rev *model.Revision) model.Revision {
+	c := *rev
+	c.ActorID = cloneString(rev.ActorID)
+	c.ActorEmail = s.actorEmail(rev.ActorID)
+	c.Snapshot = cloneRaw(rev.Snapshot)
+	return c
+}
+
+// RestoreSnapshot writes the revisioned fields and translations of snapshot
+// back onto the row. Fields missing from an older snapshot keep their
+// current value. It returns ErrNotFound when the row no longer exists or is
+// in the trash.
+func (s *Store) RestoreSnapshot(_ context.Context, entityType, entityID string, version int, snapshot json.RawMessage) error {
+	fieldNames, ok := revisionFields[entityType]
+	if !ok {
+		return fmt.Errorf("entity type %q has no revisions", entityType)
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(snapshot, &doc); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if err := s.tables[entityType].restore(entityID, version, doc, fieldNames, now); err != nil {
+		return err
+	}
+
+	if raw, ok := doc["translations"]; ok && repository.IsTranslatable(entityType) {
+		var translations model.Translations
+		if err := json.Unmarshal(raw, &translations); err != nil {
+			return err
+		}
+		s.replaceTranslations(entityType, entityID, translations, now)
+	}
+	return nil
+}
+
+// === Trash ===
+
+// GetTrash lists trashed rows of the given entity types, most recently deleted first.
+func (s *Store) GetTrash(_ context.Context, entityTypes []string) ([]model.TrashItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := []model.TrashItem{}
+	for _, entityType := range entityTypes {
+		if !repository.IsTrashable(entityType) {
+			continue
+		}
+		for _, r := range s.tables[entityType].trashed() {
+			data, err := json.Marshal(r.data)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, model.TrashItem{EntityType: entityType, ID: r.id, Data: data, DeletedAt: r.deletedAt})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].DeletedAt.After(items[j].DeletedAt) })
+	return items, nil
+}
+
+// RestoreFromTrash takes a row out of the trash. It returns ErrNotFound when
+// the row is not in the trash.
+func (s *Store) RestoreFromTrash(_ context.Context, entityType, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !repository.IsTrashable(entityType) || !s.tables[entityType].untrash(id) {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// PurgeTrash permanently deletes rows trashed before cutoff, along with their
+// revision history and translations, and returns how many rows were removed.
+func (s *Store) PurgeTrash(_ context.Context, cutoff time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var total int64
+	for _, entityType := range repository.TrashEntityTypes {
+		for _, id := range s.tables[entityType].purge(cutoff) {
+			s.revisions = deleteWhere(s.revisions, func(rev *model.Revision) bool {
+				return rev.EntityType == entityType && rev.EntityID == id
+			})
+			s.deleteTranslations(entityType, id)
+			total++
+		}
+	}
+	return total, nil
+}
+
+// === Publishing ===
+
+// PublishScheduled marks scheduled rows whose publish time has passed as
+// published and returns how many rows changed.
+func (s *Store) PublishScheduled(_ context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var total int64
+	for _, entityType := range repository.PublishableEntityTypes {
+		total += s.tables[entityType].publishDue(now)
+	}
+	return total, nil
+}
+
+func cloneString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	return &c
+}
+
+func cloneRaw(raw json.RawMessage) json.RawMessage {
+	if raw == nil {
+		return nil
+	}
+	return append(json.RawMessage{}, raw...)
+}
@@ -0,0 +1,503 @@
+// Package memory is an in-process implementation of repository.Store for
+// tests. It follows the same contract as the Postgres repository, checked by
+// the suite in repositorytest, and is safe for concurrent use. Nothing
+// survives the process.
+package memory
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// Store keeps every aggregate in maps and slices behind a single mutex.
+type Store struct {
+	mu sync.Mutex
+
+	skills       *table[model.Skill]
+	projects     *table[model.Project]
+	experiences  *table[model.Experience]
+	education    *table[model.Education]
+	hobbies      *table[model.Hobby]
+	testimonials *table[model.Testimonial]
+	messages     *table[model.Message]
+	contactInfo  *table[model.ContactInfo]
+	// tables holds the tables above by entity type.
+	tables map[string]entityTable
+
+	translations map[translationKey]*translation
+
+	admins        []*model.Admin
+	recoveryCodes []*recoveryCode
+	refreshTokens []*model.RefreshToken
+	revokedTokens map[string]time.Time
+	challenges    []*model.WebAuthnChallenge
+	credentials   []*model.WebAuthnCredential
+	apiKeys       []*model.APIKey
+
+	auditLog  []*model.AuditEntry
+	revisions []*model.Revision
+}
+
+var _ repository.Store = (*Store)(nil)
+
+func NewStore() *Store {
+	s := &Store{
+		skills: &table[model.Skill]{
+			entityType: model.AuditEntitySkill,
+			fields: func(v *model.Skill) fields {
+				return fields{&v.ID, &v.Version, &v.Publication, &v.SortOrder, &v.CreatedAt, &v.UpdatedAt}
+			},
+			clone: func(v model.Skill) model.Skill {
+				v.PublishAt = cloneTime(v.PublishAt)
+				return v
+			},
+		},
+		projects: &table[model.Project]{
+			entityType: model.AuditEntityProject,
+			fields: func(v *model.Project) fields {
+				return fields{&v.ID, &v.Version, &v.Publication, &v.SortOrder, &v.CreatedAt, &v.UpdatedAt}
+			},
+			clone: func(v model.Project) model.Project {
+				v.Tags = cloneStrings(v.Tags)
+				v.PublishAt = cloneTime(v.PublishAt)
+				return v
+			},
+		},
+		experiences: &table[model.Experience]{
+			entityType: model.AuditEntityExperience,
+			fields: func(v *model.Experience) fields {
+				return fields{&v.ID, &v.Version, &v.Publication, &v.SortOrder, &v.CreatedAt, &v.UpdatedAt}
+			},
+			clone: func(v model.Experience) model.Experience {
+				v.Description = cloneStrings(v.Description)
+				v.PublishAt = cloneTime(v.PublishAt)
+				return v
+			},
+		},
+		education: &table[model.Education]{
+			entityType: model.AuditEntityEducation,
+			fields: func(v *model.Education) fields {
+				return fields{&v.ID, &v.Version, &v.Publication, &v.SortOrder, &v.CreatedAt, &v.UpdatedAt}
+			},
+			clone: func(v model.Education) model.Education {
+				v.PublishAt = cloneTime(v.PublishAt)
+				return v
+			},
+		},
+		hobbies: &table[model.Hobby]{
+			entityType: model.AuditEntityHobby,
+			fields: func(v *model.Hobby) fields {
+				return fields{&v.ID, &v.Version, &v.Publication, &v.SortOrder, &v.CreatedAt, &v.UpdatedAt}
+			},
+			clone: func(v model.Hobby) model.Hobby {
+				v.PublishAt = cloneTime(v.PublishAt)
+				return v
+			},
+		},
+		testimonials: &table[model.Testimonial]{
+			entityType: model.AuditEntityTestimonial,
+			fields: func(v *model.Testimonial) fields {
+				return fields{id: &v.ID, createdAt: &v.CreatedAt, updatedAt: &v.UpdatedAt}
+			},
+			clone: func(v model.Testimonial) model.Testimonial { return v },
+		},
+		messages: &table[model.Message]{
+			entityType: model.AuditEntityMessage,
+			fields: func(v *model.Message) fields {
+				return fields{id: &v.ID, createdAt: &v.CreatedAt}
+			},
+			clone: func(v model.Message) model.Message { return v },
+		},
+		contactInfo: &table[model.ContactInfo]{
+			entityType: model.AuditEntityContactInfo,
+			fields: func(v *model.ContactInfo) fields {
+				return fields{id: &v.ID, version: &v.Version, updatedAt: &v.UpdatedAt}
+			},
+			clone: func(v model.ContactInfo) model.ContactInfo { return v },
+		},
+		translations:  map[translationKey]*translation{},
+		revokedTokens: map[string]time.Time{},
+	}
+	s.tables = map[string]entityTable{
+		model.AuditEntitySkill:       s.skills,
+		model.AuditEntityProject:     s.projects,
+		model.AuditEntityExperience:  s.experiences,
+		model.AuditEntityEducation:   s.education,
+		model.AuditEntityHobby:       s.hobbies,
+		model.AuditEntityTestimonial: s.testimonials,
+		model.AuditEntityMessage:     s.messages,
+		model.AuditEntityContactInfo: s.contactInfo,
+	}
+	return s
+}
+
+// === Tables ===
+
+// fields points at the bookkeeping fields of a stored value. Pointers are
+// nil for fields the type does not have.
+type fields struct {
+	id          *string
+	version     *int
+	publication *model.Publication
+	sortOrder   *int
+	createdAt   *time.Time
+	updatedAt   *time.Time
+}
+
+// table is the rows of one entity type, in insertion order. Deleting a
+// trashable row only sets its deletedAt.
+type table[T any] struct {
+	entityType string
+	fields     func(*T) fields
+	// clone copies a value so the table never shares slices or pointers
+	// with its callers.
+	clone func(T) T
+	rows  []*row[T]
+}
+
+type row[T any] struct {
+	value     T
+	deletedAt *time.Time
+}
+
+func (t *table[T]) id(r *row[T]) string {
+	return *t.fields(&r.value).id
+}
+
+// find returns the row with id, trashed or not.
+func (t *table[T]) find(id string) *row[T] {
+	for _, r := range t.rows {
+		if t.id(r) == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// live returns the row with id unless it is missing or in the trash.
+func (t *table[T]) live(id string) *row[T] {
+	r := t.find(id)
+	if r == nil || r.deletedAt != nil {
+		return nil
+	}
+	return r
+}
+
+// list returns copies of the rows not in the trash, in sort order when the
+// type has one. With publishedOnly, drafts and scheduled rows whose time
+// has not come are left out; types without a publication status are
+// always live.
+func (t *table[T]) list(publishedOnly bool, now time.Time) []T {
+	var values []T
+	for _, r := range t.rows {
+		if r.deletedAt != nil {
+			continue
+		}
+		if p := t.fields(&r.value).publication; publishedOnly && p != nil && !isLive(*p, now) {
+			continue
+		}
+		values = append(values, t.clone(r.value))
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		a, b := t.fields(&values[i]), t.fields(&values[j])
+		return a.sortOrder != nil && *a.sortOrder < *b.sortOrder
+	})
+	return values
+}
+
+// insert stores a copy of v with a new id, version 1 and the given time.
+func (t *table[T]) insert(v T, now time.Time) *row[T] {
+	v = t.clone(v)
+	model.SetTranslations(&v, nil)
+	model.SetNeedsReview(&v, nil)
+
+	f := t.fields(&v)
+	*f.id = newID()
+	if f.version != nil {
+		*f.version = 1
+	}
+	if f.createdAt != nil {
+		*f.createdAt = now
+	}
+	if f.updatedAt != nil {
+		*f.updatedAt = now
+	}
+	r := &row[T]{value: v}
+	t.rows = append(t.rows, r)
+	return r
+}
+
+// update replaces a live row at v's version with v, keeping its creation
+// time, and its status and publish time when v has no status.
+func (t *table[T]) update(v T, now time.Time) (*row[T], error) {
+	v = t.clone(v)
+	model.SetTranslations(&v, nil)
+	model.SetNeedsReview(&v, nil)
+
+	f := t.fields(&v)
+	r, err := t.checkVersion(*f.id, *f.version)
+	if err != nil {
+		return nil, err
+	}
+	stored := t.fields(&r.value)
+	if f.publication.Status == "" {
+		*f.publication = *stored.publication
+	}
+	*f.createdAt = *stored.createdAt
+	*f.version = *stored.version + 1
+	*f.updatedAt = now
+	r.value = v
+	return r, nil
+}
+
+// checkVersion returns the live row with id if it is at version, and
+// otherwise ErrNotFound or ErrVersionConflict.
+func (t *table[T]) checkVersion(id string, version int) (*row[T], error) {
+	r := t.live(id)
+	if r == nil {
+		return nil, repository.ErrNotFound
+	}
+	if *t.fields(&r.value).version != version {
+		return nil, repository.ErrVersionConflict
+	}
+	return r, nil
+}
+
+// trash moves a live row to the trash, bumping its version if it has one.
+func (t *table[T]) trash(r *row[T], now time.Time) {
+	r.deletedAt = &now
+	if version := t.fields(&r.value).version; version != nil {
+		*version++
+	}
+}
+
+// entityTable is what snapshots, revisions, the trash, publishing and the
+// translation report need from a table, whatever its type.
+type entityTable interface {
+	// document returns the row with id, trashed or not, as a snapshot
+	// without translations.
+	document(id string) (map[string]json.RawMessage, bool)
+	// liveDocuments returns the rows that show on the public site at now,
+	// in sort order.
+	liveDocuments(now time.Time) []document
+	trashed() []trashedRow
+	untrash(id string) bool
+	// purge drops rows trashed before cutoff and returns their ids.
+	purge(cutoff time.Time) []string
+	// restore sets the given JSON fields of a live row at version from doc.
+	restore(id string, version int, doc map[string]json.RawMessage, fieldNames []string, now time.Time) error
+	// touch moves a live row at version to the next one.
+	touch(id string, version int, now time.Time) error
+	// publishDue publishes scheduled rows whose time has come.
+	publishDue(now time.Time) int64
+}
+
+type document struct {
+	id   string
+	data map[string]json.RawMessage
+}
+
+type trashedRow struct {
+	id        string
+	data      map[string]json.RawMessage
+	deletedAt time.Time
+}
+
+func (t *table[T]) document(id string) (map[string]json.RawMessage, bool) {
+	r := t.find(id)
+	if r == nil {
+		return nil, false
+	}
+	return t.encode(r.value, r.deletedAt), true
+}
+
+func (t *table[T]) liveDocuments(now time.Time) []document {
+	var docs []document
+	for _, value := range t.list(true, now) {
+		docs = append(docs, document{*t.fields(&value).id, t.encode(value, nil)})
+	}
+	return docs
+}
+
+// encode returns value as a snapshot: its JSON fields under their column
+// names, without the legacy French fields and review flags that are stored
+// as translations.
+func (t *table[T]) encode(value T, deletedAt *time.Time) map[string]json.RawMessage {
+	value = t.clone(value)
+	model.SetTranslations(&value, nil)
+	model.SetNeedsReview(&value, nil)
+	encoded, _ := json.Marshal(value)
+
+	var fieldsByName map[string]json.RawMessage
+	json.Unmarshal(encoded, &fieldsByName)
+
+	doc := map[string]json.RawMessage{}
+	for name, raw := range fieldsByName {
+		base, legacy := strings.CutSuffix(name, "Fr")
+		if _, translated := fieldsByName[base]; (legacy && translated) || name == "translations" || name == "needsReview" {
+			continue
+		}
+		doc[column(name)] = raw
+	}
+	if repository.IsTrashable(t.entityType) {
+		doc["deleted_at"], _ = json.Marshal(deletedAt)
+	}
+	return doc
+}
+
+func (t *table[T]) trashed() []trashedRow {
+	var rows []trashedRow
+	for _, r := range t.rows {
+		if r.deletedAt != nil {
+			rows = append(rows, trashedRow{t.id(r), t.encode(r.value, r.deletedAt), *r.deletedAt})
+		}
+	}
+	return rows
+}
+
+func (t *table[T]) untrash(id string) bool {
+	r := t.find(id)
+	if r == nil || r.deletedAt == nil {
+		return false
+	}
+	r.deletedAt = nil
+	if version := t.fields(&r.value).version; version != nil {
+		*version++
+	}
+	return true
+}
+
+func (t *table[T]) purge(cutoff time.Time) []string {
+	var purged []string
+	kept := t.rows[:0]
+	for _, r := range t.rows {
+		if r.deletedAt != nil && r.deletedAt.Before(cutoff) {
+			purged = append(purged, t.id(r))
+			continue
+		}
+		kept = append(kept, r)
+	}
+	t.rows = kept
+	return purged
+}
+
+func (t *table[T]) restore(id string, version int, doc map[string]json.RawMessage, fieldNames []string, now time.Time) error {
+	r, err := t.checkVersion(id, version)
+	if err != nil {
+		return err
+	}
+
+	restored := map[string]json.RawMessage{}
+	for _, name := range fieldNames {
+		if raw, ok := doc[column(name)]; ok {
+			restored[name] = raw
+		}
+	}
+	encoded, err := json.Marshal(restored)
+	if err != nil {
+		return err
+	}
+	value := t.clone(r.value)
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return err
+	}
+
+	f := t.fields(&value)
+	if f.version != nil {
+		*f.version++
+	}
+	if f.updatedAt != nil {
+		*f.updatedAt = now
+	}
+	r.value = value
+	return nil
+}
+
+func (t *table[T]) touch(id string, version int, now time.Time) error {
+	r, err := t.checkVersion(id, version)
+	if err != nil {
+		return err
+	}
+	f := t.fields(&r.value)
+	*f.version++
+	*f.updatedAt = now
+	return nil
+}
+
+func (t *table[T]) publishDue(now time.Time) int64 {
+	var published int64
+	for _, r := range t.rows {
+		f := t.fields(&r.value)
+		if r.deletedAt != nil || f.publication == nil {
+			continue
+		}
+		if p := f.publication; p.Status == model.StatusScheduled && p.PublishAt != nil && !p.PublishAt.After(now) {
+			p.Status = model.StatusPublished
+			*f.updatedAt = time.Now()
+			published++
+		}
+	}
+	return published
+}
+
+// isLive reports whether content with publication p shows on the public
+// site at now. Scheduled content counts once its time has come, even before
+// PublishScheduled has flipped it.
+func isLive(p model.Publication, now time.Time) bool {
+	switch p.Status {
+	case model.StatusPublished:
+		return true
+	case model.StatusScheduled:
+		return p.PublishAt != nil && !p.PublishAt.After(now)
+	}
+	return false
+}
+
+// === Helpers ===
+
+// column is the snapshot key of a JSON field: "aboutTitle" becomes
+// "about_title", like the Postgres column.
+func column(field string) string {
+	var b strings.Builder
+	for _, r := range field {
+		if unicode.IsUpper(r) {
+			b.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// newID returns a random version 4 UUID, the kind of id Postgres assigns.
+func newID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string{}, s...)
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
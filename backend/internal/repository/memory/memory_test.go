@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/portfolio/backend/internal/repository"
+	"github.com/portfolio/backend/internal/repository/memory"
+	"github.com/portfolio/backend/internal/repository/repositorytest"
+)
+
+func TestStoreContract(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.Store {
+		return memory.NewStore()
+	})
+}
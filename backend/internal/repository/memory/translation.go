@@ -0,0 +1,257 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// === Translations ===
+
+type translationKey struct {
+	entityType, id, locale, field string
+}
+
+type translation struct {
+	// value is decoded from JSON, so lists are []any as they are when read
+	// from Postgres.
+	value   any
+	machine bool
+	// sourceHash is the hash of the source text when the translation was
+	// last written; it differs from the current one once the source changes.
+	sourceHash string
+	updatedAt  time.Time
+}
+
+// storedTranslations returns the translations of an entity and the machine
+// translations no one has edited yet.
+func (s *Store) storedTranslations(entityType, id string) (model.Translations, model.ReviewFlags) {
+	var values model.Translations
+	var needsReview model.ReviewFlags
+	for _, key := range s.translationKeys(entityType, id) {
+		t := s.translations[key]
+		if values == nil {
+			values = model.Translations{}
+		}
+		values.Set(key.locale, key.field, copyValue(t.value))
+		if t.machine {
+			if needsReview == nil {
+				needsReview = model.ReviewFlags{}
+			}
+			needsReview[key.locale] = append(needsReview[key.locale], key.field)
+		}
+	}
+	return values, needsReview
+}
+
+// translationKeys returns the keys of an entity's translations by field.
+func (s *Store) translationKeys(entityType, id string) []translationKey {
+	var keys []translationKey
+	for key := range s.translations {
+		if key.entityType == entityType && key.id == id {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].field != keys[j].field {
+			return keys[i].field < keys[j].field
+		}
+		return keys[i].locale < keys[j].locale
+	})
+	return keys
+}
+
+// applyTranslations sets the stored translations on entity, a pointer to a
+// model struct.
+func (s *Store) applyTranslations(entityType, id string, entity any) {
+	values, needsReview := s.storedTranslations(entityType, id)
+	model.SetTranslations(entity, values)
+	model.SetNeedsReview(entity, needsReview)
+}
+
+// saveTranslations applies t to an entity: empty values delete a
+// translation, others replace it unless the text is unchanged, so editing
+// only the source marks a translation stale and only editing the text
+// clears its review flag.
+func (s *Store) saveTranslations(entityType, id string, t model.Translations, now time.Time) {
+	for locale, fieldValues := range t {
+		for field, value := range fieldValues {
+			key := translationKey{entityType, id, locale, field}
+			if model.IsEmptyTranslation(value) {
+				delete(s.translations, key)
+				continue
+			}
+			value = copyValue(value)
+			if existing, ok := s.translations[key]; ok && sameValue(existing.value, value) {
+				continue
+			}
+			s.translations[key] = &translation{value: value, sourceHash: s.sourceHash(entityType, id, field), updatedAt: now}
+		}
+	}
+}
+
+// replaceTranslations makes t the entity's complete set of translations.
+func (s *Store) replaceTranslations(entityType, id string, t model.Translations, now time.Time) {
+	s.deleteTranslations(entityType, id)
+	s.saveTranslations(entityType, id, t, now)
+}
+
+func (s *Store) deleteTranslations(entityType, id string) {
+	for _, key := range s.translationKeys(entityType, id) {
+		delete(s.translations, key)
+	}
+}
+
+// sourceHash hashes the current source text of a translated field.
+func (s *Store) sourceHash(entityType, id, field string) string {
+	doc, _ := s.tables[entityType].document(id)
+	return hashSource(doc[column(field)])
+}
+
+func hashSource(raw json.RawMessage) string {
+	var compact bytes.Buffer
+	json.Compact(&compact, raw)
+	sum := sha256.Sum256(compact.Bytes())
+	return string(sum[:])
+}
+
+// AddMachineTranslations stores t as machine translations of an entity that
+// need review and moves the entity to a new version, provided it is still at
+// version. Fields that already have a translation keep it.
+func (s *Store) AddMachineTranslations(_ context.Context, entityType, id string, version int, t model.Translations) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if err := s.tables[entityType].touch(id, version, now); err != nil {
+		return err
+	}
+	for locale, fieldValues := range t {
+		for field, value := range fieldValues {
+			key := translationKey{entityType, id, locale, field}
+			if _, ok := s.translations[key]; ok || model.IsEmptyTranslation(value) {
+				continue
+			}
+			s.translations[key] = &translation{value: copyValue(value), machine: true, sourceHash: s.sourceHash(entityType, id, field), updatedAt: now}
+		}
+	}
+	return nil
+}
+
+// MarkTranslationsReviewed brings the source hash of the locale translations
+// of fields up to date and clears their machine-translation flag, leaving
+// the text alone.
+func (s *Store) MarkTranslationsReviewed(_ context.Context, entityType, id, locale string, fields []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, field := range fields {
+		if _, ok := s.translations[translationKey{entityType, id, locale, field}]; !ok {
+			return repository.ErrNotFound
+		}
+	}
+	now := time.Now()
+	for _, field := range fields {
+		t := s.translations[translationKey{entityType, id, locale, field}]
+		t.sourceHash = s.sourceHash(entityType, id, field)
+		t.machine = false
+		t.updatedAt = now
+	}
+	return nil
+}
+
+// === Translation status ===
+
+func (s *Store) TranslationStatus(_ context.Context, locales []string) (model.TranslationStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := model.TranslationStatus{
+		Locales: []model.LocaleCoverage{},
+		Missing: []model.TranslationGap{},
+		Stale:   []model.TranslationGap{},
+	}
+	coverage := make(map[string]*model.LocaleCoverage, len(locales))
+	for _, locale := range locales {
+		coverage[locale] = &model.LocaleCoverage{Locale: locale}
+	}
+
+	now := time.Now()
+	for _, entityType := range sortedKeys(model.TranslatableFields) {
+		fieldNames := sortedKeys(model.TranslatableFields[entityType])
+		for _, doc := range s.tables[entityType].liveDocuments(now) {
+			for _, field := range fieldNames {
+				source := doc.data[column(field)]
+				var text any
+				json.Unmarshal(source, &text)
+				if model.IsEmptyTranslation(text) {
+					continue
+				}
+
+				for _, locale := range locales {
+					gap := model.TranslationGap{EntityType: entityType, EntityID: doc.id, Locale: locale, Field: field}
+					counts := coverage[locale]
+					counts.Total++
+
+					t, ok := s.translations[translationKey{entityType, doc.id, locale, field}]
+					if !ok {
+						counts.Missing++
+						status.Missing = append(status.Missing, gap)
+						continue
+					}
+					counts.Translated++
+					if t.sourceHash != hashSource(source) {
+						counts.Stale++
+						translatedAt := t.updatedAt
+						gap.TranslatedAt = &translatedAt
+						status.Stale = append(status.Stale, gap)
+					}
+				}
+			}
+		}
+	}
+
+	for _, locale := range locales {
+		counts := coverage[locale]
+		counts.Coverage = 100
+		if counts.Total > 0 {
+			counts.Coverage = math.Round(float64(counts.Translated)*1000/float64(counts.Total)) / 10
+		}
+		status.Locales = append(status.Locales, *counts)
+	}
+	return status, nil
+}
+
+// copyValue returns value as it reads back from JSON, sharing nothing with
+// the caller.
+func copyValue(value any) any {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var decoded any
+	json.Unmarshal(encoded, &decoded)
+	return decoded
+}
+
+func sameValue(a, b any) bool {
+	x, _ := json.Marshal(a)
+	y, _ := json.Marshal(b)
+	return bytes.Equal(x, y)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
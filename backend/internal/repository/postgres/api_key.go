@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/portfolio/backend/internal/model"
+)
+
+// === API keys ===
+
+const apiKeyColumns = "k.id, k.admin_id, a.role, k.name, k.prefix, k.key_hash, k.scopes, k.expires_at, k.last_used_at, k.created_at"
+
+func scanAPIKey(row pgx.Row) (model.APIKey, error) {
+	var key model.APIKey
+	err := row.Scan(&key.ID, &key.AdminID, &key.AdminRole, &key.Name, &key.Prefix, &key.KeyHash,
+		&key.Scopes, &key.ExpiresAt, &key.LastUsedAt, &key.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return key, ErrNotFound
+	}
+	return key, err
+}
+
+func (r *Repository) CreateAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error) {
+	query := `
+		WITH k AS (
+			INSERT INTO api_keys (admin_id, name, prefix, key_hash, scopes, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING *
+		)
+		SELECT ` + apiKeyColumns + ` FROM k JOIN admin a ON a.id = k.admin_id`
+	return scanAPIKey(r.db.QueryRow(ctx, query, key.AdminID, key.Name, key.Prefix, key.KeyHash, key.Scopes, key.ExpiresAt))
+}
+
+func (r *Repository) GetAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys k JOIN admin a ON a.id = k.admin_id ORDER BY k.created_at DESC`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []model.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// GetAPIKeyByHash looks a key up together with its creator's current role.
+func (r *Repository) GetAPIKeyByHash(ctx context.Context, keyHash string) (model.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys k JOIN admin a ON a.id = k.admin_id WHERE k.key_hash = $1`
+	return scanAPIKey(r.db.QueryRow(ctx, query, keyHash))
+}
+
+// TouchAPIKey records that a key was used. Writes are coalesced to once a minute
+// so a busy script does not update the row on every request.
+func (r *Repository) TouchAPIKey(ctx context.Context, id string) error {
+	query := `UPDATE api_keys SET last_used_at = NOW() WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')`
+	_, err := r.db.Exec(ctx, query, id)
+	return err
+}
+
+func (r *Repository) DeleteAPIKey(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM api_keys WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// === Audit log ===
+
+// auditTables maps the entity types that live in a table to that table, so
+// snapshots never interpolate anything but these constants into SQL.
+var auditTables = map[string]string{
+	model.AuditEntitySkill:       "skills",
+	model.AuditEntityProject:     "projects",
+	model.AuditEntityExperience:  "experiences",
+	model.AuditEntityEducation:   "education",
+	model.AuditEntityHobby:       "hobbies",
+	model.AuditEntityTestimonial: "testimonials",
+	model.AuditEntityMessage:     "messages",
+	model.AuditEntityContactInfo: "contact_info",
+}
+
+// SnapshotEntity returns the stored row as JSON, or nil when the entity type
+// has no table or the row does not exist. Translatable entities carry their
+// translations under "translations".
+func (r *Repository) SnapshotEntity(ctx context.Context, entityType, id string) (json.RawMessage, error) {
+	table, ok := auditTables[entityType]
+	if !ok || id == "" {
+		return nil, nil
+	}
+
+	var snapshot json.RawMessage
+	query := fmt.Sprintf(`SELECT to_jsonb(t) FROM %s t WHERE t.id::text = $1`, table)
+	args := []any{id}
+	if repository.IsTranslatable(entityType) {
+		query = fmt.Sprintf(`SELECT to_jsonb(t) || jsonb_build_object('translations', %s) FROM %s t WHERE t.id::text = $1`, translationsSnapshotSQL, table)
+		args = append(args, entityType)
+	}
+	err := r.db.QueryRow(ctx, query, args...).Scan(&snapshot)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return snapshot, err
+}
+
+func (r *Repository) CreateAuditEntry(ctx context.Context, entry model.AuditEntry) error {
+	query := `
+		INSERT INTO audit_log (actor_id, actor_email, api_key_id, action, entity_type, entity_id, before, after, ip_address)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.Exec(ctx, query, entry.ActorID, entry.ActorEmail, entry.APIKeyID, entry.Action,
+		entry.EntityType, entry.EntityID, entry.Before, entry.After, entry.IPAddress)
+	return err
+}
+
+// ListAuditEntries returns one page of entries, newest first, plus the number
+// of entries matching the filter.
+func (r *Repository) ListAuditEntries(ctx context.Context, filter model.AuditLogFilter) ([]model.AuditEntry, int, error) {
+	var conditions []string
+	var args []any
+	where := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.ActorID != "" {
+		where("l.actor_id::text = $%d", filter.ActorID)
+	}
+	if filter.Action != "" {
+		where("l.action = $%d", filter.Action)
+	}
+	if filter.EntityType != "" {
+		where("l.entity_type = $%d", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		where("l.entity_id = $%d", filter.EntityID)
+	}
+	if filter.From != nil {
+		where("l.created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		where("l.created_at < $%d", *filter.To)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM audit_log l `+whereClause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// API key requests carry no email in the context, so fall back to the
+	// actor's current address.
+	query := fmt.Sprintf(`
+		SELECT l.id, l.actor_id, COALESCE(NULLIF(l.actor_email, ''), a.email, ''), l.api_key_id,
+			l.action, l.entity_type, l.entity_id, l.before, l.after, l.ip_address, l.created_at
+		FROM audit_log l
+		LEFT JOIN admin a ON a.id = l.actor_id
+		%s
+		ORDER BY l.created_at DESC, l.id
+		LIMIT $%d OFFSET $%d
+	`, whereClause, len(args)+1, len(args)+2)
+	rows, err := r.db.Query(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := []model.AuditEntry{}
+	for rows.Next() {
+		var entry model.AuditEntry
+		if err := rows.Scan(&entry.ID, &entry.ActorID, &entry.ActorEmail, &entry.APIKeyID,
+			&entry.Action, &entry.EntityType, &entry.EntityID, &entry.Before, &entry.After,
+			&entry.IPAddress, &entry.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, total, rows.Err()
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/portfolio/backend/internal/ratelimit"
+)
+
+// === Rate limiting ===
+// These methods make Repository a ratelimit.Store, so lockouts and contact
+// quotas are shared by every instance and survive restarts.
+
+var _ ratelimit.Store = (*Repository)(nil)
+
+func (r *Repository) LoginBlockedUntil(ctx context.Context, key string, now time.Time) (time.Time, error) {
+	var blockedUntil *time.Time
+	err := r.db.QueryRow(ctx, `SELECT blocked_until FROM login_attempts WHERE key = $1`, key).Scan(&blockedUntil)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	if blockedUntil == nil {
+		return time.Time{}, nil
+	}
+	return *blockedUntil, nil
+}
+
+// RegisterLoginFailure locks the key's row so concurrent failures on different
+// instances are counted one after the other.
+func (r *Repository) RegisterLoginFailure(ctx context.Context, key string, now time.Time, policy ratelimit.LoginPolicy) (time.Duration, bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `INSERT INTO login_attempts (key) VALUES ($1) ON CONFLICT (key) DO NOTHING`, key); err != nil {
+		return 0, false, err
+	}
+
+	var state ratelimit.LoginState
+	var lastFailedAt, blockedUntil *time.Time
+	query := `SELECT failed_count, last_failed_at, blocked_until FROM login_attempts WHERE key = $1 FOR UPDATE`
+	if err := tx.QueryRow(ctx, query, key).Scan(&state.FailedCount, &lastFailedAt, &blockedUntil); err != nil {
+		return 0, false, err
+	}
+	if lastFailedAt != nil {
+		state.LastFailedAt = *lastFailedAt
+	}
+	if blockedUntil != nil {
+		state.BlockedUntil = *blockedUntil
+	}
+
+	state, remaining, blocked := policy.RegisterFailure(state, now)
+
+	update := `UPDATE login_attempts SET failed_count = $1, last_failed_at = $2, blocked_until = $3, updated_at = $4 WHERE key = $5`
+	if _, err := tx.Exec(ctx, update, state.FailedCount, nullTime(state.LastFailedAt), nullTime(state.BlockedUntil), now, key); err != nil {
+		return 0, false, err
+	}
+	return remaining, blocked, tx.Commit(ctx)
+}
+
+func (r *Repository) ResetLoginFailures(ctx context.Context, key string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM login_attempts WHERE key = $1`, key)
+	return err
+}
+
+// AllowRequest serialises requests for one key with an advisory lock, then
+// counts the hits inside the sliding window and the current UTC day.
+func (r *Repository) AllowRequest(ctx context.Context, key string, now time.Time, policy ratelimit.RatePolicy) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, key); err != nil {
+		return false, err
+	}
+
+	windowStart := now.Add(-policy.Window)
+	dayStart := ratelimit.DayStart(now)
+	query := `
+		SELECT COUNT(*) FILTER (WHERE created_at >= $2),
+			COUNT(*) FILTER (WHERE created_at >= $3)
+		FROM rate_limit_hits
+		WHERE key = $1 AND created_at >= LEAST($2::timestamptz, $3::timestamptz)
+	`
+	var inWindow, today int
+	if err := tx.QueryRow(ctx, query, key, windowStart, dayStart).Scan(&inWindow, &today); err != nil {
+		return false, err
+	}
+	if inWindow >= policy.MaxInWindow || today >= policy.MaxPerDay {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(ctx, `INSERT INTO rate_limit_hits (key, created_at) VALUES ($1, $2)`, key, now); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+// PurgeLimiterState drops lockout and rate limit rows nobody has touched recently.
+func (r *Repository) PurgeLimiterState(ctx context.Context) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM login_attempts WHERE updated_at < NOW() - INTERVAL '72 hours'`); err != nil {
+		return err
+	}
+	_, err := r.db.Exec(ctx, `DELETE FROM rate_limit_hits WHERE created_at < NOW() - INTERVAL '48 hours'`)
+	return err
+}
+
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,264 @@
+package postgres
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Migrations live in migrations/ as NNN_name.up.sql and NNN_name.down.sql and
+// are applied in version order, each in its own transaction, and recorded in
+// schema_migrations with a checksum of the up file. Migrations 001 to 022 are
+// idempotent: databases set up before the runner existed have no
+// schema_migrations table, so they replay them all on first start.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the Postgres advisory lock held while migrating, so
+// replicas starting together apply each migration once.
+const migrationLockID int64 = 7_230_951_001
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// ErrMigrationChanged is returned when an applied migration's up file no
+// longer matches the checksum recorded for it.
+var ErrMigrationChanged = errors.New("applied migration has changed")
+
+// Migration is a schema migration and whether it has been applied.
+type Migration struct {
+	Version   int
+	Name      string
+	Checksum  string
+	AppliedAt *time.Time
+	// Modified is set when the up file no longer matches the checksum
+	// recorded when it was applied.
+	Modified bool
+	// Missing is set for applied migrations this build does not know about.
+	Missing bool
+
+	up, down string
+}
+
+// loadMigrations reads the migrations in fsys, sorted by version.
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	paths, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, path := range paths {
+		match := migrationFileName.FindStringSubmatch(path)
+		if match == nil {
+			return nil, fmt.Errorf("migration %s is not named NNN_name.up.sql or NNN_name.down.sql", path)
+		}
+		version, _ := strconv.Atoi(match[1])
+		m := byVersion[version]
+		if m == nil {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, m.Name, match[2])
+		}
+
+		contents, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "up" {
+			m.up = string(contents)
+			sum := sha256.Sum256(contents)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.down = string(contents)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" || m.down == "" {
+			return nil, fmt.Errorf("migration %03d_%s needs both an up and a down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+func embeddedMigrations() ([]Migration, error) {
+	fsys, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return loadMigrations(fsys)
+}
+
+// Migrate applies the pending migrations and returns them. It refuses to run
+// when an applied migration has been edited since, and leaves alone those
+// applied by a newer build.
+func Migrate(ctx context.Context, pool *pgxpool.Pool) ([]Migration, error) {
+	var applied []Migration
+	err := withMigrationLock(ctx, pool, func(conn *pgx.Conn) error {
+		migrations, err := migrationStatus(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, m := range migrations {
+			if m.Modified {
+				return fmt.Errorf("%w: %03d_%s", ErrMigrationChanged, m.Version, m.Name)
+			}
+		}
+
+		for _, m := range migrations {
+			if m.AppliedAt != nil || m.Missing {
+				continue
+			}
+			err := runMigration(ctx, conn, m.up, `INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`, m.Version, m.Name, m.Checksum)
+			if err != nil {
+				return fmt.Errorf("migration %03d_%s failed: %w", m.Version, m.Name, err)
+			}
+			applied = append(applied, m)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// RollbackMigrations reverts the last steps applied migrations, newest first,
+// and returns them.
+func RollbackMigrations(ctx context.Context, pool *pgxpool.Pool, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := withMigrationLock(ctx, pool, func(conn *pgx.Conn) error {
+		migrations, err := migrationStatus(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			m := migrations[i]
+			if m.AppliedAt == nil {
+				continue
+			}
+			if m.Missing {
+				return fmt.Errorf("migration %03d_%s is not part of this build and cannot be reverted", m.Version, m.Name)
+			}
+			err := runMigration(ctx, conn, m.down, `DELETE FROM schema_migrations WHERE version = $1`, m.Version)
+			if err != nil {
+				return fmt.Errorf("reverting migration %03d_%s failed: %w", m.Version, m.Name, err)
+			}
+			reverted = append(reverted, m)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// MigrationStatus lists the known migrations and those recorded as applied,
+// by version.
+func MigrationStatus(ctx context.Context, pool *pgxpool.Pool) ([]Migration, error) {
+	var migrations []Migration
+	err := withMigrationLock(ctx, pool, func(conn *pgx.Conn) error {
+		var err error
+		migrations, err = migrationStatus(ctx, conn)
+		return err
+	})
+	return migrations, err
+}
+
+// withMigrationLock runs fn on a connection holding the migration lock, once
+// schema_migrations exists.
+func withMigrationLock(ctx context.Context, pool *pgxpool.Pool, fn func(conn *pgx.Conn) error) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+
+	_, err = conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			checksum VARCHAR(64) NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`)
+	if err != nil {
+		return err
+	}
+	return fn(conn.Conn())
+}
+
+func migrationStatus(ctx context.Context, conn *pgx.Conn) ([]Migration, error) {
+	migrations, err := embeddedMigrations()
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int]int{}
+	for i, m := range migrations {
+		byVersion[m.Version] = i
+	}
+
+	rows, err := conn.Query(ctx, `SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int
+		var name, checksum string
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &name, &checksum, &appliedAt); err != nil {
+			return nil, err
+		}
+		i, ok := byVersion[version]
+		if !ok {
+			migrations = append(migrations, Migration{Version: version, Name: name, Checksum: checksum, AppliedAt: &appliedAt, Missing: true})
+			continue
+		}
+		migrations[i].AppliedAt = &appliedAt
+		migrations[i].Modified = migrations[i].Checksum != checksum
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// runMigration runs script, then the bookkeeping statement, in one transaction.
+func runMigration(ctx context.Context, conn *pgx.Conn, script, record string, args ...any) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Without arguments the script runs over the simple protocol, which
+	// allows several statements.
+	if _, err := tx.Exec(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
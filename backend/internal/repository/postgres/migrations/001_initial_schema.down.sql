@@ -0,0 +1,13 @@
+-- Migration: 001_initial_schema.down.sql
+-- Drops the initial schema, and with it all content
+DROP TABLE IF EXISTS messages;
+DROP TABLE IF EXISTS testimonials;
+DROP TABLE IF EXISTS resume;
+DROP TABLE IF EXISTS hobbies;
+DROP TABLE IF EXISTS education;
+DROP TABLE IF EXISTS experiences;
+DROP TABLE IF EXISTS projects;
+DROP TABLE IF EXISTS skills;
+DROP TABLE IF EXISTS contact_info;
+DROP TABLE IF EXISTS admin;
+DROP FUNCTION IF EXISTS update_updated_at_column();
@@ -1,5 +1,5 @@
 -- Initial schema for Portfolio application (Single Admin Model)
--- Migration: 001_initial_schema.sql
+-- Migration: 001_initial_schema.up.sql
 
 -- Enable UUID extension
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
@@ -128,16 +128,16 @@ CREATE TABLE IF NOT EXISTS messages (
 );
 
 -- Indexes for better query performance
-CREATE INDEX idx_skills_sort_order ON skills(sort_order);
-CREATE INDEX idx_projects_sort_order ON projects(sort_order);
-CREATE INDEX idx_projects_featured ON projects(featured);
-CREATE INDEX idx_experiences_sort_order ON experiences(sort_order);
-CREATE INDEX idx_education_sort_order ON education(sort_order);
-CREATE INDEX idx_hobbies_sort_order ON hobbies(sort_order);
-CREATE INDEX idx_testimonials_status ON testimonials(status);
-CREATE INDEX idx_messages_is_read ON messages(is_read);
-CREATE INDEX idx_messages_created_at ON messages(created_at DESC);
-CREATE INDEX idx_messages_email_hash_created_at ON messages(email, content_hash, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_skills_sort_order ON skills(sort_order);
+CREATE INDEX IF NOT EXISTS idx_projects_sort_order ON projects(sort_order);
+CREATE INDEX IF NOT EXISTS idx_projects_featured ON projects(featured);
+CREATE INDEX IF NOT EXISTS idx_experiences_sort_order ON experiences(sort_order);
+CREATE INDEX IF NOT EXISTS idx_education_sort_order ON education(sort_order);
+CREATE INDEX IF NOT EXISTS idx_hobbies_sort_order ON hobbies(sort_order);
+CREATE INDEX IF NOT EXISTS idx_testimonials_status ON testimonials(status);
+CREATE INDEX IF NOT EXISTS idx_messages_is_read ON messages(is_read);
+CREATE INDEX IF NOT EXISTS idx_messages_created_at ON messages(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_messages_email_hash_created_at ON messages(email, content_hash, created_at DESC);
 
 -- Updated_at trigger function
 CREATE OR REPLACE FUNCTION update_updated_at_column()
@@ -149,41 +149,49 @@ END;
 $$ language 'plpgsql';
 
 -- Apply updated_at trigger to all tables with updated_at column
+DROP TRIGGER IF EXISTS update_admin_updated_at ON admin;
 CREATE TRIGGER update_admin_updated_at
     BEFORE UPDATE ON admin
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_contact_info_updated_at ON contact_info;
 CREATE TRIGGER update_contact_info_updated_at
     BEFORE UPDATE ON contact_info
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_skills_updated_at ON skills;
 CREATE TRIGGER update_skills_updated_at
     BEFORE UPDATE ON skills
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_projects_updated_at ON projects;
 CREATE TRIGGER update_projects_updated_at
     BEFORE UPDATE ON projects
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_experiences_updated_at ON experiences;
 CREATE TRIGGER update_experiences_updated_at
     BEFORE UPDATE ON experiences
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_education_updated_at ON education;
 CREATE TRIGGER update_education_updated_at
     BEFORE UPDATE ON education
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_hobbies_updated_at ON hobbies;
 CREATE TRIGGER update_hobbies_updated_at
     BEFORE UPDATE ON hobbies
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
+DROP TRIGGER IF EXISTS update_testimonials_updated_at ON testimonials;
 CREATE TRIGGER update_testimonials_updated_at
     BEFORE UPDATE ON testimonials
     FOR EACH ROW
@@ -197,8 +205,8 @@ ON CONFLICT (email) DO NOTHING;
 
 -- Insert default contact info
 INSERT INTO contact_info (email, location)
-VALUES ('clay@portfolio.com', 'Montreal, QC')
-ON CONFLICT DO NOTHING;
+SELECT 'clay@portfolio.com', 'Montreal, QC'
+WHERE NOT EXISTS (SELECT 1 FROM contact_info);
 
 -- Migration: 002_add_subject.sql
 -- Add subject to messages table
@@ -208,12 +216,15 @@ ALTER TABLE messages ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64) DEFAULT '
 -- Migration: 003_seed_testimonials.sql
 -- Seed testimonials
 INSERT INTO testimonials (author_name, author_role, author_email, content, rating, status)
-VALUES 
+SELECT * FROM (VALUES
 ('Sarah Jenkins', 'Product Manager', 'sarah.j@techstart.com', 'Working with this developer was an absolute pleasure. They understood our requirements perfectly and delivered a high-quality solution ahead of schedule.', 5, 'approved'),
 ('Michael Chen', 'CTO', 'michael@innovate.io', 'Exceptional technical skills and great communication. The code quality was top-notch and easy to maintain.', 5, 'approved'),
 ('Jessica Wu', 'Designer', 'jessica@creative.net', 'Good attention to detail on the frontend implementation. The animations are smooth and the responsive design works flawlessly.', 4, 'approved'),
 ('David Miller', 'Founder', 'david@startup.co', 'Solid work on the backend architecture. Highly recommended for full-stack projects.', 5, 'approved'),
-('Emily Brown', 'Marketing Director', 'emily@growth.com', 'Great results! Our site performance improved significantly.', 5, 'pending');
+('Emily Brown', 'Marketing Director', 'emily@growth.com', 'Great results! Our site performance improved significantly.', 5, 'pending')
+) AS v(author_name, author_role, author_email, content, rating, status)
+WHERE NOT EXISTS (SELECT 1 FROM testimonials)
+AND NOT EXISTS (SELECT 1 FROM admin WHERE password_hash <> '$2a$10$placeholder-hash');
 
 -- Migration: 004_add_project_french_fields.sql
 -- Add French fields to projects table
@@ -0,0 +1,12 @@
+-- Migration: 002_add_french_fields.down.sql
+ALTER TABLE education
+DROP COLUMN IF EXISTS degree_fr,
+DROP COLUMN IF EXISTS school_fr,
+DROP COLUMN IF EXISTS location_fr,
+DROP COLUMN IF EXISTS description_fr;
+
+ALTER TABLE experiences
+DROP COLUMN IF EXISTS title_fr,
+DROP COLUMN IF EXISTS company_fr,
+DROP COLUMN IF EXISTS location_fr,
+DROP COLUMN IF EXISTS description_fr;
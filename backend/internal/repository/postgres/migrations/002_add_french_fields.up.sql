@@ -0,0 +1,13 @@
+-- Add French fields to education table
+ALTER TABLE education
+ADD COLUMN IF NOT EXISTS degree_fr VARCHAR(255) DEFAULT '',
+ADD COLUMN IF NOT EXISTS school_fr VARCHAR(255) DEFAULT '',
+ADD COLUMN IF NOT EXISTS location_fr VARCHAR(255) DEFAULT '',
+ADD COLUMN IF NOT EXISTS description_fr TEXT DEFAULT '';
+
+-- Add French fields to experiences table
+ALTER TABLE experiences
+ADD COLUMN IF NOT EXISTS title_fr VARCHAR(255) DEFAULT '',
+ADD COLUMN IF NOT EXISTS company_fr VARCHAR(255) DEFAULT '',
+ADD COLUMN IF NOT EXISTS location_fr VARCHAR(255) DEFAULT '',
+ADD COLUMN IF NOT EXISTS description_fr TEXT[] DEFAULT '{}';
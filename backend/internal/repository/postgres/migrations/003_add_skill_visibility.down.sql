@@ -0,0 +1,2 @@
+-- Migration: 003_add_skill_visibility.down.sql
+ALTER TABLE skills DROP COLUMN IF EXISTS show_in_portfolio;
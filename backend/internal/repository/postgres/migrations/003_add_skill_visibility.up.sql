@@ -0,0 +1,2 @@
+-- Add show_in_portfolio column to skills table
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS show_in_portfolio BOOLEAN DEFAULT true;
@@ -0,0 +1,4 @@
+-- Migration: 004_add_project_french_fields.down.sql
+ALTER TABLE projects
+DROP COLUMN IF EXISTS title_fr,
+DROP COLUMN IF EXISTS description_fr;
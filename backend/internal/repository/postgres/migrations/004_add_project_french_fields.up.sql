@@ -1,4 +1,4 @@
--- Migration: 004_add_project_french_fields.sql
+-- Migration: 004_add_project_french_fields.up.sql
 -- Add French fields to projects table
 ALTER TABLE projects
 ADD COLUMN IF NOT EXISTS title_fr VARCHAR(255),
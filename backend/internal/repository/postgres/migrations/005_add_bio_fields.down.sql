@@ -0,0 +1,4 @@
+-- Migration: 005_add_bio_fields.down.sql
+ALTER TABLE contact_info
+DROP COLUMN IF EXISTS bio,
+DROP COLUMN IF EXISTS bio_fr;
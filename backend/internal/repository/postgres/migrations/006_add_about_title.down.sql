@@ -0,0 +1,3 @@
+-- Migration: 006_add_about_title.down.sql
+ALTER TABLE contact_info DROP COLUMN IF EXISTS about_title;
+ALTER TABLE contact_info DROP COLUMN IF EXISTS about_title_fr;
@@ -0,0 +1,2 @@
+-- Migration: 007_remove_placeholder_admin.down.sql
+-- Nothing to undo: the placeholder admin could never sign in.
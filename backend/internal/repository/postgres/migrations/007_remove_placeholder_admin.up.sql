@@ -0,0 +1,3 @@
+-- Remove the placeholder admin seeded by 001; it has no usable password hash.
+-- The backend creates the first real admin from ADMIN_USER/ADMIN_PASSWORD on startup.
+DELETE FROM admin WHERE password_hash = '$2a$10$placeholder-hash';
@@ -0,0 +1,3 @@
+-- Migration: 008_add_refresh_tokens.down.sql
+DROP TABLE IF EXISTS revoked_access_tokens;
+DROP TABLE IF EXISTS refresh_tokens;
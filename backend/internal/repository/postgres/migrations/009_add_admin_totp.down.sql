@@ -0,0 +1,7 @@
+-- Migration: 009_add_admin_totp.down.sql
+DROP TABLE IF EXISTS admin_recovery_codes;
+
+ALTER TABLE admin
+DROP COLUMN IF EXISTS totp_secret,
+DROP COLUMN IF EXISTS totp_enabled,
+DROP COLUMN IF EXISTS totp_last_step;
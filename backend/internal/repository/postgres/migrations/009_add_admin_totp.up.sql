@@ -0,0 +1,16 @@
+-- Migration: 009_add_admin_totp.up.sql
+-- Optional TOTP two-factor authentication for admins, with hashed single-use recovery codes
+ALTER TABLE admin
+ADD COLUMN IF NOT EXISTS totp_secret VARCHAR(64),
+ADD COLUMN IF NOT EXISTS totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN IF NOT EXISTS totp_last_step BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS admin_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    admin_id UUID NOT NULL REFERENCES admin(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_recovery_codes_admin_id ON admin_recovery_codes(admin_id);
@@ -0,0 +1,3 @@
+-- Migration: 010_add_webauthn.down.sql
+DROP TABLE IF EXISTS webauthn_challenges;
+DROP TABLE IF EXISTS webauthn_credentials;
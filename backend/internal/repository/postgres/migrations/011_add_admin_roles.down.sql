@@ -0,0 +1,2 @@
+-- Migration: 011_add_admin_roles.down.sql
+ALTER TABLE admin DROP COLUMN IF EXISTS role;
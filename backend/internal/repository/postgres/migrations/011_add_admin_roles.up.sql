@@ -0,0 +1,4 @@
+-- Migration: 011_add_admin_roles.up.sql
+-- Role-based access for multiple admin users; existing admins become owners
+ALTER TABLE admin
+ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'owner';
@@ -0,0 +1,2 @@
+-- Migration: 012_add_api_keys.down.sql
+DROP TABLE IF EXISTS api_keys;
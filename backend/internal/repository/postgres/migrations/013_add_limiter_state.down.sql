@@ -0,0 +1,3 @@
+-- Migration: 013_add_limiter_state.down.sql
+DROP TABLE IF EXISTS rate_limit_hits;
+DROP TABLE IF EXISTS login_attempts;
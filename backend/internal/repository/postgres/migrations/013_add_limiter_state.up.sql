@@ -0,0 +1,18 @@
+-- Migration: 013_add_limiter_state.up.sql
+-- Shared admin login lockouts and contact form rate limits (LIMITER_STORE=postgres)
+CREATE TABLE IF NOT EXISTS login_attempts (
+    key VARCHAR(255) PRIMARY KEY,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP WITH TIME ZONE,
+    blocked_until TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS rate_limit_hits (
+    id BIGSERIAL PRIMARY KEY,
+    key VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_hits_key_created_at ON rate_limit_hits(key, created_at);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_updated_at ON login_attempts(updated_at);
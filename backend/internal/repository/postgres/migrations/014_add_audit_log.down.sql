@@ -0,0 +1,2 @@
+-- Migration: 014_add_audit_log.down.sql
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,19 @@
+-- Migration: 014_add_audit_log.up.sql
+-- Records every mutating admin action with before/after snapshots
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor_id UUID REFERENCES admin(id) ON DELETE SET NULL,
+    actor_email VARCHAR(255) NOT NULL DEFAULT '',
+    api_key_id UUID,
+    action VARCHAR(32) NOT NULL,
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(64) NOT NULL DEFAULT '',
+    before JSONB,
+    after JSONB,
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor_id ON audit_log(actor_id);
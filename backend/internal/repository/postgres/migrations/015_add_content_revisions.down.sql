@@ -0,0 +1,2 @@
+-- Migration: 015_add_content_revisions.down.sql
+DROP TABLE IF EXISTS content_revisions;
@@ -0,0 +1,12 @@
+-- Migration: 015_add_content_revisions.up.sql
+-- Snapshot of a content row taken before each update, used for diff and rollback
+CREATE TABLE IF NOT EXISTS content_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(64) NOT NULL,
+    revision INTEGER NOT NULL,
+    snapshot JSONB NOT NULL,
+    actor_id UUID REFERENCES admin(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (entity_type, entity_id, revision)
+);
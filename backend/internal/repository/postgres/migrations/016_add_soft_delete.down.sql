@@ -0,0 +1,17 @@
+-- Migration: 016_add_soft_delete.down.sql
+-- Rows still in the trash are removed for good before the column goes.
+DELETE FROM skills WHERE deleted_at IS NOT NULL;
+DELETE FROM projects WHERE deleted_at IS NOT NULL;
+DELETE FROM experiences WHERE deleted_at IS NOT NULL;
+DELETE FROM education WHERE deleted_at IS NOT NULL;
+DELETE FROM hobbies WHERE deleted_at IS NOT NULL;
+DELETE FROM testimonials WHERE deleted_at IS NOT NULL;
+DELETE FROM messages WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE skills DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE experiences DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE education DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE hobbies DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE testimonials DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE messages DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,13 @@
+-- Migration: 017_add_publication_status.down.sql
+-- Drafts and scheduled rows become visible once the status is gone.
+DROP INDEX IF EXISTS idx_skills_scheduled;
+DROP INDEX IF EXISTS idx_projects_scheduled;
+DROP INDEX IF EXISTS idx_experiences_scheduled;
+DROP INDEX IF EXISTS idx_education_scheduled;
+DROP INDEX IF EXISTS idx_hobbies_scheduled;
+
+ALTER TABLE skills DROP COLUMN IF EXISTS status, DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS status, DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE experiences DROP COLUMN IF EXISTS status, DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE education DROP COLUMN IF EXISTS status, DROP COLUMN IF EXISTS publish_at;
+ALTER TABLE hobbies DROP COLUMN IF EXISTS status, DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,18 @@
+-- Migration: 017_add_publication_status.up.sql
+-- Draft/published/scheduled workflow; existing rows stay published
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'published', 'scheduled'));
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'published', 'scheduled'));
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE experiences ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'published', 'scheduled'));
+ALTER TABLE experiences ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE education ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'published', 'scheduled'));
+ALTER TABLE education ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE hobbies ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'published', 'scheduled'));
+ALTER TABLE hobbies ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_skills_scheduled ON skills(publish_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_projects_scheduled ON projects(publish_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_experiences_scheduled ON experiences(publish_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_education_scheduled ON education(publish_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_hobbies_scheduled ON hobbies(publish_at) WHERE status = 'scheduled';
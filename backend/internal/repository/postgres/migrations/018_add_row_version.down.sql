@@ -0,0 +1,6 @@
+-- Migration: 018_add_row_version.down.sql
+ALTER TABLE skills DROP COLUMN IF EXISTS version;
+ALTER TABLE projects DROP COLUMN IF EXISTS version;
+ALTER TABLE experiences DROP COLUMN IF EXISTS version;
+ALTER TABLE education DROP COLUMN IF EXISTS version;
+ALTER TABLE hobbies DROP COLUMN IF EXISTS version;
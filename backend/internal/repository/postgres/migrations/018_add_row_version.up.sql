@@ -0,0 +1,7 @@
+-- Migration: 018_add_row_version.up.sql
+-- Row versions for optimistic concurrency (ETag / If-Match) on admin edits
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE experiences ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE education ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE hobbies ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+-- Migration: 019_add_date_precision.down.sql
+-- Month-precision dates keep the first day of their month.
+ALTER TABLE experiences DROP COLUMN IF EXISTS start_date_precision, DROP COLUMN IF EXISTS end_date_precision;
+ALTER TABLE education DROP COLUMN IF EXISTS start_date_precision, DROP COLUMN IF EXISTS end_date_precision;
@@ -0,0 +1,10 @@
+-- Migration: 019_add_date_precision.up.sql
+-- Experience and education dates may be known only to the month ("2023-05")
+ALTER TABLE experiences ADD COLUMN IF NOT EXISTS start_date_precision VARCHAR(5) NOT NULL DEFAULT 'day' CHECK (start_date_precision IN ('day', 'month'));
+ALTER TABLE experiences ADD COLUMN IF NOT EXISTS end_date_precision VARCHAR(5) NOT NULL DEFAULT 'day' CHECK (end_date_precision IN ('day', 'month'));
+ALTER TABLE education ADD COLUMN IF NOT EXISTS start_date_precision VARCHAR(5) NOT NULL DEFAULT 'day' CHECK (start_date_precision IN ('day', 'month'));
+ALTER TABLE education ADD COLUMN IF NOT EXISTS end_date_precision VARCHAR(5) NOT NULL DEFAULT 'day' CHECK (end_date_precision IN ('day', 'month'));
+
+-- Empty end dates used to be stored as the zero time instead of NULL
+UPDATE experiences SET end_date = NULL WHERE end_date = '0001-01-01';
+UPDATE education SET end_date = NULL WHERE end_date = '0001-01-01';
@@ -0,0 +1,41 @@
+-- Migration: 020_add_translations.down.sql
+-- Restores the per-table French columns from the French translations. Other
+-- locales, and fields that never had a French column, are lost.
+ALTER TABLE projects
+ADD COLUMN IF NOT EXISTS title_fr VARCHAR(255),
+ADD COLUMN IF NOT EXISTS description_fr TEXT;
+ALTER TABLE experiences
+ADD COLUMN IF NOT EXISTS title_fr VARCHAR(255) DEFAULT '',
+ADD COLUMN IF NOT EXISTS company_fr VARCHAR(255) DEFAULT '',
+ADD COLUMN IF NOT EXISTS location_fr VARCHAR(255) DEFAULT '',
+ADD COLUMN IF NOT EXISTS description_fr TEXT[] DEFAULT '{}';
+ALTER TABLE education
+ADD COLUMN IF NOT EXISTS degree_fr VARCHAR(255) DEFAULT '',
+ADD COLUMN IF NOT EXISTS school_fr VARCHAR(255) DEFAULT '',
+ADD COLUMN IF NOT EXISTS location_fr VARCHAR(255) DEFAULT '',
+ADD COLUMN IF NOT EXISTS description_fr TEXT DEFAULT '';
+ALTER TABLE contact_info
+ADD COLUMN IF NOT EXISTS bio_fr TEXT DEFAULT '',
+ADD COLUMN IF NOT EXISTS about_title_fr TEXT DEFAULT '';
+
+UPDATE projects s SET
+    title_fr = (SELECT t.value #>> '{}' FROM translations t WHERE t.entity_type = 'project' AND t.entity_id = s.id AND t.locale = 'fr' AND t.field = 'title'),
+    description_fr = (SELECT t.value #>> '{}' FROM translations t WHERE t.entity_type = 'project' AND t.entity_id = s.id AND t.locale = 'fr' AND t.field = 'description');
+
+UPDATE experiences s SET
+    title_fr = COALESCE((SELECT t.value #>> '{}' FROM translations t WHERE t.entity_type = 'experience' AND t.entity_id = s.id AND t.locale = 'fr' AND t.field = 'title'), ''),
+    company_fr = COALESCE((SELECT t.value #>> '{}' FROM translations t WHERE t.entity_type = 'experience' AND t.entity_id = s.id AND t.locale = 'fr' AND t.field = 'company'), ''),
+    location_fr = COALESCE((SELECT t.value #>> '{}' FROM translations t WHERE t.entity_type = 'experience' AND t.entity_id = s.id AND t.locale = 'fr' AND t.field = 'location'), ''),
+    description_fr = COALESCE((SELECT ARRAY(SELECT jsonb_array_elements_text(t.value)) FROM translations t WHERE t.entity_type = 'experience' AND t.entity_id = s.id AND t.locale = 'fr' AND t.field = 'description'), '{}');
+
+UPDATE education s SET
+    degree_fr = COALESCE((SELECT t.value #>> '{}' FROM translations t WHERE t.entity_type = 'education' AND t.entity_id = s.id AND t.locale = 'fr' AND t.field = 'degree'), ''),
+    school_fr = COALESCE((SELECT t.value #>> '{}' FROM translations t WHERE t.entity_type = 'education' AND t.entity_id = s.id AND t.locale = 'fr' AND t.field = 'school'), ''),
+    location_fr = COALESCE((SELECT t.value #>> '{}' FROM translations t WHERE t.entity_type = 'education' AND t.entity_id = s.id AND t.locale = 'fr' AND t.field = 'location'), ''),
+    description_fr = COALESCE((SELECT t.value #>> '{}' FROM translations t WHERE t.entity_type = 'education' AND t.entity_id = s.id AND t.locale = 'fr' AND t.field = 'description'), '');
+
+UPDATE contact_info s SET
+    bio_fr = COALESCE((SELECT t.value #>> '{}' FROM translations t WHERE t.entity_type = 'contact_info' AND t.entity_id = s.id AND t.locale = 'fr' AND t.field = 'bio'), ''),
+    about_title_fr = COALESCE((SELECT t.value #>> '{}' FROM translations t WHERE t.entity_type = 'contact_info' AND t.entity_id = s.id AND t.locale = 'fr' AND t.field = 'aboutTitle'), '');
+
+DROP TABLE IF EXISTS translations;
@@ -0,0 +1,52 @@
+-- Migration: 020_add_translations.up.sql
+-- Translations of content fields, keyed by entity, locale and field, replace
+-- the per-table French columns. Values are JSON strings, or arrays for list
+-- fields such as experience descriptions.
+CREATE TABLE IF NOT EXISTS translations (
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id UUID NOT NULL,
+    locale VARCHAR(16) NOT NULL,
+    field VARCHAR(64) NOT NULL,
+    value JSONB NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entity_type, entity_id, locale, field)
+);
+
+-- Move the existing French text into translations
+INSERT INTO translations (entity_type, entity_id, locale, field, value)
+SELECT 'project', id, 'fr', 'title', to_jsonb(title_fr) FROM projects WHERE title_fr IS NOT NULL AND to_jsonb(title_fr) NOT IN ('""'::jsonb, '[]'::jsonb)
+UNION ALL
+SELECT 'project', id, 'fr', 'description', to_jsonb(description_fr) FROM projects WHERE description_fr IS NOT NULL AND to_jsonb(description_fr) NOT IN ('""'::jsonb, '[]'::jsonb)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO translations (entity_type, entity_id, locale, field, value)
+SELECT 'experience', id, 'fr', 'title', to_jsonb(title_fr) FROM experiences WHERE title_fr IS NOT NULL AND to_jsonb(title_fr) NOT IN ('""'::jsonb, '[]'::jsonb)
+UNION ALL
+SELECT 'experience', id, 'fr', 'company', to_jsonb(company_fr) FROM experiences WHERE company_fr IS NOT NULL AND to_jsonb(company_fr) NOT IN ('""'::jsonb, '[]'::jsonb)
+UNION ALL
+SELECT 'experience', id, 'fr', 'location', to_jsonb(location_fr) FROM experiences WHERE location_fr IS NOT NULL AND to_jsonb(location_fr) NOT IN ('""'::jsonb, '[]'::jsonb)
+UNION ALL
+SELECT 'experience', id, 'fr', 'description', to_jsonb(description_fr) FROM experiences WHERE description_fr IS NOT NULL AND to_jsonb(description_fr) NOT IN ('""'::jsonb, '[]'::jsonb)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO translations (entity_type, entity_id, locale, field, value)
+SELECT 'education', id, 'fr', 'degree', to_jsonb(degree_fr) FROM education WHERE degree_fr IS NOT NULL AND to_jsonb(degree_fr) NOT IN ('""'::jsonb, '[]'::jsonb)
+UNION ALL
+SELECT 'education', id, 'fr', 'school', to_jsonb(school_fr) FROM education WHERE school_fr IS NOT NULL AND to_jsonb(school_fr) NOT IN ('""'::jsonb, '[]'::jsonb)
+UNION ALL
+SELECT 'education', id, 'fr', 'location', to_jsonb(location_fr) FROM education WHERE location_fr IS NOT NULL AND to_jsonb(location_fr) NOT IN ('""'::jsonb, '[]'::jsonb)
+UNION ALL
+SELECT 'education', id, 'fr', 'description', to_jsonb(description_fr) FROM education WHERE description_fr IS NOT NULL AND to_jsonb(description_fr) NOT IN ('""'::jsonb, '[]'::jsonb)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO translations (entity_type, entity_id, locale, field, value)
+SELECT 'contact_info', id, 'fr', 'bio', to_jsonb(bio_fr) FROM contact_info WHERE bio_fr IS NOT NULL AND to_jsonb(bio_fr) NOT IN ('""'::jsonb, '[]'::jsonb)
+UNION ALL
+SELECT 'contact_info', id, 'fr', 'aboutTitle', to_jsonb(about_title_fr) FROM contact_info WHERE about_title_fr IS NOT NULL AND to_jsonb(about_title_fr) NOT IN ('""'::jsonb, '[]'::jsonb)
+ON CONFLICT DO NOTHING;
+
+-- Drop the French columns
+ALTER TABLE projects DROP COLUMN IF EXISTS title_fr, DROP COLUMN IF EXISTS description_fr;
+ALTER TABLE experiences DROP COLUMN IF EXISTS title_fr, DROP COLUMN IF EXISTS company_fr, DROP COLUMN IF EXISTS location_fr, DROP COLUMN IF EXISTS description_fr;
+ALTER TABLE education DROP COLUMN IF EXISTS degree_fr, DROP COLUMN IF EXISTS school_fr, DROP COLUMN IF EXISTS location_fr, DROP COLUMN IF EXISTS description_fr;
+ALTER TABLE contact_info DROP COLUMN IF EXISTS bio_fr, DROP COLUMN IF EXISTS about_title_fr;
@@ -0,0 +1,2 @@
+-- Migration: 021_add_translation_source_hash.down.sql
+ALTER TABLE translations DROP COLUMN IF EXISTS source_hash;
@@ -0,0 +1,15 @@
+-- Migration: 021_add_translation_source_hash.up.sql
+-- Hash of the source text a translation was written against, so translations
+-- whose source changed since can be reported as stale. Existing translations
+-- are taken to match their current source.
+ALTER TABLE translations ADD COLUMN IF NOT EXISTS source_hash VARCHAR(32);
+
+-- Fields are named in camelCase ("aboutTitle"), their columns in snake_case
+UPDATE translations t SET source_hash = md5((to_jsonb(s) -> lower(regexp_replace(t.field, '([A-Z])', '_\1', 'g')))::text)
+FROM projects s WHERE t.entity_type = 'project' AND t.entity_id = s.id AND t.source_hash IS NULL;
+UPDATE translations t SET source_hash = md5((to_jsonb(s) -> lower(regexp_replace(t.field, '([A-Z])', '_\1', 'g')))::text)
+FROM experiences s WHERE t.entity_type = 'experience' AND t.entity_id = s.id AND t.source_hash IS NULL;
+UPDATE translations t SET source_hash = md5((to_jsonb(s) -> lower(regexp_replace(t.field, '([A-Z])', '_\1', 'g')))::text)
+FROM education s WHERE t.entity_type = 'education' AND t.entity_id = s.id AND t.source_hash IS NULL;
+UPDATE translations t SET source_hash = md5((to_jsonb(s) -> lower(regexp_replace(t.field, '([A-Z])', '_\1', 'g')))::text)
+FROM contact_info s WHERE t.entity_type = 'contact_info' AND t.entity_id = s.id AND t.source_hash IS NULL;
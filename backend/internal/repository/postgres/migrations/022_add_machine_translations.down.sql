@@ -0,0 +1,3 @@
+-- Migration: 022_add_machine_translations.down.sql
+-- Machine translations are kept, as if an editor had written them.
+ALTER TABLE translations DROP COLUMN IF EXISTS machine_translated;
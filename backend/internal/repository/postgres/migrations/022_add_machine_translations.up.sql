@@ -0,0 +1,4 @@
+-- Migration: 022_add_machine_translations.up.sql
+-- Marks translations pre-filled by machine translation, which need review
+-- until an editor changes them.
+ALTER TABLE translations ADD COLUMN IF NOT EXISTS machine_translated BOOLEAN NOT NULL DEFAULT FALSE;
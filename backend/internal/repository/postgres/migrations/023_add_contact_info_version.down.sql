@@ -0,0 +1,2 @@
+-- Migration: 023_add_contact_info_version.down.sql
+ALTER TABLE contact_info DROP COLUMN IF EXISTS version;
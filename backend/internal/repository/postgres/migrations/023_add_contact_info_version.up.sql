@@ -0,0 +1,3 @@
+-- Migration: 023_add_contact_info_version.up.sql
+-- Row version for optimistic concurrency (ETag / If-Match) on contact info edits
+ALTER TABLE contact_info ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,59 @@
+package postgres_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/portfolio/backend/internal/repository"
+	"github.com/portfolio/backend/internal/repository/postgres"
+	"github.com/portfolio/backend/internal/repository/repositorytest"
+)
+
+// TestRepositoryContract runs the contract suite against the database at
+// TEST_DATABASE_URL. It migrates the database and empties every table before
+// each subtest, so never point it at one whose data you want to keep.
+func TestRepositoryContract(t *testing.T) {
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	pool, err := postgres.NewConnection(databaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	if _, err := postgres.Migrate(context.Background(), pool); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := postgres.NewRepository(pool)
+	repositorytest.Run(t, func(t *testing.T) repository.Store {
+		truncate(t, pool)
+		return repo
+	})
+}
+
+// truncate empties every table but the migration history.
+func truncate(t *testing.T, pool *pgxpool.Pool) {
+	t.Helper()
+	ctx := context.Background()
+	rows, err := pool.Query(ctx, `SELECT tablename FROM pg_tables WHERE schemaname = current_schema() AND tablename <> 'schema_migrations'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, table := range tables {
+		tables[i] = pgx.Identifier{table}.Sanitize()
+	}
+	if _, err := pool.Exec(ctx, `TRUNCATE `+strings.Join(tables, ", ")+` CASCADE`); err != nil {
+		t.Fatal(err)
+	}
+}
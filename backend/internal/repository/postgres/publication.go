@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// === Publishing ===
+
+// PublishScheduled marks scheduled rows whose publish_at has passed as
+// published and returns how many rows changed.
+func (r *Repository) PublishScheduled(ctx context.Context, now time.Time) (int64, error) {
+	var total int64
+	for _, entityType := range repository.PublishableEntityTypes {
+		query := fmt.Sprintf(`
+			UPDATE %s SET status = 'published', updated_at = NOW()
+			WHERE status = 'scheduled' AND publish_at <= $1 AND deleted_at IS NULL
+		`, auditTables[entityType])
+		tag, err := r.db.Exec(ctx, query, now)
+		if err != nil {
+			return total, err
+		}
+		total += tag.RowsAffected()
+	}
+	return total, nil
+}
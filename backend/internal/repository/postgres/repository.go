@@ -7,14 +7,39 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// The errors are repository's, so callers can check them without importing
+// this package.
+var (
+	ErrNotFound           = repository.ErrNotFound
+	ErrVersionConflict    = repository.ErrVersionConflict
+	ErrAdminExists        = repository.ErrAdminExists
+	ErrLastOwner          = repository.ErrLastOwner
+	ErrCredentialExists   = repository.ErrCredentialExists
+	ErrRefreshTokenReused = repository.ErrRefreshTokenReused
 )
 
 type Repository struct {
-	db *pgxpool.Pool
+	db dbtx
 }
 
+// dbtx is what the repository runs its queries on: the pool, or the
+// transaction of a repository from WithTx. Begin on a transaction opens a
+// savepoint, so methods that need their own transaction work in both.
+type dbtx interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+var _ repository.Store = (*Repository)(nil)
+
 func NewConnection(databaseURL string) (*pgxpool.Pool, error) {
 	config, err := pgxpool.ParseConfig(databaseURL)
 	if err != nil {
@@ -31,11 +56,6 @@ func NewConnection(databaseURL string) (*pgxpool.Pool, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	// Keep production databases aligned with expected schema.
-	if err := ensureSchema(pool); err != nil {
-		return nil, fmt.Errorf("failed to ensure database schema: %w", err)
-	}
-
 	return pool, nil
 }
 
@@ -43,10 +63,51 @@ func NewRepository(db *pgxpool.Pool) *Repository {
 	return &Repository{db: db}
 }
 
+// WithTx calls fn with a repository whose every query runs in one
+// transaction. The transaction is committed if fn returns nil and rolled
+// back otherwise, so several calls can be made all-or-nothing.
+func (r *Repository) WithTx(ctx context.Context, fn func(tx *Repository) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(&Repository{db: tx}); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// publishedOnly limits public queries to live content. Scheduled rows count as
+// soon as their time has come, even before the scheduler has flipped them.
+const publishedOnly = ` AND (status = 'published' OR (status = 'scheduled' AND publish_at <= NOW()))`
+
 // === Skills ===
 
+// GetSkills returns the skills visible on the public site.
 func (r *Repository) GetSkills(ctx context.Context) ([]model.Skill, error) {
-	query := `SELECT id, name, COALESCE(icon, ''), proficiency, COALESCE(category, ''), sort_order, COALESCE(show_in_portfolio, TRUE) FROM skills ORDER BY sort_order ASC`
+	return r.listSkills(ctx, publishedOnly)
+}
+
+// GetAllSkills also returns drafts and scheduled skills, for the admin.
+func (r *Repository) GetAllSkills(ctx context.Context) ([]model.Skill, error) {
+	return r.listSkills(ctx, "")
+}
+
+const skillColumns = `id, name, COALESCE(icon, ''), proficiency, COALESCE(category, ''), sort_order, COALESCE(show_in_portfolio, TRUE), status, publish_at, version`
+
+func scanSkill(row pgx.Row) (model.Skill, error) {
+	var s model.Skill
+	err := row.Scan(&s.ID, &s.Name, &s.Icon, &s.Proficiency, &s.Category, &s.SortOrder, &s.ShowInPortfolio, &s.Status, &s.PublishAt, &s.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s, ErrNotFound
+	}
+	return s, err
+}
+
+func (r *Repository) listSkills(ctx context.Context, condition string) ([]model.Skill, error) {
+	query := `SELECT ` + skillColumns + ` FROM skills WHERE deleted_at IS NULL` + condition + ` ORDER BY sort_order ASC`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -55,8 +116,8 @@ func (r *Repository) GetSkills(ctx context.Context) ([]model.Skill, error) {
 
 	var skills []model.Skill
 	for rows.Next() {
-		var s model.Skill
-		if err := rows.Scan(&s.ID, &s.Name, &s.Icon, &s.Proficiency, &s.Category, &s.SortOrder, &s.ShowInPortfolio); err != nil {
+		s, err := scanSkill(rows)
+		if err != nil {
 			return nil, err
 		}
 		skills = append(skills, s)
@@ -64,37 +125,76 @@ func (r *Repository) GetSkills(ctx context.Context) ([]model.Skill, error) {
 	return skills, nil
 }
 
+// GetSkillByID returns a skill that is not in the trash, whatever its status.
+func (r *Repository) GetSkillByID(ctx context.Context, id string) (model.Skill, error) {
+	return scanSkill(r.db.QueryRow(ctx, `SELECT `+skillColumns+` FROM skills WHERE id = $1 AND deleted_at IS NULL`, id))
+}
+
 func (r *Repository) CreateSkill(ctx context.Context, s model.Skill) (model.Skill, error) {
 	query := `
-		INSERT INTO skills (name, icon, proficiency, category, sort_order, show_in_portfolio)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING id, created_at, updated_at
+		INSERT INTO skills (name, icon, proficiency, category, sort_order, show_in_portfolio, status, publish_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at, version
 	`
-	err := r.db.QueryRow(ctx, query, s.Name, s.Icon, s.Proficiency, s.Category, s.SortOrder, s.ShowInPortfolio).Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+	err := r.db.QueryRow(ctx, query, s.Name, s.Icon, s.Proficiency, s.Category, s.SortOrder, s.ShowInPortfolio, s.Status, s.PublishAt).Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt, &s.Version)
 	return s, err
 }
 
+// UpdateSkill only applies when s.Version is still the stored version. It
+// returns ErrNotFound or ErrVersionConflict otherwise.
 func (r *Repository) UpdateSkill(ctx context.Context, s model.Skill) (model.Skill, error) {
 	query := `
 		UPDATE skills 
-		SET name = $1, icon = $2, proficiency = $3, category = $4, sort_order = $5, show_in_portfolio = $6, updated_at = NOW()
-		WHERE id = $7
-		RETURNING created_at, updated_at
+		SET name = $1, icon = $2, proficiency = $3, category = $4, sort_order = $5, show_in_portfolio = $6,
+			status = COALESCE(NULLIF($8, ''), status), publish_at = CASE WHEN $8 = '' THEN publish_at ELSE $9 END, version = version + 1, updated_at = NOW()
+		WHERE id = $7 AND deleted_at IS NULL AND version = $10
+		RETURNING created_at, updated_at, status, publish_at, version
 	`
-	err := r.db.QueryRow(ctx, query, s.Name, s.Icon, s.Proficiency, s.Category, s.SortOrder, s.ShowInPortfolio, s.ID).Scan(&s.CreatedAt, &s.UpdatedAt)
+	err := r.db.QueryRow(ctx, query, s.Name, s.Icon, s.Proficiency, s.Category, s.SortOrder, s.ShowInPortfolio, s.ID, s.Status, s.PublishAt, s.Version).Scan(&s.CreatedAt, &s.UpdatedAt, &s.Status, &s.PublishAt, &s.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s, r.versionMismatch(ctx, "skills", s.ID)
+	}
 	return s, err
 }
 
-func (r *Repository) DeleteSkill(ctx context.Context, id string) error {
-	query := `DELETE FROM skills WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
-	return err
+// DeleteSkill moves the row to the trash if it is still at the given version.
+func (r *Repository) DeleteSkill(ctx context.Context, id string, version int) error {
+	query := `UPDATE skills SET deleted_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NULL AND version = $2`
+	tag, err := r.db.Exec(ctx, query, id, version)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return r.versionMismatch(ctx, "skills", id)
+	}
+	return nil
 }
 
 // === Projects ===
 
+// GetProjects returns the projects visible on the public site.
 func (r *Repository) GetProjects(ctx context.Context) ([]model.Project, error) {
-	query := `SELECT id, title, COALESCE(title_fr, ''), COALESCE(description, ''), COALESCE(description_fr, ''), COALESCE(image_url, ''), COALESCE(live_url, ''), COALESCE(code_url, ''), COALESCE(tags, '{}'::text[]), featured, sort_order FROM projects ORDER BY sort_order ASC`
+	return r.listProjects(ctx, publishedOnly)
+}
+
+// GetAllProjects also returns drafts and scheduled projects, for the admin.
+func (r *Repository) GetAllProjects(ctx context.Context) ([]model.Project, error) {
+	return r.listProjects(ctx, "")
+}
+
+const projectColumns = `id, title, COALESCE(description, ''), COALESCE(image_url, ''), COALESCE(live_url, ''), COALESCE(code_url, ''), COALESCE(tags, '{}'::text[]), featured, sort_order, status, publish_at, version`
+
+func scanProject(row pgx.Row) (model.Project, error) {
+	var p model.Project
+	err := row.Scan(&p.ID, &p.Title, &p.Description, &p.ImageURL, &p.LiveURL, &p.CodeURL, &p.Tags, &p.Featured, &p.SortOrder, &p.Status, &p.PublishAt, &p.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return p, ErrNotFound
+	}
+	return p, err
+}
+
+func (r *Repository) listProjects(ctx context.Context, condition string) ([]model.Project, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE deleted_at IS NULL` + condition + ` ORDER BY sort_order ASC`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -103,46 +203,129 @@ func (r *Repository) GetProjects(ctx context.Context) ([]model.Project, error) {
 
 	var projects []model.Project
 	for rows.Next() {
-		var p model.Project
-		if err := rows.Scan(&p.ID, &p.Title, &p.TitleFr, &p.Description, &p.DescriptionFr, &p.ImageURL, &p.LiveURL, &p.CodeURL, &p.Tags, &p.Featured, &p.SortOrder); err != nil {
+		p, err := scanProject(rows)
+		if err != nil {
 			return nil, err
 		}
 		projects = append(projects, p)
 	}
-	return projects, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	err = attachTranslations(ctx, r.db, model.AuditEntityProject, projects, func(p model.Project) string { return p.ID })
+	return projects, err
 }
 
+// GetProjectByID returns a project that is not in the trash, whatever its status.
+func (r *Repository) GetProjectByID(ctx context.Context, id string) (model.Project, error) {
+	p, err := scanProject(r.db.QueryRow(ctx, `SELECT `+projectColumns+` FROM projects WHERE id = $1 AND deleted_at IS NULL`, id))
+	if err != nil {
+		return p, err
+	}
+	translations, err := loadTranslations(ctx, r.db, model.AuditEntityProject, id)
+	translations[id].apply(&p)
+	return p, err
+}
+
+// CreateProject inserts p together with p.Translations.
 func (r *Repository) CreateProject(ctx context.Context, p model.Project) (model.Project, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return p, err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-		INSERT INTO projects (title, title_fr, description, description_fr, image_url, live_url, code_url, tags, featured, sort_order)
+		INSERT INTO projects (title, description, image_url, live_url, code_url, tags, featured, sort_order, status, publish_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, created_at, updated_at
+		RETURNING id, created_at, updated_at, version
 	`
-	err := r.db.QueryRow(ctx, query, p.Title, p.TitleFr, p.Description, p.DescriptionFr, p.ImageURL, p.LiveURL, p.CodeURL, p.Tags, p.Featured, p.SortOrder).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
-	return p, err
+	err = tx.QueryRow(ctx, query, p.Title, p.Description, p.ImageURL, p.LiveURL, p.CodeURL, p.Tags, p.Featured, p.SortOrder, p.Status, p.PublishAt).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt, &p.Version)
+	if err != nil {
+		return p, err
+	}
+	translations, err := saveTranslations(ctx, tx, model.AuditEntityProject, p.ID, p.Translations)
+	if err != nil {
+		return p, err
+	}
+	translations.apply(&p)
+	return p, tx.Commit(ctx)
 }
 
+// UpdateProject only applies when p.Version is still the stored version. It
+// returns ErrNotFound or ErrVersionConflict otherwise. p.Translations are
+// merged into the stored ones.
 func (r *Repository) UpdateProject(ctx context.Context, p model.Project) (model.Project, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return p, err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
 		UPDATE projects 
-		SET title = $1, title_fr = $2, description = $3, description_fr = $4, image_url = $5, live_url = $6, code_url = $7, tags = $8, featured = $9, sort_order = $10, updated_at = NOW()
-		WHERE id = $11
-		RETURNING created_at, updated_at
+		SET title = $1, description = $2, image_url = $3, live_url = $4, code_url = $5, tags = $6, featured = $7, sort_order = $8,
+			status = COALESCE(NULLIF($10, ''), status), publish_at = CASE WHEN $10 = '' THEN publish_at ELSE $11 END, version = version + 1, updated_at = NOW()
+		WHERE id = $9 AND deleted_at IS NULL AND version = $12
+		RETURNING created_at, updated_at, status, publish_at, version
 	`
-	err := r.db.QueryRow(ctx, query, p.Title, p.TitleFr, p.Description, p.DescriptionFr, p.ImageURL, p.LiveURL, p.CodeURL, p.Tags, p.Featured, p.SortOrder, p.ID).Scan(&p.CreatedAt, &p.UpdatedAt)
-	return p, err
+	err = tx.QueryRow(ctx, query, p.Title, p.Description, p.ImageURL, p.LiveURL, p.CodeURL, p.Tags, p.Featured, p.SortOrder, p.ID, p.Status, p.PublishAt, p.Version).Scan(&p.CreatedAt, &p.UpdatedAt, &p.Status, &p.PublishAt, &p.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return p, r.versionMismatch(ctx, "projects", p.ID)
+	}
+	if err != nil {
+		return p, err
+	}
+	translations, err := saveTranslations(ctx, tx, model.AuditEntityProject, p.ID, p.Translations)
+	if err != nil {
+		return p, err
+	}
+	translations.apply(&p)
+	return p, tx.Commit(ctx)
 }
 
-func (r *Repository) DeleteProject(ctx context.Context, id string) error {
-	query := `DELETE FROM projects WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
-	return err
+// DeleteProject moves the row to the trash if it is still at the given version.
+func (r *Repository) DeleteProject(ctx context.Context, id string, version int) error {
+	query := `UPDATE projects SET deleted_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NULL AND version = $2`
+	tag, err := r.db.Exec(ctx, query, id, version)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return r.versionMismatch(ctx, "projects", id)
+	}
+	return nil
 }
 
 // === Experience ===
 
+// GetExperiences returns the experiences visible on the public site.
 func (r *Repository) GetExperiences(ctx context.Context) ([]model.Experience, error) {
-	query := `SELECT id, title, COALESCE(title_fr, ''), company, COALESCE(company_fr, ''), COALESCE(location, ''), COALESCE(location_fr, ''), start_date, end_date, is_current, COALESCE(description, '{}'::text[]), COALESCE(description_fr, '{}'::text[]), sort_order FROM experiences ORDER BY sort_order ASC`
+	return r.listExperiences(ctx, publishedOnly)
+}
+
+// GetAllExperiences also returns drafts and scheduled experiences, for the admin.
+func (r *Repository) GetAllExperiences(ctx context.Context) ([]model.Experience, error) {
+	return r.listExperiences(ctx, "")
+}
+
+const experienceColumns = `id, title, company, COALESCE(location, ''), start_date, end_date, start_date_precision, end_date_precision, is_current, COALESCE(description, '{}'::text[]), sort_order, status, publish_at, version`
+
+func scanExperience(row pgx.Row) (model.Experience, error) {
+	var e model.Experience
+	var startDate, endDate *time.Time
+	var startPrecision, endPrecision string
+	err := row.Scan(&e.ID, &e.Title, &e.Company, &e.Location, &startDate, &endDate, &startPrecision, &endPrecision, &e.Current, &e.Description, &e.SortOrder, &e.Status, &e.PublishAt, &e.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return e, ErrNotFound
+	}
+	e.StartDate = model.NewDate(startDate, model.DatePrecision(startPrecision))
+	e.EndDate = model.NewDate(endDate, model.DatePrecision(endPrecision))
+	return e, err
+}
+
+func (r *Repository) listExperiences(ctx context.Context, condition string) ([]model.Experience, error) {
+	query := `SELECT ` + experienceColumns + ` FROM experiences WHERE deleted_at IS NULL` + condition + ` ORDER BY sort_order ASC`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -151,54 +334,129 @@ func (r *Repository) GetExperiences(ctx context.Context) ([]model.Experience, er
 
 	var exps []model.Experience
 	for rows.Next() {
-		var e model.Experience
-		var startDate time.Time
-		// Handle nullable end_date
-		var endDatePtr *time.Time
-		
-		if err := rows.Scan(&e.ID, &e.Title, &e.TitleFr, &e.Company, &e.CompanyFr, &e.Location, &e.LocationFr, &startDate, &endDatePtr, &e.Current, &e.Description, &e.DescriptionFr, &e.SortOrder); err != nil {
+		e, err := scanExperience(rows)
+		if err != nil {
 			return nil, err
 		}
-		e.StartDate = startDate
-		if endDatePtr != nil {
-			e.EndDate = *endDatePtr
-		}
 		exps = append(exps, e)
 	}
-	return exps, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	err = attachTranslations(ctx, r.db, model.AuditEntityExperience, exps, func(e model.Experience) string { return e.ID })
+	return exps, err
 }
 
+// GetExperienceByID returns an experience that is not in the trash, whatever its status.
+func (r *Repository) GetExperienceByID(ctx context.Context, id string) (model.Experience, error) {
+	e, err := scanExperience(r.db.QueryRow(ctx, `SELECT `+experienceColumns+` FROM experiences WHERE id = $1 AND deleted_at IS NULL`, id))
+	if err != nil {
+		return e, err
+	}
+	translations, err := loadTranslations(ctx, r.db, model.AuditEntityExperience, id)
+	translations[id].apply(&e)
+	return e, err
+}
+
+// CreateExperience inserts e together with e.Translations.
 func (r *Repository) CreateExperience(ctx context.Context, e model.Experience) (model.Experience, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return e, err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-		INSERT INTO experiences (title, title_fr, company, company_fr, location, location_fr, start_date, end_date, is_current, description, description_fr, sort_order)
+		INSERT INTO experiences (title, company, location, start_date, end_date, is_current, description, sort_order, status, publish_at, start_date_precision, end_date_precision)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-		RETURNING id, created_at, updated_at
+		RETURNING id, created_at, updated_at, version
 	`
-	err := r.db.QueryRow(ctx, query, e.Title, e.TitleFr, e.Company, e.CompanyFr, e.Location, e.LocationFr, e.StartDate, e.EndDate, e.Current, e.Description, e.DescriptionFr, e.SortOrder).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
-	return e, err
+	err = tx.QueryRow(ctx, query, e.Title, e.Company, e.Location, e.StartDate.Ptr(), e.EndDate.Ptr(), e.Current, e.Description, e.SortOrder, e.Status, e.PublishAt, e.StartDate.StoredPrecision(), e.EndDate.StoredPrecision()).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt, &e.Version)
+	if err != nil {
+		return e, err
+	}
+	translations, err := saveTranslations(ctx, tx, model.AuditEntityExperience, e.ID, e.Translations)
+	if err != nil {
+		return e, err
+	}
+	translations.apply(&e)
+	return e, tx.Commit(ctx)
 }
 
+// UpdateExperience only applies when e.Version is still the stored version. It
+// returns ErrNotFound or ErrVersionConflict otherwise. e.Translations are
+// merged into the stored ones.
 func (r *Repository) UpdateExperience(ctx context.Context, e model.Experience) (model.Experience, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return e, err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
 		UPDATE experiences
-		SET title = $1, title_fr = $2, company = $3, company_fr = $4, location = $5, location_fr = $6, start_date = $7, end_date = $8, is_current = $9, description = $10, description_fr = $11, sort_order = $12, updated_at = NOW()
-		WHERE id = $13
-		RETURNING created_at, updated_at
+		SET title = $1, company = $2, location = $3, start_date = $4, end_date = $5, start_date_precision = $13, end_date_precision = $14, is_current = $6, description = $7, sort_order = $8,
+			status = COALESCE(NULLIF($10, ''), status), publish_at = CASE WHEN $10 = '' THEN publish_at ELSE $11 END, version = version + 1, updated_at = NOW()
+		WHERE id = $9 AND deleted_at IS NULL AND version = $12
+		RETURNING created_at, updated_at, status, publish_at, version
 	`
-	err := r.db.QueryRow(ctx, query, e.Title, e.TitleFr, e.Company, e.CompanyFr, e.Location, e.LocationFr, e.StartDate, e.EndDate, e.Current, e.Description, e.DescriptionFr, e.SortOrder, e.ID).Scan(&e.CreatedAt, &e.UpdatedAt)
-	return e, err
+	err = tx.QueryRow(ctx, query, e.Title, e.Company, e.Location, e.StartDate.Ptr(), e.EndDate.Ptr(), e.Current, e.Description, e.SortOrder, e.ID, e.Status, e.PublishAt, e.Version, e.StartDate.StoredPrecision(), e.EndDate.StoredPrecision()).Scan(&e.CreatedAt, &e.UpdatedAt, &e.Status, &e.PublishAt, &e.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return e, r.versionMismatch(ctx, "experiences", e.ID)
+	}
+	if err != nil {
+		return e, err
+	}
+	translations, err := saveTranslations(ctx, tx, model.AuditEntityExperience, e.ID, e.Translations)
+	if err != nil {
+		return e, err
+	}
+	translations.apply(&e)
+	return e, tx.Commit(ctx)
 }
 
-func (r *Repository) DeleteExperience(ctx context.Context, id string) error {
-	query := `DELETE FROM experiences WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
-	return err
+// DeleteExperience moves the row to the trash if it is still at the given version.
+func (r *Repository) DeleteExperience(ctx context.Context, id string, version int) error {
+	query := `UPDATE experiences SET deleted_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NULL AND version = $2`
+	tag, err := r.db.Exec(ctx, query, id, version)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return r.versionMismatch(ctx, "experiences", id)
+	}
+	return nil
 }
 
 // === Education ===
 
+// GetEducation returns the education visible on the public site.
 func (r *Repository) GetEducation(ctx context.Context) ([]model.Education, error) {
-	query := `SELECT id, degree, COALESCE(degree_fr, ''), school, COALESCE(school_fr, ''), COALESCE(location, ''), COALESCE(location_fr, ''), start_date, end_date, COALESCE(description, ''), COALESCE(description_fr, ''), sort_order FROM education ORDER BY sort_order ASC`
+	return r.listEducation(ctx, publishedOnly)
+}
+
+// GetAllEducation also returns drafts and scheduled education, for the admin.
+func (r *Repository) GetAllEducation(ctx context.Context) ([]model.Education, error) {
+	return r.listEducation(ctx, "")
+}
+
+const educationColumns = `id, degree, school, COALESCE(location, ''), start_date, end_date, start_date_precision, end_date_precision, COALESCE(description, ''), sort_order, status, publish_at, version`
+
+func scanEducation(row pgx.Row) (model.Education, error) {
+	var e model.Education
+	var startDate, endDate *time.Time
+	var startPrecision, endPrecision string
+	err := row.Scan(&e.ID, &e.Degree, &e.School, &e.Location, &startDate, &endDate, &startPrecision, &endPrecision, &e.Description, &e.SortOrder, &e.Status, &e.PublishAt, &e.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return e, ErrNotFound
+	}
+	e.StartDate = model.NewDate(startDate, model.DatePrecision(startPrecision))
+	e.EndDate = model.NewDate(endDate, model.DatePrecision(endPrecision))
+	return e, err
+}
+
+func (r *Repository) listEducation(ctx context.Context, condition string) ([]model.Education, error) {
+	query := `SELECT ` + educationColumns + ` FROM education WHERE deleted_at IS NULL` + condition + ` ORDER BY sort_order ASC`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -207,53 +465,125 @@ func (r *Repository) GetEducation(ctx context.Context) ([]model.Education, error
 
 	var edus []model.Education
 	for rows.Next() {
-		var e model.Education
-		var startDate time.Time
-		var endDatePtr *time.Time
-		
-		if err := rows.Scan(&e.ID, &e.Degree, &e.DegreeFr, &e.School, &e.SchoolFr, &e.Location, &e.LocationFr, &startDate, &endDatePtr, &e.Description, &e.DescriptionFr, &e.SortOrder); err != nil {
+		e, err := scanEducation(rows)
+		if err != nil {
 			return nil, err
 		}
-		e.StartDate = startDate
-		if endDatePtr != nil {
-			e.EndDate = *endDatePtr
-		}
 		edus = append(edus, e)
 	}
-	return edus, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	err = attachTranslations(ctx, r.db, model.AuditEntityEducation, edus, func(e model.Education) string { return e.ID })
+	return edus, err
+}
+
+// GetEducationByID returns an education entry that is not in the trash, whatever its status.
+func (r *Repository) GetEducationByID(ctx context.Context, id string) (model.Education, error) {
+	e, err := scanEducation(r.db.QueryRow(ctx, `SELECT `+educationColumns+` FROM education WHERE id = $1 AND deleted_at IS NULL`, id))
+	if err != nil {
+		return e, err
+	}
+	translations, err := loadTranslations(ctx, r.db, model.AuditEntityEducation, id)
+	translations[id].apply(&e)
+	return e, err
 }
 
+// CreateEducation inserts e together with e.Translations.
 func (r *Repository) CreateEducation(ctx context.Context, e model.Education) (model.Education, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return e, err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-		INSERT INTO education (degree, degree_fr, school, school_fr, location, location_fr, start_date, end_date, description, description_fr, sort_order)
+		INSERT INTO education (degree, school, location, start_date, end_date, description, sort_order, status, publish_at, start_date_precision, end_date_precision)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		RETURNING id, created_at, updated_at
+		RETURNING id, created_at, updated_at, version
 	`
-	err := r.db.QueryRow(ctx, query, e.Degree, e.DegreeFr, e.School, e.SchoolFr, e.Location, e.LocationFr, e.StartDate, e.EndDate, e.Description, e.DescriptionFr, e.SortOrder).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
-	return e, err
+	err = tx.QueryRow(ctx, query, e.Degree, e.School, e.Location, e.StartDate.Ptr(), e.EndDate.Ptr(), e.Description, e.SortOrder, e.Status, e.PublishAt, e.StartDate.StoredPrecision(), e.EndDate.StoredPrecision()).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt, &e.Version)
+	if err != nil {
+		return e, err
+	}
+	translations, err := saveTranslations(ctx, tx, model.AuditEntityEducation, e.ID, e.Translations)
+	if err != nil {
+		return e, err
+	}
+	translations.apply(&e)
+	return e, tx.Commit(ctx)
 }
 
+// UpdateEducation only applies when e.Version is still the stored version. It
+// returns ErrNotFound or ErrVersionConflict otherwise. e.Translations are
+// merged into the stored ones.
 func (r *Repository) UpdateEducation(ctx context.Context, e model.Education) (model.Education, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return e, err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
 		UPDATE education
-		SET degree = $1, degree_fr = $2, school = $3, school_fr = $4, location = $5, location_fr = $6, start_date = $7, end_date = $8, description = $9, description_fr = $10, sort_order = $11, updated_at = NOW()
-		WHERE id = $12
-		RETURNING created_at, updated_at
+		SET degree = $1, school = $2, location = $3, start_date = $4, end_date = $5, start_date_precision = $12, end_date_precision = $13, description = $6, sort_order = $7,
+			status = COALESCE(NULLIF($9, ''), status), publish_at = CASE WHEN $9 = '' THEN publish_at ELSE $10 END, version = version + 1, updated_at = NOW()
+		WHERE id = $8 AND deleted_at IS NULL AND version = $11
+		RETURNING created_at, updated_at, status, publish_at, version
 	`
-	err := r.db.QueryRow(ctx, query, e.Degree, e.DegreeFr, e.School, e.SchoolFr, e.Location, e.LocationFr, e.StartDate, e.EndDate, e.Description, e.DescriptionFr, e.SortOrder, e.ID).Scan(&e.CreatedAt, &e.UpdatedAt)
-	return e, err
+	err = tx.QueryRow(ctx, query, e.Degree, e.School, e.Location, e.StartDate.Ptr(), e.EndDate.Ptr(), e.Description, e.SortOrder, e.ID, e.Status, e.PublishAt, e.Version, e.StartDate.StoredPrecision(), e.EndDate.StoredPrecision()).Scan(&e.CreatedAt, &e.UpdatedAt, &e.Status, &e.PublishAt, &e.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return e, r.versionMismatch(ctx, "education", e.ID)
+	}
+	if err != nil {
+		return e, err
+	}
+	translations, err := saveTranslations(ctx, tx, model.AuditEntityEducation, e.ID, e.Translations)
+	if err != nil {
+		return e, err
+	}
+	translations.apply(&e)
+	return e, tx.Commit(ctx)
 }
 
-func (r *Repository) DeleteEducation(ctx context.Context, id string) error {
-	query := `DELETE FROM education WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
-	return err
+// DeleteEducation moves the row to the trash if it is still at the given version.
+func (r *Repository) DeleteEducation(ctx context.Context, id string, version int) error {
+	query := `UPDATE education SET deleted_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NULL AND version = $2`
+	tag, err := r.db.Exec(ctx, query, id, version)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return r.versionMismatch(ctx, "education", id)
+	}
+	return nil
 }
 
 // === Hobbies ===
 
+// GetHobbies returns the hobbies visible on the public site.
 func (r *Repository) GetHobbies(ctx context.Context) ([]model.Hobby, error) {
-	query := `SELECT id, name, COALESCE(icon, ''), COALESCE(description, ''), sort_order FROM hobbies ORDER BY sort_order ASC`
+	return r.listHobbies(ctx, publishedOnly)
+}
+
+// GetAllHobbies also returns drafts and scheduled hobbies, for the admin.
+func (r *Repository) GetAllHobbies(ctx context.Context) ([]model.Hobby, error) {
+	return r.listHobbies(ctx, "")
+}
+
+const hobbyColumns = `id, name, COALESCE(icon, ''), COALESCE(description, ''), sort_order, status, publish_at, version`
+
+func scanHobby(row pgx.Row) (model.Hobby, error) {
+	var h model.Hobby
+	err := row.Scan(&h.ID, &h.Name, &h.Icon, &h.Description, &h.SortOrder, &h.Status, &h.PublishAt, &h.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return h, ErrNotFound
+	}
+	return h, err
+}
+
+func (r *Repository) listHobbies(ctx context.Context, condition string) ([]model.Hobby, error) {
+	query := `SELECT ` + hobbyColumns + ` FROM hobbies WHERE deleted_at IS NULL` + condition + ` ORDER BY sort_order ASC`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -262,46 +592,104 @@ func (r *Repository) GetHobbies(ctx context.Context) ([]model.Hobby, error) {
 
 	var hobbies []model.Hobby
 	for rows.Next() {
-		var h model.Hobby
-		if err := rows.Scan(&h.ID, &h.Name, &h.Icon, &h.Description, &h.SortOrder); err != nil {
+		h, err := scanHobby(rows)
+		if err != nil {
 			return nil, err
 		}
 		hobbies = append(hobbies, h)
 	}
-	return hobbies, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	err = attachTranslations(ctx, r.db, model.AuditEntityHobby, hobbies, func(h model.Hobby) string { return h.ID })
+	return hobbies, err
 }
 
+// GetHobbyByID returns a hobby that is not in the trash, whatever its status.
+func (r *Repository) GetHobbyByID(ctx context.Context, id string) (model.Hobby, error) {
+	h, err := scanHobby(r.db.QueryRow(ctx, `SELECT `+hobbyColumns+` FROM hobbies WHERE id = $1 AND deleted_at IS NULL`, id))
+	if err != nil {
+		return h, err
+	}
+	translations, err := loadTranslations(ctx, r.db, model.AuditEntityHobby, id)
+	translations[id].apply(&h)
+	return h, err
+}
+
+// CreateHobby inserts h together with h.Translations.
 func (r *Repository) CreateHobby(ctx context.Context, h model.Hobby) (model.Hobby, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return h, err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-		INSERT INTO hobbies (name, icon, description, sort_order)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, created_at, updated_at
+		INSERT INTO hobbies (name, icon, description, sort_order, status, publish_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at, version
 	`
-	err := r.db.QueryRow(ctx, query, h.Name, h.Icon, h.Description, h.SortOrder).Scan(&h.ID, &h.CreatedAt, &h.UpdatedAt)
-	return h, err
+	err = tx.QueryRow(ctx, query, h.Name, h.Icon, h.Description, h.SortOrder, h.Status, h.PublishAt).Scan(&h.ID, &h.CreatedAt, &h.UpdatedAt, &h.Version)
+	if err != nil {
+		return h, err
+	}
+	translations, err := saveTranslations(ctx, tx, model.AuditEntityHobby, h.ID, h.Translations)
+	if err != nil {
+		return h, err
+	}
+	translations.apply(&h)
+	return h, tx.Commit(ctx)
 }
 
+// UpdateHobby only applies when h.Version is still the stored version. It
+// returns ErrNotFound or ErrVersionConflict otherwise. h.Translations are
+// merged into the stored ones.
 func (r *Repository) UpdateHobby(ctx context.Context, h model.Hobby) (model.Hobby, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return h, err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
 		UPDATE hobbies
-		SET name = $1, icon = $2, description = $3, sort_order = $4, updated_at = NOW()
-		WHERE id = $5
-		RETURNING created_at, updated_at
+		SET name = $1, icon = $2, description = $3, sort_order = $4,
+			status = COALESCE(NULLIF($6, ''), status), publish_at = CASE WHEN $6 = '' THEN publish_at ELSE $7 END, version = version + 1, updated_at = NOW()
+		WHERE id = $5 AND deleted_at IS NULL AND version = $8
+		RETURNING created_at, updated_at, status, publish_at, version
 	`
-	err := r.db.QueryRow(ctx, query, h.Name, h.Icon, h.Description, h.SortOrder, h.ID).Scan(&h.CreatedAt, &h.UpdatedAt)
-	return h, err
+	err = tx.QueryRow(ctx, query, h.Name, h.Icon, h.Description, h.SortOrder, h.ID, h.Status, h.PublishAt, h.Version).Scan(&h.CreatedAt, &h.UpdatedAt, &h.Status, &h.PublishAt, &h.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return h, r.versionMismatch(ctx, "hobbies", h.ID)
+	}
+	if err != nil {
+		return h, err
+	}
+	translations, err := saveTranslations(ctx, tx, model.AuditEntityHobby, h.ID, h.Translations)
+	if err != nil {
+		return h, err
+	}
+	translations.apply(&h)
+	return h, tx.Commit(ctx)
 }
 
-func (r *Repository) DeleteHobby(ctx context.Context, id string) error {
-	query := `DELETE FROM hobbies WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
-	return err
+// DeleteHobby moves the row to the trash if it is still at the given version.
+func (r *Repository) DeleteHobby(ctx context.Context, id string, version int) error {
+	query := `UPDATE hobbies SET deleted_at = NOW(), version = version + 1 WHERE id = $1 AND deleted_at IS NULL AND version = $2`
+	tag, err := r.db.Exec(ctx, query, id, version)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return r.versionMismatch(ctx, "hobbies", id)
+	}
+	return nil
 }
 
 // === Testimonials ===
 
 func (r *Repository) GetApprovedTestimonials(ctx context.Context) ([]model.Testimonial, error) {
-	query := `SELECT id, author_name, COALESCE(author_role, ''), content, rating, status FROM testimonials WHERE status = 'approved' ORDER BY created_at DESC`
+	query := `SELECT id, author_name, COALESCE(author_role, ''), content, rating, status FROM testimonials WHERE status = 'approved' AND deleted_at IS NULL ORDER BY created_at DESC`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -320,7 +708,7 @@ func (r *Repository) GetApprovedTestimonials(ctx context.Context) ([]model.Testi
 }
 
 func (r *Repository) GetAllTestimonials(ctx context.Context) ([]model.Testimonial, error) {
-	query := `SELECT id, author_name, COALESCE(author_role, ''), COALESCE(author_email, ''), content, rating, status, created_at, updated_at FROM testimonials ORDER BY created_at DESC`
+	query := `SELECT id, author_name, COALESCE(author_role, ''), COALESCE(author_email, ''), content, rating, status, created_at, updated_at FROM testimonials WHERE deleted_at IS NULL ORDER BY created_at DESC`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -349,21 +737,33 @@ func (r *Repository) CreateTestimonial(ctx context.Context, t model.Testimonial)
 }
 
 func (r *Repository) UpdateTestimonialStatus(ctx context.Context, id, status string) error {
-	query := `UPDATE testimonials SET status = $1, updated_at = NOW() WHERE id = $2`
-	_, err := r.db.Exec(ctx, query, status, id)
-	return err
+	query := `UPDATE testimonials SET status = $1, updated_at = NOW() WHERE id = $2 AND deleted_at IS NULL`
+	tag, err := r.db.Exec(ctx, query, status, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
 
 func (r *Repository) DeleteTestimonial(ctx context.Context, id string) error {
-	query := `DELETE FROM testimonials WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
-	return err
+	query := `UPDATE testimonials SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
 
 // === Messages ===
 
 func (r *Repository) GetMessages(ctx context.Context) ([]model.Message, error) {
-	query := `SELECT id, name, email, subject, content, is_read, created_at FROM messages ORDER BY created_at DESC`
+	query := `SELECT id, name, email, subject, content, is_read, created_at FROM messages WHERE deleted_at IS NULL ORDER BY created_at DESC`
 	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		return nil, err
@@ -381,6 +781,16 @@ func (r *Repository) GetMessages(ctx context.Context) ([]model.Message, error) {
 	return messages, nil
 }
 
+// PurgeMessages permanently deletes messages received before cutoff, read or
+// not and including those in the trash, and returns how many were removed.
+func (r *Repository) PurgeMessages(ctx context.Context, cutoff time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM messages WHERE created_at < $1`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
 func (r *Repository) CreateMessage(ctx context.Context, m model.Message) (model.Message, error) {
 	query := `
 		INSERT INTO messages (name, email, subject, content, content_hash, is_read)
@@ -416,27 +826,39 @@ func (r *Repository) HasRecentDuplicateMessage(ctx context.Context, email, conte
 }
 
 func (r *Repository) MarkMessageRead(ctx context.Context, id string) error {
-	query := `UPDATE messages SET is_read = TRUE WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
-	return err
+	query := `UPDATE messages SET is_read = TRUE WHERE id = $1 AND deleted_at IS NULL`
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
 
 func (r *Repository) DeleteMessage(ctx context.Context, id string) error {
-	query := `DELETE FROM messages WHERE id = $1`
-	_, err := r.db.Exec(ctx, query, id)
-	return err
+	query := `UPDATE messages SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
 
 // === Contact Info ===
 
 func (r *Repository) GetContactInfo(ctx context.Context) (model.ContactInfo, error) {
-	query := `SELECT id, email, COALESCE(phone, ''), COALESCE(location, ''), COALESCE(linkedin, ''), COALESCE(github, ''), COALESCE(twitter, ''), COALESCE(website, ''), COALESCE(bio, ''), COALESCE(bio_fr, ''), COALESCE(about_title, ''), COALESCE(about_title_fr, ''), updated_at FROM contact_info LIMIT 1`
+	query := `SELECT id, email, COALESCE(phone, ''), COALESCE(location, ''), COALESCE(linkedin, ''), COALESCE(github, ''), COALESCE(twitter, ''), COALESCE(website, ''), COALESCE(bio, ''), COALESCE(about_title, ''), version, updated_at FROM contact_info LIMIT 1`
 	
 	var info model.ContactInfo
 	err := r.db.QueryRow(ctx, query).Scan(
 		&info.ID, &info.Email, &info.Phone, &info.Location, 
 		&info.LinkedIn, &info.GitHub, &info.Twitter, &info.Website,
-		&info.Bio, &info.BioFr, &info.AboutTitle, &info.AboutTitleFr, &info.UpdatedAt,
+		&info.Bio, &info.AboutTitle, &info.Version, &info.UpdatedAt,
 	)
 	
 	if err != nil {
@@ -447,9 +869,13 @@ func (r *Repository) GetContactInfo(ctx context.Context) (model.ContactInfo, err
 		return model.ContactInfo{}, err
 	}
 	
-	return info, nil
+	translations, err := loadTranslations(ctx, r.db, model.AuditEntityContactInfo, info.ID)
+	translations[info.ID].apply(&info)
+	return info, err
 }
 
+// UpdateContactInfo creates or replaces the contact info if it is still at
+// info.Version; info.Translations are merged into the stored ones.
 func (r *Repository) UpdateContactInfo(ctx context.Context, info model.ContactInfo) (model.ContactInfo, error) {
 	// Check if record exists
 	existing, err := r.GetContactInfo(ctx)
@@ -457,34 +883,206 @@ func (r *Repository) UpdateContactInfo(ctx context.Context, info model.ContactIn
 		return model.ContactInfo{}, err
 	}
 	
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.ContactInfo{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if existing.Version != info.Version {
+		return model.ContactInfo{}, ErrVersionConflict
+	}
+
 	var query string
 	if existing.ID == "" {
 		// Create new
-		query = `INSERT INTO contact_info (email, phone, location, linkedin, github, twitter, website, bio, bio_fr, about_title, about_title_fr, updated_at) 
-				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, CURRENT_TIMESTAMP) 
-				RETURNING id, email, phone, location, linkedin, github, twitter, website, bio, bio_fr, about_title, about_title_fr, updated_at`
+		query = `INSERT INTO contact_info (email, phone, location, linkedin, github, twitter, website, bio, about_title, updated_at) 
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, CURRENT_TIMESTAMP) 
+				RETURNING id, email, phone, location, linkedin, github, twitter, website, bio, about_title, version, updated_at`
 		
-		err = r.db.QueryRow(ctx, query, info.Email, info.Phone, info.Location, info.LinkedIn, info.GitHub, info.Twitter, info.Website, info.Bio, info.BioFr, info.AboutTitle, info.AboutTitleFr).Scan(
+		err = tx.QueryRow(ctx, query, info.Email, info.Phone, info.Location, info.LinkedIn, info.GitHub, info.Twitter, info.Website, info.Bio, info.AboutTitle).Scan(
 			&info.ID, &info.Email, &info.Phone, &info.Location, 
 			&info.LinkedIn, &info.GitHub, &info.Twitter, &info.Website,
-			&info.Bio, &info.BioFr, &info.AboutTitle, &info.AboutTitleFr, &info.UpdatedAt,
+			&info.Bio, &info.AboutTitle, &info.Version, &info.UpdatedAt,
 		)
 	} else {
-		// Update existing
-		query = `UPDATE contact_info SET email=$1, phone=$2, location=$3, linkedin=$4, github=$5, twitter=$6, website=$7, bio=$8, bio_fr=$9, about_title=$10, about_title_fr=$11, updated_at=CURRENT_TIMESTAMP 
-				WHERE id=$12 
-				RETURNING id, email, phone, location, linkedin, github, twitter, website, bio, bio_fr, about_title, about_title_fr, updated_at`
+		// Update existing, unless someone else saved it since it was read
+		query = `UPDATE contact_info SET email=$1, phone=$2, location=$3, linkedin=$4, github=$5, twitter=$6, website=$7, bio=$8, about_title=$9, version=version+1, updated_at=CURRENT_TIMESTAMP 
+				WHERE id=$10 AND version=$11
+				RETURNING id, email, phone, location, linkedin, github, twitter, website, bio, about_title, version, updated_at`
 				
-		err = r.db.QueryRow(ctx, query, info.Email, info.Phone, info.Location, info.LinkedIn, info.GitHub, info.Twitter, info.Website, info.Bio, info.BioFr, info.AboutTitle, info.AboutTitleFr, existing.ID).Scan(
+		err = tx.QueryRow(ctx, query, info.Email, info.Phone, info.Location, info.LinkedIn, info.GitHub, info.Twitter, info.Website, info.Bio, info.AboutTitle, existing.ID, info.Version).Scan(
 			&info.ID, &info.Email, &info.Phone, &info.Location, 
 			&info.LinkedIn, &info.GitHub, &info.Twitter, &info.Website,
-			&info.Bio, &info.BioFr, &info.AboutTitle, &info.AboutTitleFr, &info.UpdatedAt,
+			&info.Bio, &info.AboutTitle, &info.Version, &info.UpdatedAt,
 		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ContactInfo{}, ErrVersionConflict
+		}
 	}
 	
 	if err != nil {
 		return model.ContactInfo{}, err
 	}
 	
-	return info, nil
+	translations, err := saveTranslations(ctx, tx, model.AuditEntityContactInfo, info.ID, info.Translations)
+	if err != nil {
+		return model.ContactInfo{}, err
+	}
+	translations.apply(&info)
+	return info, tx.Commit(ctx)
+}
+
+// === Admin ===
+
+func (r *Repository) CountAdmins(ctx context.Context) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM admin`).Scan(&count)
+	return count, err
+}
+
+func (r *Repository) GetAdminByEmail(ctx context.Context, email string) (model.Admin, error) {
+	query := `SELECT ` + adminColumns + ` FROM admin WHERE LOWER(email) = LOWER($1)`
+	return scanAdmin(r.db.QueryRow(ctx, query, email))
+}
+
+func (r *Repository) GetAdminByID(ctx context.Context, id string) (model.Admin, error) {
+	query := `SELECT ` + adminColumns + ` FROM admin WHERE id = $1`
+	return scanAdmin(r.db.QueryRow(ctx, query, id))
+}
+
+const adminColumns = `id, email, password_hash, name, role, COALESCE(totp_secret, ''), totp_last_step, totp_enabled, created_at, updated_at`
+
+func scanAdmin(row pgx.Row) (model.Admin, error) {
+	var a model.Admin
+	err := row.Scan(&a.ID, &a.Email, &a.PasswordHash, &a.Name, &a.Role, &a.TOTPSecret, &a.TOTPLastStep, &a.TwoFactorEnabled, &a.CreatedAt, &a.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Admin{}, ErrNotFound
+	}
+	return a, err
+}
+
+func (r *Repository) CreateAdmin(ctx context.Context, a model.Admin) (model.Admin, error) {
+	query := `
+		INSERT INTO admin (email, password_hash, name, role)
+		VALUES (LOWER($1), $2, $3, $4)
+		RETURNING id, email, created_at, updated_at
+	`
+	err := r.db.QueryRow(ctx, query, a.Email, a.PasswordHash, a.Name, a.Role).Scan(&a.ID, &a.Email, &a.CreatedAt, &a.UpdatedAt)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return a, ErrAdminExists
+	}
+	return a, err
+}
+
+func (r *Repository) GetAdmins(ctx context.Context) ([]model.Admin, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+adminColumns+` FROM admin ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	admins := []model.Admin{}
+	for rows.Next() {
+		a, err := scanAdmin(rows)
+		if err != nil {
+			return nil, err
+		}
+		admins = append(admins, a)
+	}
+	return admins, rows.Err()
+}
+
+// UpdateAdminUser changes an admin's name and role. It refuses to demote the
+// last remaining owner so the site can never be locked out of user management.
+func (r *Repository) UpdateAdminUser(ctx context.Context, id, name, role string) (model.Admin, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.Admin{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	if role != "owner" {
+		if err := ensureOtherOwner(ctx, tx, id); err != nil {
+			return model.Admin{}, err
+		}
+	}
+
+	query := `UPDATE admin SET name = $1, role = $2, updated_at = NOW() WHERE id = $3 RETURNING ` + adminColumns
+	a, err := scanAdmin(tx.QueryRow(ctx, query, name, role, id))
+	if err != nil {
+		return model.Admin{}, err
+	}
+	return a, tx.Commit(ctx)
+}
+
+// DeleteAdmin removes an admin and signs them out; passkeys and recovery codes cascade.
+// Like UpdateAdminUser it will not remove the last owner.
+func (r *Repository) DeleteAdmin(ctx context.Context, id string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := ensureOtherOwner(ctx, tx, id); err != nil {
+		return err
+	}
+
+	// Refresh tokens cascade away with the admin, so denylist their live access tokens first.
+	denylist := `
+		INSERT INTO revoked_access_tokens (jti, expires_at)
+		SELECT access_token_id, access_expires_at
+		FROM refresh_tokens
+		WHERE admin_id = $1 AND access_expires_at > NOW()
+		ON CONFLICT (jti) DO NOTHING
+	`
+	if _, err := tx.Exec(ctx, denylist, id); err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM admin WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return tx.Commit(ctx)
+}
+
+// ensureOtherOwner returns ErrLastOwner if id is the only owner. The owner rows
+// are locked so two concurrent demotions cannot both pass the check.
+func ensureOtherOwner(ctx context.Context, tx pgx.Tx, id string) error {
+	rows, err := tx.Query(ctx, `SELECT id FROM admin WHERE role = 'owner' FOR UPDATE`)
+	if err != nil {
+		return err
+	}
+	owners, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+
+	for _, owner := range owners {
+		if owner != id {
+			return nil
+		}
+	}
+	if len(owners) == 0 {
+		return nil
+	}
+	return ErrLastOwner
+}
+
+func (r *Repository) UpdateAdminPassword(ctx context.Context, id, passwordHash string) error {
+	query := `UPDATE admin SET password_hash = $1, updated_at = NOW() WHERE id = $2`
+	tag, err := r.db.Exec(ctx, query, passwordHash, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
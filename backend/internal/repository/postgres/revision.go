@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// === Revisions ===
+
+// revisionColumns lists, per revisioned entity type, the columns a restore
+// writes back, along with any translations. Identity, timestamps and
+// publication status are left alone.
+// Keep these in sync with the migrations and with repository.IsRevisioned.
+var revisionColumns = map[string][]string{
+	model.AuditEntitySkill: {
+		"name", "icon", "proficiency", "category", "sort_order", "show_in_portfolio",
+	},
+	model.AuditEntityProject: {
+		"title", "description", "image_url", "live_url", "code_url",
+		"tags", "featured", "sort_order",
+	},
+	model.AuditEntityExperience: {
+		"title", "company", "location", "start_date", "end_date",
+		"start_date_precision", "end_date_precision", "is_current", "description", "sort_order",
+	},
+	model.AuditEntityEducation: {
+		"degree", "school", "location", "start_date", "end_date",
+		"start_date_precision", "end_date_precision", "description", "sort_order",
+	},
+	model.AuditEntityHobby: {
+		"name", "icon", "description", "sort_order",
+	},
+	model.AuditEntityContactInfo: {
+		"email", "phone", "location", "linkedin", "github", "twitter", "website",
+		"bio", "about_title",
+	},
+}
+
+const revisionColumnsSQL = `v.id, v.entity_type, v.entity_id, v.revision, v.actor_id, COALESCE(a.email, ''), v.snapshot, v.created_at`
+
+func scanRevision(row pgx.Row) (model.Revision, error) {
+	var rev model.Revision
+	err := row.Scan(&rev.ID, &rev.EntityType, &rev.EntityID, &rev.Revision, &rev.ActorID, &rev.ActorEmail, &rev.Snapshot, &rev.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return rev, ErrNotFound
+	}
+	return rev, err
+}
+
+// CreateRevision stores snapshot as the next revision of the entity. Writers
+// for one entity are serialised with an advisory lock so two concurrent saves
+// cannot both pick the same revision number.
+func (r *Repository) CreateRevision(ctx context.Context, entityType, entityID string, snapshot json.RawMessage, actorID *string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1::text || ':' || $2::text))`, entityType, entityID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO content_revisions (entity_type, entity_id, revision, snapshot, actor_id)
+		SELECT $1, $2, COALESCE(MAX(revision), 0) + 1, $3, $4
+		FROM content_revisions
+		WHERE entity_type = $1 AND entity_id = $2
+	`
+	if _, err := tx.Exec(ctx, query, entityType, entityID, snapshot, actorID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// GetRevisions lists an entity's revisions, newest first.
+func (r *Repository) GetRevisions(ctx context.Context, entityType, entityID string) ([]model.Revision, error) {
+	query := `SELECT ` + revisionColumnsSQL + ` FROM content_revisions v LEFT JOIN admin a ON a.id = v.actor_id
+		WHERE v.entity_type = $1 AND v.entity_id = $2 ORDER BY v.revision DESC`
+	rows, err := r.db.Query(ctx, query, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []model.Revision{}
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, rows.Err()
+}
+
+func (r *Repository) GetRevision(ctx context.Context, entityType, entityID string, revision int) (model.Revision, error) {
+	query := `SELECT ` + revisionColumnsSQL + ` FROM content_revisions v LEFT JOIN admin a ON a.id = v.actor_id
+		WHERE v.entity_type = $1 AND v.entity_id = $2 AND v.revision = $3`
+	return scanRevision(r.db.QueryRow(ctx, query, entityType, entityID, revision))
+}
+
+// RestoreSnapshot writes the revisioned columns and translations of snapshot
+// back onto the row if it is still at version. Columns missing from an older
+// snapshot keep their current value. It returns ErrNotFound when the row no
+// longer exists or is in the trash, and ErrVersionConflict when it changed.
+func (r *Repository) RestoreSnapshot(ctx context.Context, entityType, entityID string, version int, snapshot json.RawMessage) error {
+	columns, ok := revisionColumns[entityType]
+	if !ok {
+		return fmt.Errorf("entity type %q has no revisions", entityType)
+	}
+	table := auditTables[entityType]
+	columnList := strings.Join(columns, ", ")
+
+	condition := "t.id::text = $2 AND t.version = $3"
+	if repository.IsTrashable(entityType) {
+		condition += " AND t.deleted_at IS NULL"
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %[1]s t SET (%[2]s) = (SELECT %[2]s FROM jsonb_populate_record(t, $1)), version = t.version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE %[3]s
+	`, table, columnList, condition)
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, query, snapshot, entityID, version)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		if repository.IsTrashable(entityType) {
+			return r.versionMismatch(ctx, table, entityID)
+		}
+		var exists bool
+		query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE id::text = $1)`, table)
+		if err := r.db.QueryRow(ctx, query, entityID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return ErrNotFound
+		}
+		return ErrVersionConflict
+	}
+
+	if repository.IsTranslatable(entityType) {
+		translations, ok, err := snapshotTranslations(entityType, snapshot)
+		if err != nil {
+			return err
+		}
+		if ok {
+			if err := replaceTranslations(ctx, tx, entityType, entityID, translations); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit(ctx)
+}
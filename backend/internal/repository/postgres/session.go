@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/portfolio/backend/internal/model"
+)
+
+// === Sessions ===
+
+func (r *Repository) CreateRefreshToken(ctx context.Context, t model.RefreshToken) (model.RefreshToken, error) {
+	query := `
+		INSERT INTO refresh_tokens (admin_id, session_id, token_hash, access_token_id, access_expires_at, user_agent, ip_address, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`
+	err := r.db.QueryRow(ctx, query, t.AdminID, t.SessionID, t.TokenHash, t.AccessTokenID, t.AccessExpires, t.UserAgent, t.IPAddress, t.ExpiresAt).Scan(&t.ID, &t.CreatedAt)
+	return t, err
+}
+
+// GetRefreshToken looks up a token by hash. Expired tokens are reported as not found.
+func (r *Repository) GetRefreshToken(ctx context.Context, tokenHash string) (model.RefreshToken, error) {
+	query := `
+		SELECT id, admin_id, session_id, token_hash, access_token_id, access_expires_at, user_agent, ip_address, expires_at, created_at, revoked_at
+		FROM refresh_tokens
+		WHERE token_hash = $1 AND expires_at > NOW()
+	`
+	var t model.RefreshToken
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(
+		&t.ID, &t.AdminID, &t.SessionID, &t.TokenHash, &t.AccessTokenID, &t.AccessExpires,
+		&t.UserAgent, &t.IPAddress, &t.ExpiresAt, &t.CreatedAt, &t.RevokedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.RefreshToken{}, ErrNotFound
+	}
+	return t, err
+}
+
+// RotateRefreshToken retires oldID and stores next in the same session.
+// If oldID was already retired the session is revoked and ErrRefreshTokenReused is returned.
+func (r *Repository) RotateRefreshToken(ctx context.Context, oldID string, next model.RefreshToken) (model.RefreshToken, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.RefreshToken{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `UPDATE refresh_tokens SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, oldID)
+	if err != nil {
+		return model.RefreshToken{}, err
+	}
+	if tag.RowsAffected() == 0 {
+		// Lost a race with another rotation, or a replay; treat both as theft.
+		tx.Rollback(ctx)
+		if err := r.RevokeSession(ctx, next.SessionID); err != nil {
+			return model.RefreshToken{}, err
+		}
+		return model.RefreshToken{}, ErrRefreshTokenReused
+	}
+
+	query := `
+		INSERT INTO refresh_tokens (admin_id, session_id, token_hash, access_token_id, access_expires_at, user_agent, ip_address, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRow(ctx, query, next.AdminID, next.SessionID, next.TokenHash, next.AccessTokenID, next.AccessExpires, next.UserAgent, next.IPAddress, next.ExpiresAt).Scan(&next.ID, &next.CreatedAt)
+	if err != nil {
+		return model.RefreshToken{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.RefreshToken{}, err
+	}
+	return next, nil
+}
+
+// RevokeSession retires every refresh token in the session and denylists the
+// access tokens that were minted alongside them and have not yet expired.
+func (r *Repository) RevokeSession(ctx context.Context, sessionID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	denylist := `
+		INSERT INTO revoked_access_tokens (jti, expires_at)
+		SELECT access_token_id, access_expires_at
+		FROM refresh_tokens
+		WHERE session_id = $1 AND access_expires_at > NOW()
+		ON CONFLICT (jti) DO NOTHING
+	`
+	if _, err := tx.Exec(ctx, denylist, sessionID); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE refresh_tokens SET revoked_at = NOW() WHERE session_id = $1 AND revoked_at IS NULL`, sessionID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RevokeAdminSession revokes a session only if it belongs to adminID.
+func (r *Repository) RevokeAdminSession(ctx context.Context, adminID, sessionID string) error {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM refresh_tokens WHERE admin_id = $1 AND session_id = $2)`
+	if err := r.db.QueryRow(ctx, query, adminID, sessionID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotFound
+	}
+	return r.RevokeSession(ctx, sessionID)
+}
+
+// RevokeAdminSessions revokes every session belonging to adminID.
+func (r *Repository) RevokeAdminSessions(ctx context.Context, adminID string) error {
+	rows, err := r.db.Query(ctx, `SELECT DISTINCT session_id FROM refresh_tokens WHERE admin_id = $1 AND revoked_at IS NULL`, adminID)
+	if err != nil {
+		return err
+	}
+	sessionIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+
+	for _, sessionID := range sessionIDs {
+		if err := r.RevokeSession(ctx, sessionID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetActiveSessions lists sessions for adminID that still hold a usable refresh token.
+func (r *Repository) GetActiveSessions(ctx context.Context, adminID string) ([]model.Session, error) {
+	query := `
+		SELECT session_id,
+			(ARRAY_AGG(user_agent ORDER BY created_at DESC))[1],
+			(ARRAY_AGG(ip_address ORDER BY created_at DESC))[1],
+			MIN(created_at), MAX(created_at), MAX(expires_at)
+		FROM refresh_tokens
+		WHERE admin_id = $1
+		GROUP BY session_id
+		HAVING BOOL_OR(revoked_at IS NULL AND expires_at > NOW())
+		ORDER BY MAX(created_at) DESC
+	`
+	rows, err := r.db.Query(ctx, query, adminID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []model.Session{}
+	for rows.Next() {
+		var s model.Session
+		if err := rows.Scan(&s.ID, &s.UserAgent, &s.IPAddress, &s.CreatedAt, &s.LastUsedAt, &s.ExpiresAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+// IsAccessTokenRevoked reports whether the access token jti is on the denylist.
+func (r *Repository) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM revoked_access_tokens WHERE jti = $1)`, jti).Scan(&revoked)
+	return revoked, err
+}
+
+// RevokeAccessToken denylists a single access token until it would have expired anyway.
+func (r *Repository) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	query := `INSERT INTO revoked_access_tokens (jti, expires_at) VALUES ($1, $2) ON CONFLICT (jti) DO NOTHING`
+	_, err := r.db.Exec(ctx, query, jti, expiresAt)
+	return err
+}
+
+// PurgeExpiredTokens drops denylist entries, refresh tokens and WebAuthn challenges past their expiry.
+func (r *Repository) PurgeExpiredTokens(ctx context.Context) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM revoked_access_tokens WHERE expires_at < NOW()`); err != nil {
+		return err
+	}
+	if _, err := r.db.Exec(ctx, `DELETE FROM webauthn_challenges WHERE expires_at < NOW()`); err != nil {
+		return err
+	}
+	_, err := r.db.Exec(ctx, `DELETE FROM refresh_tokens WHERE expires_at < NOW()`)
+	return err
+}
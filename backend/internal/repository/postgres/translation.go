@@ -0,0 +1,460 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// === Translations ===
+
+// querier is what the translation helpers need from a pool or a transaction.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// legacyTranslationColumns maps the per-table French columns that predate the
+// translations table to the field they translate. Restoring a revision taken
+// before migration 020 moved their data into translations reads them from the
+// snapshot.
+var legacyTranslationColumns = map[string]map[string]string{
+	model.AuditEntityProject: {
+		"title_fr":       "title",
+		"description_fr": "description",
+	},
+	model.AuditEntityExperience: {
+		"title_fr":       "title",
+		"company_fr":     "company",
+		"location_fr":    "location",
+		"description_fr": "description",
+	},
+	model.AuditEntityEducation: {
+		"degree_fr":      "degree",
+		"school_fr":      "school",
+		"location_fr":    "location",
+		"description_fr": "description",
+	},
+	model.AuditEntityContactInfo: {
+		"bio_fr":         "bio",
+		"about_title_fr": "aboutTitle",
+	},
+}
+
+// translationsSnapshotSQL aggregates the translations of row t into the
+// {"locale": {"field": value}} shape of model.Translations. $2 is the entity type.
+const translationsSnapshotSQL = `(
+	SELECT COALESCE(jsonb_object_agg(l.locale, l.fields), '{}'::jsonb)
+	FROM (
+		SELECT locale, jsonb_object_agg(field, value) AS fields
+		FROM translations WHERE entity_type = $2 AND entity_id = t.id
+		GROUP BY locale
+	) l
+)`
+
+// storedTranslations are an entity's translations as stored, with the
+// machine translations no one has edited yet.
+type storedTranslations struct {
+	values      model.Translations
+	needsReview model.ReviewFlags
+}
+
+// apply sets the translations on entity, a pointer to a model struct.
+func (s storedTranslations) apply(entity any) {
+	model.SetTranslations(entity, s.values)
+	model.SetNeedsReview(entity, s.needsReview)
+}
+
+// loadTranslations returns the translations of the given entities by id.
+// Entities without any are absent from the map.
+func loadTranslations(ctx context.Context, q querier, entityType string, ids ...string) (map[string]storedTranslations, error) {
+	byID := map[string]storedTranslations{}
+	if len(ids) == 0 {
+		return byID, nil
+	}
+
+	rows, err := q.Query(ctx, `SELECT entity_id::text, locale, field, value, machine_translated FROM translations WHERE entity_type = $1 AND entity_id = ANY($2::text[]::uuid[]) ORDER BY field`, entityType, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, locale, field string
+		var value any
+		var machine bool
+		if err := rows.Scan(&id, &locale, &field, &value, &machine); err != nil {
+			return nil, err
+		}
+		stored, ok := byID[id]
+		if !ok {
+			stored = storedTranslations{values: model.Translations{}}
+		}
+		stored.values.Set(locale, field, value)
+		if machine {
+			if stored.needsReview == nil {
+				stored.needsReview = model.ReviewFlags{}
+			}
+			stored.needsReview[locale] = append(stored.needsReview[locale], field)
+		}
+		byID[id] = stored
+	}
+	return byID, rows.Err()
+}
+
+// saveTranslations applies t to an entity: empty values delete a translation,
+// others insert or replace it and record a hash of the source text they
+// translate. Translations not mentioned in t are kept. It returns the
+// entity's translations afterwards.
+func saveTranslations(ctx context.Context, q querier, entityType, id string, t model.Translations) (storedTranslations, error) {
+	for locale, fields := range t {
+		for field, value := range fields {
+			if model.IsEmptyTranslation(value) {
+				query := `DELETE FROM translations WHERE entity_type = $1 AND entity_id = $2 AND locale = $3 AND field = $4`
+				if _, err := q.Exec(ctx, query, entityType, id, locale, field); err != nil {
+					return storedTranslations{}, err
+				}
+				continue
+			}
+
+			// A translation whose text is unchanged keeps its timestamp, source
+			// hash and review flag, so editing only the source marks it stale
+			// and only editing the text clears the review flag.
+			conflict := `DO UPDATE
+				SET value = EXCLUDED.value, source_hash = EXCLUDED.source_hash, machine_translated = FALSE, updated_at = NOW()
+				WHERE translations.value IS DISTINCT FROM EXCLUDED.value`
+			if err := insertTranslation(ctx, q, entityType, id, locale, field, value, false, conflict); err != nil {
+				return storedTranslations{}, err
+			}
+		}
+	}
+
+	byID, err := loadTranslations(ctx, q, entityType, id)
+	if err != nil {
+		return storedTranslations{}, err
+	}
+	return byID[id], nil
+}
+
+// saveMachineTranslations adds t to an entity as machine translations that
+// need review. Fields that already have a translation are left alone.
+func saveMachineTranslations(ctx context.Context, q querier, entityType, id string, t model.Translations) error {
+	for locale, fields := range t {
+		for field, value := range fields {
+			if model.IsEmptyTranslation(value) {
+				continue
+			}
+			if err := insertTranslation(ctx, q, entityType, id, locale, field, value, true, `DO NOTHING`); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// insertTranslation stores one translation with the hash of its current
+// source text. conflict is the ON CONFLICT action for an existing one.
+func insertTranslation(ctx context.Context, q querier, entityType, id, locale, field string, value any, machine bool, conflict string) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	query := fmt.Sprintf(`
+		INSERT INTO translations (entity_type, entity_id, locale, field, value, machine_translated, source_hash)
+		SELECT $1, $2::uuid, $3, $4, $5, $6, %s FROM %s s WHERE s.id = $2::uuid
+		ON CONFLICT (entity_type, entity_id, locale, field) %s
+	`, sourceHashSQL("s", "$7"), auditTables[entityType], conflict)
+	_, err = q.Exec(ctx, query, entityType, id, locale, field, json.RawMessage(encoded), machine, sourceColumn(field))
+	return err
+}
+
+// AddMachineTranslations stores t as machine translations of an entity that
+// need review and moves the entity to a new version, provided it is still at
+// version. Fields that already have a translation keep it.
+func (r *Repository) AddMachineTranslations(ctx context.Context, entityType, id string, version int, t model.Translations) error {
+	table := auditTables[entityType]
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := fmt.Sprintf(`UPDATE %s SET version = version + 1, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL AND version = $2`, table)
+	tag, err := tx.Exec(ctx, query, id, version)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return r.versionMismatch(ctx, table, id)
+	}
+
+	if err := saveMachineTranslations(ctx, tx, entityType, id, t); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// MarkTranslationsReviewed brings the source hash of the locale translations
+// of fields up to date and clears their machine-translation flag, leaving
+// the text alone.
+func (r *Repository) MarkTranslationsReviewed(ctx context.Context, entityType, id, locale string, fields []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := fmt.Sprintf(`
+		UPDATE translations t SET source_hash = %s, machine_translated = FALSE, updated_at = NOW()
+		FROM %s s
+		WHERE s.id = t.entity_id AND t.entity_type = $1 AND t.entity_id = $2::uuid AND t.locale = $3 AND t.field = $4
+	`, sourceHashSQL("s", "$5"), auditTables[entityType])
+	for _, field := range fields {
+		tag, err := tx.Exec(ctx, query, entityType, id, locale, field, sourceColumn(field))
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrNotFound
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// replaceTranslations makes t the entity's complete set of translations.
+func replaceTranslations(ctx context.Context, q querier, entityType, id string, t model.Translations) error {
+	if _, err := q.Exec(ctx, `DELETE FROM translations WHERE entity_type = $1 AND entity_id = $2`, entityType, id); err != nil {
+		return err
+	}
+	_, err := saveTranslations(ctx, q, entityType, id, t)
+	return err
+}
+
+// snapshotTranslations returns the translations recorded in a revision
+// snapshot. Snapshots taken before the translations table carry the legacy
+// French columns instead.
+func snapshotTranslations(entityType string, snapshot json.RawMessage) (model.Translations, bool, error) {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(snapshot, &document); err != nil {
+		return nil, false, err
+	}
+
+	if raw, ok := document["translations"]; ok {
+		var t model.Translations
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return nil, false, err
+		}
+		return t, true, nil
+	}
+
+	t := model.Translations{}
+	found := false
+	for column, field := range legacyTranslationColumns[entityType] {
+		raw, ok := document[column]
+		if !ok {
+			continue
+		}
+		found = true
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, false, err
+		}
+		if !model.IsEmptyTranslation(value) {
+			t.Set(model.LegacyLocale, field, value)
+		}
+	}
+	return t, found, nil
+}
+
+// sourceHashSQL hashes the source text of a translated field: column of row,
+// as JSON. The same expression must be used wherever hashes are compared.
+func sourceHashSQL(row, column string) string {
+	return fmt.Sprintf(`md5((to_jsonb(%s) -> %s)::text)`, row, column)
+}
+
+// sourceColumn is the column holding the source text of a translatable
+// field: "aboutTitle" is stored in about_title.
+func sourceColumn(field string) string {
+	var column strings.Builder
+	for _, r := range field {
+		if unicode.IsUpper(r) {
+			column.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		column.WriteRune(r)
+	}
+	return column.String()
+}
+
+// attachTranslations loads the translations of items and sets them on each.
+func attachTranslations[T any](ctx context.Context, q querier, entityType string, items []T, id func(T) string) error {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, id(item))
+	}
+	byID, err := loadTranslations(ctx, q, entityType, ids...)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		byID[id(items[i])].apply(&items[i])
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// === Translation status ===
+
+// TranslationStatus reports, for each of locales, which translatable fields
+// of live content have text but no translation, and which translations are
+// stale because their source text changed after they were last updated.
+func (r *Repository) TranslationStatus(ctx context.Context, locales []string) (model.TranslationStatus, error) {
+	status := model.TranslationStatus{
+		Locales: []model.LocaleCoverage{},
+		Missing: []model.TranslationGap{},
+		Stale:   []model.TranslationGap{},
+	}
+	coverage := make(map[string]*model.LocaleCoverage, len(locales))
+	for _, locale := range locales {
+		coverage[locale] = &model.LocaleCoverage{Locale: locale}
+	}
+
+	for _, entityType := range sortedKeys(model.TranslatableFields) {
+		sources, err := r.translationSources(ctx, entityType)
+		if err != nil {
+			return model.TranslationStatus{}, err
+		}
+		translated, err := r.translationHashes(ctx, entityType)
+		if err != nil {
+			return model.TranslationStatus{}, err
+		}
+
+		for _, source := range sources {
+			for _, locale := range locales {
+				gap := model.TranslationGap{EntityType: entityType, EntityID: source.id, Locale: locale, Field: source.field}
+				counts := coverage[locale]
+				counts.Total++
+
+				translation, ok := translated[translationKey{source.id, locale, source.field}]
+				if !ok {
+					counts.Missing++
+					status.Missing = append(status.Missing, gap)
+					continue
+				}
+				counts.Translated++
+				if translation.sourceHash != nil && *translation.sourceHash != source.hash {
+					counts.Stale++
+					gap.TranslatedAt = &translation.updatedAt
+					status.Stale = append(status.Stale, gap)
+				}
+			}
+		}
+	}
+
+	for _, locale := range locales {
+		counts := coverage[locale]
+		counts.Coverage = 100
+		if counts.Total > 0 {
+			counts.Coverage = math.Round(float64(counts.Translated)*1000/float64(counts.Total)) / 10
+		}
+		status.Locales = append(status.Locales, *counts)
+	}
+	return status, nil
+}
+
+type translationSource struct {
+	id, field, hash string
+}
+
+type translationKey struct {
+	id, locale, field string
+}
+
+type translationHash struct {
+	sourceHash *string
+	updatedAt  time.Time
+}
+
+// translationSources lists the translatable fields of live entityType rows
+// that have source text, with the hash of that text. Drafts, rows scheduled
+// for later and trashed rows are not live.
+func (r *Repository) translationSources(ctx context.Context, entityType string) ([]translationSource, error) {
+	kinds := model.TranslatableFields[entityType]
+	fields := sortedKeys(kinds)
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = sourceColumn(field)
+	}
+
+	filter := ""
+	if repository.IsTrashable(entityType) {
+		filter = `WHERE s.deleted_at IS NULL`
+		if repository.IsPublishable(entityType) {
+			filter += publishedOnly
+		}
+		filter += ` ORDER BY s.sort_order, s.id`
+	}
+	query := fmt.Sprintf(`
+		SELECT s.id::text, f.field, %s, to_jsonb(s) -> f.col
+		FROM %s s CROSS JOIN unnest($1::text[], $2::text[]) AS f(field, col)
+		%s
+	`, sourceHashSQL("s", "f.col"), auditTables[entityType], filter)
+
+	rows, err := r.db.Query(ctx, query, fields, columns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sources []translationSource
+	for rows.Next() {
+		var source translationSource
+		var text any
+		if err := rows.Scan(&source.id, &source.field, &source.hash, &text); err != nil {
+			return nil, err
+		}
+		if !model.IsEmptyTranslation(text) {
+			sources = append(sources, source)
+		}
+	}
+	return sources, rows.Err()
+}
+
+// translationHashes returns the source hash and last update of every
+// translation of entityType.
+func (r *Repository) translationHashes(ctx context.Context, entityType string) (map[translationKey]translationHash, error) {
+	rows, err := r.db.Query(ctx, `SELECT entity_id::text, locale, field, source_hash, updated_at FROM translations WHERE entity_type = $1`, entityType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := map[translationKey]translationHash{}
+	for rows.Next() {
+		var key translationKey
+		var hash translationHash
+		if err := rows.Scan(&key.id, &key.locale, &key.field, &hash.sourceHash, &hash.updatedAt); err != nil {
+			return nil, err
+		}
+		hashes[key] = hash
+	}
+	return hashes, rows.Err()
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// === Trash ===
+
+// GetTrash lists trashed rows of the given entity types, most recently deleted first.
+func (r *Repository) GetTrash(ctx context.Context, entityTypes []string) ([]model.TrashItem, error) {
+	items := []model.TrashItem{}
+
+	var selects []string
+	for _, entityType := range entityTypes {
+		if !repository.IsTrashable(entityType) {
+			continue
+		}
+		selects = append(selects, fmt.Sprintf(
+			`SELECT '%s', t.id::text, to_jsonb(t), t.deleted_at FROM %s t WHERE t.deleted_at IS NOT NULL`,
+			entityType, auditTables[entityType]))
+	}
+	if len(selects) == 0 {
+		return items, nil
+	}
+
+	rows, err := r.db.Query(ctx, strings.Join(selects, " UNION ALL ")+" ORDER BY 4 DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item model.TrashItem
+		if err := rows.Scan(&item.EntityType, &item.ID, &item.Data, &item.DeletedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// RestoreFromTrash clears deleted_at. It returns ErrNotFound when the row is
+// not in the trash.
+func (r *Repository) RestoreFromTrash(ctx context.Context, entityType, id string) error {
+	if !repository.IsTrashable(entityType) {
+		return ErrNotFound
+	}
+	bump := ""
+	if repository.IsVersioned(entityType) {
+		bump = ", version = version + 1"
+	}
+	query := fmt.Sprintf(`UPDATE %s SET deleted_at = NULL%s WHERE id::text = $1 AND deleted_at IS NOT NULL`, auditTables[entityType], bump)
+	tag, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// PurgeTrash permanently deletes rows trashed before cutoff, along with their
+// revision history and translations, and returns how many rows were removed.
+func (r *Repository) PurgeTrash(ctx context.Context, cutoff time.Time) (int64, error) {
+	var total int64
+	for _, entityType := range repository.TrashEntityTypes {
+		query := fmt.Sprintf(`
+			WITH purged AS (
+				DELETE FROM %s WHERE deleted_at IS NOT NULL AND deleted_at < $1 RETURNING id
+			), revisions AS (
+				DELETE FROM content_revisions WHERE entity_type = $2 AND entity_id IN (SELECT id::text FROM purged)
+			), translations AS (
+				DELETE FROM translations WHERE entity_type = $2 AND entity_id IN (SELECT id FROM purged)
+			)
+			SELECT COUNT(*) FROM purged
+		`, auditTables[entityType])
+
+		var purged int64
+		if err := r.db.QueryRow(ctx, query, cutoff, entityType).Scan(&purged); err != nil {
+			return total, err
+		}
+		total += purged
+	}
+	return total, nil
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// === Two-factor authentication ===
+
+// SetPendingTOTPSecret stores a secret that is not enforced until EnableTOTP is called.
+// It refuses to overwrite the secret of an account that already has 2FA enabled.
+func (r *Repository) SetPendingTOTPSecret(ctx context.Context, adminID, secret string) error {
+	query := `UPDATE admin SET totp_secret = $1, totp_last_step = 0, updated_at = NOW() WHERE id = $2 AND totp_enabled = FALSE`
+	tag, err := r.db.Exec(ctx, query, secret, adminID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// EnableTOTP turns on 2FA and replaces any previous recovery codes with codeHashes.
+func (r *Repository) EnableTOTP(ctx context.Context, adminID string, step int64, codeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE admin SET totp_enabled = TRUE, totp_last_step = $1, updated_at = NOW() WHERE id = $2 AND totp_secret IS NOT NULL`
+	tag, err := tx.Exec(ctx, query, step, adminID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	if err := replaceRecoveryCodes(ctx, tx, adminID, codeHashes); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// DisableTOTP clears the secret and deletes every recovery code.
+func (r *Repository) DisableTOTP(ctx context.Context, adminID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE admin SET totp_enabled = FALSE, totp_secret = NULL, totp_last_step = 0, updated_at = NOW() WHERE id = $1`
+	if _, err := tx.Exec(ctx, query, adminID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM admin_recovery_codes WHERE admin_id = $1`, adminID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ConsumeTOTPStep records step as used. It returns false if that step (or a
+// later one) was already used, which blocks replay of an intercepted code.
+func (r *Repository) ConsumeTOTPStep(ctx context.Context, adminID string, step int64) (bool, error) {
+	query := `UPDATE admin SET totp_last_step = $1 WHERE id = $2 AND totp_last_step < $1`
+	tag, err := r.db.Exec(ctx, query, step, adminID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ReplaceRecoveryCodes discards all existing recovery codes and stores codeHashes.
+func (r *Repository) ReplaceRecoveryCodes(ctx context.Context, adminID string, codeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := replaceRecoveryCodes(ctx, tx, adminID, codeHashes); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx pgx.Tx, adminID string, codeHashes []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM admin_recovery_codes WHERE admin_id = $1`, adminID); err != nil {
+		return err
+	}
+	for _, hash := range codeHashes {
+		if _, err := tx.Exec(ctx, `INSERT INTO admin_recovery_codes (admin_id, code_hash) VALUES ($1, $2)`, adminID, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UseRecoveryCode marks a matching unused code as spent. It returns ErrNotFound
+// if the code does not exist or was already used.
+func (r *Repository) UseRecoveryCode(ctx context.Context, adminID, codeHash string) error {
+	query := `UPDATE admin_recovery_codes SET used_at = NOW() WHERE admin_id = $1 AND code_hash = $2 AND used_at IS NULL`
+	tag, err := r.db.Exec(ctx, query, adminID, codeHash)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *Repository) CountUnusedRecoveryCodes(ctx context.Context, adminID string) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM admin_recovery_codes WHERE admin_id = $1 AND used_at IS NULL`, adminID).Scan(&count)
+	return count, err
+}
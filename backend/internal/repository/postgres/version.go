@@ -0,0 +1,22 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// === Row versions ===
+
+// versionMismatch explains why a versioned write on table matched no row:
+// either the row is gone or in the trash, or it has moved on to another version.
+func (r *Repository) versionMismatch(ctx context.Context, table, id string) error {
+	var exists bool
+	query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE id::text = $1 AND deleted_at IS NULL)`, table)
+	if err := r.db.QueryRow(ctx, query, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotFound
+	}
+	return ErrVersionConflict
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/portfolio/backend/internal/model"
+)
+
+// === WebAuthn ===
+
+const webAuthnCredentialColumns = "id, admin_id, credential_id, public_key, sign_count, transports, name, created_at, last_used_at"
+
+func scanWebAuthnCredential(row pgx.Row) (model.WebAuthnCredential, error) {
+	var cred model.WebAuthnCredential
+	var signCount int64
+	err := row.Scan(&cred.ID, &cred.AdminID, &cred.CredentialID, &cred.PublicKey, &signCount,
+		&cred.Transports, &cred.Name, &cred.CreatedAt, &cred.LastUsedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return cred, ErrNotFound
+	}
+	cred.SignCount = uint32(signCount)
+	return cred, err
+}
+
+func (r *Repository) CreateWebAuthnChallenge(ctx context.Context, challenge model.WebAuthnChallenge) (string, error) {
+	var adminID *string
+	if challenge.AdminID != "" {
+		adminID = &challenge.AdminID
+	}
+
+	query := `INSERT INTO webauthn_challenges (admin_id, challenge, purpose, expires_at) VALUES ($1, $2, $3, $4) RETURNING id`
+	var id string
+	err := r.db.QueryRow(ctx, query, adminID, challenge.Challenge, challenge.Purpose, challenge.ExpiresAt).Scan(&id)
+	return id, err
+}
+
+// ConsumeWebAuthnChallenge deletes and returns an unexpired challenge so it can only
+// be answered once. Registration challenges must also belong to adminID.
+func (r *Repository) ConsumeWebAuthnChallenge(ctx context.Context, id, purpose, adminID string) (model.WebAuthnChallenge, error) {
+	query := `DELETE FROM webauthn_challenges
+		WHERE id = $1 AND purpose = $2 AND COALESCE(admin_id::text, '') = $3 AND expires_at > NOW()
+		RETURNING id, COALESCE(admin_id::text, ''), challenge, purpose, expires_at`
+
+	var challenge model.WebAuthnChallenge
+	err := r.db.QueryRow(ctx, query, id, purpose, adminID).Scan(
+		&challenge.ID, &challenge.AdminID, &challenge.Challenge, &challenge.Purpose, &challenge.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return challenge, ErrNotFound
+	}
+	return challenge, err
+}
+
+func (r *Repository) CreateWebAuthnCredential(ctx context.Context, cred model.WebAuthnCredential) (model.WebAuthnCredential, error) {
+	if cred.Transports == nil {
+		cred.Transports = []string{}
+	}
+
+	query := `INSERT INTO webauthn_credentials (admin_id, credential_id, public_key, sign_count, transports, name)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING ` + webAuthnCredentialColumns
+	created, err := scanWebAuthnCredential(r.db.QueryRow(ctx, query,
+		cred.AdminID, cred.CredentialID, cred.PublicKey, int64(cred.SignCount), cred.Transports, cred.Name))
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return created, ErrCredentialExists
+	}
+	return created, err
+}
+
+func (r *Repository) GetWebAuthnCredentialByCredentialID(ctx context.Context, credentialID string) (model.WebAuthnCredential, error) {
+	query := `SELECT ` + webAuthnCredentialColumns + ` FROM webauthn_credentials WHERE credential_id = $1`
+	return scanWebAuthnCredential(r.db.QueryRow(ctx, query, credentialID))
+}
+
+func (r *Repository) GetWebAuthnCredentials(ctx context.Context, adminID string) ([]model.WebAuthnCredential, error) {
+	query := `SELECT ` + webAuthnCredentialColumns + ` FROM webauthn_credentials WHERE admin_id = $1 ORDER BY created_at`
+	rows, err := r.db.Query(ctx, query, adminID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	creds := []model.WebAuthnCredential{}
+	for rows.Next() {
+		cred, err := scanWebAuthnCredential(rows)
+		if err != nil {
+			return nil, err
+		}
+		creds = append(creds, cred)
+	}
+	return creds, rows.Err()
+}
+
+// UpdateWebAuthnSignCount records a successful assertion. The counter only moves
+// forward, so two concurrent logins cannot roll it back.
+func (r *Repository) UpdateWebAuthnSignCount(ctx context.Context, id string, signCount uint32) error {
+	query := `UPDATE webauthn_credentials SET sign_count = GREATEST(sign_count, $1), last_used_at = NOW() WHERE id = $2`
+	_, err := r.db.Exec(ctx, query, int64(signCount), id)
+	return err
+}
+
+func (r *Repository) DeleteWebAuthnCredential(ctx context.Context, adminID, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM webauthn_credentials WHERE id = $1 AND admin_id = $2`, id, adminID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
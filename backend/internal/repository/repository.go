@@ -0,0 +1,107 @@
+// Package repository defines the storage interfaces the handlers depend on,
+// one per aggregate, and the errors and entity types every implementation
+// shares. postgres is the production implementation; memory keeps everything
+// in process for tests.
+package repository
+
+import (
+	"errors"
+
+	"github.com/portfolio/backend/internal/model"
+)
+
+var (
+	// ErrNotFound is returned when a lookup or mutation matches no row.
+	ErrNotFound = errors.New("not found")
+	// ErrVersionConflict is returned when a versioned update or delete names a
+	// version that is no longer the stored one.
+	ErrVersionConflict = errors.New("version conflict")
+
+	ErrAdminExists = errors.New("admin already exists")
+	ErrLastOwner   = errors.New("cannot remove the last owner")
+
+	ErrCredentialExists = errors.New("credential already registered")
+
+	// ErrRefreshTokenReused is returned when an already-rotated refresh token is
+	// presented again. The whole session is revoked before this is returned.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+)
+
+// === Entity types ===
+
+// TrashEntityTypes are the entity types whose deletes are soft: the row keeps
+// a deletion time until it is restored or purged.
+var TrashEntityTypes = []string{
+	model.AuditEntitySkill,
+	model.AuditEntityProject,
+	model.AuditEntityExperience,
+	model.AuditEntityEducation,
+	model.AuditEntityHobby,
+	model.AuditEntityTestimonial,
+	model.AuditEntityMessage,
+}
+
+// PublishableEntityTypes have a draft/published/scheduled status.
+var PublishableEntityTypes = []string{
+	model.AuditEntitySkill,
+	model.AuditEntityProject,
+	model.AuditEntityExperience,
+	model.AuditEntityEducation,
+	model.AuditEntityHobby,
+}
+
+// versionedEntityTypes carry a version that every write bumps, so the admin
+// can detect concurrent edits.
+var versionedEntityTypes = []string{
+	model.AuditEntitySkill,
+	model.AuditEntityProject,
+	model.AuditEntityExperience,
+	model.AuditEntityEducation,
+	model.AuditEntityHobby,
+	model.AuditEntityContactInfo,
+}
+
+// revisionedEntityTypes keep a revision history of their updates.
+var revisionedEntityTypes = []string{
+	model.AuditEntitySkill,
+	model.AuditEntityProject,
+	model.AuditEntityExperience,
+	model.AuditEntityEducation,
+	model.AuditEntityHobby,
+	model.AuditEntityContactInfo,
+}
+
+// IsTrashable reports whether entityType is soft-deleted.
+func IsTrashable(entityType string) bool {
+	return contains(TrashEntityTypes, entityType)
+}
+
+// IsPublishable reports whether entityType has a publication status.
+func IsPublishable(entityType string) bool {
+	return contains(PublishableEntityTypes, entityType)
+}
+
+// IsVersioned reports whether entityType has a row version.
+func IsVersioned(entityType string) bool {
+	return contains(versionedEntityTypes, entityType)
+}
+
+// IsRevisioned reports whether updates to entityType keep a revision history.
+func IsRevisioned(entityType string) bool {
+	return contains(revisionedEntityTypes, entityType)
+}
+
+// IsTranslatable reports whether entityType has translatable fields.
+func IsTranslatable(entityType string) bool {
+	_, ok := model.TranslatableFields[entityType]
+	return ok
+}
+
+func contains(list []string, s string) bool {
+	for _, candidate := range list {
+		if candidate == s {
+			return true
+		}
+	}
+	return false
+}
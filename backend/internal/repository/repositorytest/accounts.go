@@ -0,0 +1,367 @@
+package repositorytest
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/portfolio/backend/internal/auth"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// === Admin accounts ===
+
+func testAdmins(t *testing.T, s repository.Store) {
+	owner := createAdmin(t, s, "Owner@Example.com", auth.RoleOwner)
+	if owner.Email != "owner@example.com" {
+		t.Fatalf("email = %q, want it lowercased", owner.Email)
+	}
+	_, err := s.CreateAdmin(ctx, model.Admin{Email: "OWNER@example.com", PasswordHash: "hash", Name: "Again", Role: auth.RoleEditor})
+	wantErr(t, err, repository.ErrAdminExists)
+
+	got, err := s.GetAdminByEmail(ctx, "OWNER@EXAMPLE.COM")
+	ok(t, err)
+	if got.ID != owner.ID {
+		t.Fatalf("GetAdminByEmail found %s, want %s", got.ID, owner.ID)
+	}
+	_, err = s.GetAdminByEmail(ctx, "nobody@example.com")
+	wantErr(t, err, repository.ErrNotFound)
+	_, err = s.GetAdminByID(ctx, missingID)
+	wantErr(t, err, repository.ErrNotFound)
+
+	editor := createAdmin(t, s, "editor@example.com", auth.RoleEditor)
+	count, err := s.CountAdmins(ctx)
+	ok(t, err)
+	admins, err := s.GetAdmins(ctx)
+	ok(t, err)
+	if count != 2 || len(admins) != 2 || admins[0].ID != owner.ID {
+		t.Fatalf("admins = %d, %+v, want the owner then the editor", count, admins)
+	}
+
+	ok(t, s.UpdateAdminPassword(ctx, editor.ID, "new-hash"))
+	wantErr(t, s.UpdateAdminPassword(ctx, missingID, "hash"), repository.ErrNotFound)
+	got, err = s.GetAdminByID(ctx, editor.ID)
+	ok(t, err)
+	if got.PasswordHash != "new-hash" {
+		t.Fatalf("password hash = %q, want new-hash", got.PasswordHash)
+	}
+
+	// Deleting an admin signs them out and leaves their history behind.
+	session := createSession(t, s, editor.ID, time.Hour)
+	ok(t, s.CreateAuditEntry(ctx, model.AuditEntry{ActorID: &editor.ID, Action: model.AuditActionCreate, EntityType: model.AuditEntitySkill}))
+	ok(t, s.DeleteAdmin(ctx, editor.ID))
+	wantErr(t, s.DeleteAdmin(ctx, editor.ID), repository.ErrNotFound)
+	if revoked, err := s.IsAccessTokenRevoked(ctx, session.AccessTokenID); err != nil || !revoked {
+		t.Fatalf("access token of a deleted admin revoked = %v, %v", revoked, err)
+	}
+	_, err = s.GetRefreshToken(ctx, session.TokenHash)
+	wantErr(t, err, repository.ErrNotFound)
+	entries, _, err := s.ListAuditEntries(ctx, model.AuditLogFilter{Limit: 10})
+	ok(t, err)
+	if len(entries) != 1 || entries[0].ActorID != nil {
+		t.Fatalf("audit entries = %+v, want one without an actor", entries)
+	}
+}
+
+func testLastOwner(t *testing.T, s repository.Store) {
+	owner := createOwner(t, s)
+	_, err := s.UpdateAdminUser(ctx, owner.ID, "Owner", auth.RoleEditor)
+	wantErr(t, err, repository.ErrLastOwner)
+	wantErr(t, s.DeleteAdmin(ctx, owner.ID), repository.ErrLastOwner)
+
+	// Renaming the last owner is fine as long as they stay one.
+	renamed, err := s.UpdateAdminUser(ctx, owner.ID, "Renamed", auth.RoleOwner)
+	ok(t, err)
+	if renamed.Name != "Renamed" {
+		t.Fatalf("name = %q, want Renamed", renamed.Name)
+	}
+
+	second := createAdmin(t, s, "second@example.com", auth.RoleOwner)
+	demoted, err := s.UpdateAdminUser(ctx, owner.ID, "Owner", auth.RoleEditor)
+	ok(t, err)
+	if demoted.Role != auth.RoleEditor {
+		t.Fatalf("role = %q, want editor", demoted.Role)
+	}
+	wantErr(t, s.DeleteAdmin(ctx, second.ID), repository.ErrLastOwner)
+	ok(t, s.DeleteAdmin(ctx, owner.ID))
+	_, err = s.UpdateAdminUser(ctx, missingID, "Nobody", auth.RoleOwner)
+	wantErr(t, err, repository.ErrNotFound)
+}
+
+func testTwoFactor(t *testing.T, s repository.Store) {
+	owner := createOwner(t, s)
+	wantErr(t, s.EnableTOTP(ctx, owner.ID, 1, nil), repository.ErrNotFound)
+
+	ok(t, s.SetPendingTOTPSecret(ctx, owner.ID, "secret"))
+	ok(t, s.EnableTOTP(ctx, owner.ID, 10, []string{"code-1", "code-2"}))
+	wantErr(t, s.SetPendingTOTPSecret(ctx, owner.ID, "other"), repository.ErrNotFound)
+	got, err := s.GetAdminByID(ctx, owner.ID)
+	ok(t, err)
+	if !got.TwoFactorEnabled || got.TOTPSecret != "secret" || got.TOTPLastStep != 10 {
+		t.Fatalf("admin = %+v, want 2FA enabled at step 10", got)
+	}
+
+	for _, tc := range []struct {
+		step int64
+		want bool
+	}{{10, false}, {9, false}, {11, true}, {11, false}} {
+		consumed, err := s.ConsumeTOTPStep(ctx, owner.ID, tc.step)
+		ok(t, err)
+		if consumed != tc.want {
+			t.Fatalf("ConsumeTOTPStep(%d) = %v, want %v", tc.step, consumed, tc.want)
+		}
+	}
+
+	ok(t, s.UseRecoveryCode(ctx, owner.ID, "code-1"))
+	wantErr(t, s.UseRecoveryCode(ctx, owner.ID, "code-1"), repository.ErrNotFound)
+	wantErr(t, s.UseRecoveryCode(ctx, owner.ID, "unknown"), repository.ErrNotFound)
+	if n, err := s.CountUnusedRecoveryCodes(ctx, owner.ID); err != nil || n != 1 {
+		t.Fatalf("unused recovery codes = %d, %v, want 1", n, err)
+	}
+	ok(t, s.ReplaceRecoveryCodes(ctx, owner.ID, []string{"code-3", "code-4", "code-5"}))
+	if n, err := s.CountUnusedRecoveryCodes(ctx, owner.ID); err != nil || n != 3 {
+		t.Fatalf("unused recovery codes = %d, %v, want 3", n, err)
+	}
+	wantErr(t, s.UseRecoveryCode(ctx, owner.ID, "code-2"), repository.ErrNotFound)
+
+	ok(t, s.DisableTOTP(ctx, owner.ID))
+	got, err = s.GetAdminByID(ctx, owner.ID)
+	ok(t, err)
+	if got.TwoFactorEnabled || got.TOTPSecret != "" {
+		t.Fatalf("admin = %+v, want 2FA disabled and the secret cleared", got)
+	}
+	if n, err := s.CountUnusedRecoveryCodes(ctx, owner.ID); err != nil || n != 0 {
+		t.Fatalf("unused recovery codes = %d, %v, want 0", n, err)
+	}
+}
+
+// === Sessions ===
+
+// createSession logs adminID in: a new session with one refresh token,
+// valid for ttl, and an access token valid for a minute.
+func createSession(t *testing.T, s repository.Store, adminID string, ttl time.Duration) model.RefreshToken {
+	t.Helper()
+	token, err := s.CreateRefreshToken(ctx, nextRefreshToken(adminID, newID(), ttl))
+	ok(t, err)
+	return token
+}
+
+// nextRefreshToken is a refresh token, with its access token, for a session.
+func nextRefreshToken(adminID, sessionID string, ttl time.Duration) model.RefreshToken {
+	return model.RefreshToken{
+		AdminID:       adminID,
+		SessionID:     sessionID,
+		TokenHash:     newID(),
+		AccessTokenID: newID(),
+		AccessExpires: time.Now().Add(time.Minute),
+		UserAgent:     "test",
+		IPAddress:     "127.0.0.1",
+		ExpiresAt:     time.Now().Add(ttl),
+	}
+}
+
+func testSessions(t *testing.T, s repository.Store) {
+	owner := createOwner(t, s)
+	editor := createAdmin(t, s, "editor@example.com", auth.RoleEditor)
+
+	first := createSession(t, s, owner.ID, time.Hour)
+	second := createSession(t, s, owner.ID, time.Hour)
+	expired := createSession(t, s, owner.ID, -time.Minute)
+	createSession(t, s, editor.ID, time.Hour)
+
+	got, err := s.GetRefreshToken(ctx, first.TokenHash)
+	ok(t, err)
+	if got.ID != first.ID || got.SessionID != first.SessionID || got.RevokedAt != nil {
+		t.Fatalf("refresh token = %+v, want %+v", got, first)
+	}
+	_, err = s.GetRefreshToken(ctx, expired.TokenHash)
+	wantErr(t, err, repository.ErrNotFound)
+
+	sessions, err := s.GetActiveSessions(ctx, owner.ID)
+	ok(t, err)
+	if len(sessions) != 2 || sessions[0].ID != second.SessionID || sessions[1].ID != first.SessionID {
+		t.Fatalf("sessions = %+v, want the two live ones, most recent first", sessions)
+	}
+
+	wantErr(t, s.RevokeAdminSession(ctx, editor.ID, first.SessionID), repository.ErrNotFound)
+	ok(t, s.RevokeAdminSession(ctx, owner.ID, first.SessionID))
+	if revoked, err := s.IsAccessTokenRevoked(ctx, first.AccessTokenID); err != nil || !revoked {
+		t.Fatalf("access token of a revoked session revoked = %v, %v", revoked, err)
+	}
+	got, err = s.GetRefreshToken(ctx, first.TokenHash)
+	ok(t, err)
+	if got.RevokedAt == nil {
+		t.Fatal("refresh token of a revoked session is not revoked")
+	}
+
+	ok(t, s.RevokeAdminSessions(ctx, owner.ID))
+	sessions, err = s.GetActiveSessions(ctx, owner.ID)
+	ok(t, err)
+	if len(sessions) != 0 {
+		t.Fatalf("sessions after revoking all = %+v", sessions)
+	}
+	sessions, err = s.GetActiveSessions(ctx, editor.ID)
+	ok(t, err)
+	if len(sessions) != 1 {
+		t.Fatalf("editor sessions = %+v, want theirs untouched", sessions)
+	}
+
+	// The denylist forgets tokens once they would have expired anyway.
+	jti := newID()
+	ok(t, s.RevokeAccessToken(ctx, jti, time.Now().Add(-time.Second)))
+	ok(t, s.RevokeAccessToken(ctx, jti, time.Now().Add(-time.Second)))
+	if revoked, err := s.IsAccessTokenRevoked(ctx, jti); err != nil || !revoked {
+		t.Fatalf("revoked access token = %v, %v", revoked, err)
+	}
+	ok(t, s.PurgeExpiredTokens(ctx))
+	if revoked, err := s.IsAccessTokenRevoked(ctx, jti); err != nil || revoked {
+		t.Fatalf("expired access token still revoked = %v, %v", revoked, err)
+	}
+	if revoked, err := s.IsAccessTokenRevoked(ctx, second.AccessTokenID); err != nil || !revoked {
+		t.Fatalf("live access token revoked = %v, %v, want it kept", revoked, err)
+	}
+}
+
+func testRefreshTokenReuse(t *testing.T, s repository.Store) {
+	owner := createOwner(t, s)
+	first := createSession(t, s, owner.ID, time.Hour)
+
+	second, err := s.RotateRefreshToken(ctx, first.ID, nextRefreshToken(owner.ID, first.SessionID, time.Hour))
+	ok(t, err)
+	if second.ID == "" || second.ID == first.ID || second.SessionID != first.SessionID {
+		t.Fatalf("rotated token = %+v, want a new token in the same session", second)
+	}
+	got, err := s.GetRefreshToken(ctx, first.TokenHash)
+	ok(t, err)
+	if got.RevokedAt == nil {
+		t.Fatal("rotated-out token is not revoked")
+	}
+
+	// Presenting the old token again looks like theft: the whole session goes.
+	_, err = s.RotateRefreshToken(ctx, first.ID, nextRefreshToken(owner.ID, first.SessionID, time.Hour))
+	wantErr(t, err, repository.ErrRefreshTokenReused)
+	got, err = s.GetRefreshToken(ctx, second.TokenHash)
+	ok(t, err)
+	if got.RevokedAt == nil {
+		t.Fatal("token of a reused session is not revoked")
+	}
+	if revoked, err := s.IsAccessTokenRevoked(ctx, second.AccessTokenID); err != nil || !revoked {
+		t.Fatalf("access token of a reused session revoked = %v, %v", revoked, err)
+	}
+	sessions, err := s.GetActiveSessions(ctx, owner.ID)
+	ok(t, err)
+	if len(sessions) != 0 {
+		t.Fatalf("sessions = %+v, want none", sessions)
+	}
+}
+
+// === WebAuthn ===
+
+func testWebAuthn(t *testing.T, s repository.Store) {
+	owner := createOwner(t, s)
+	editor := createAdmin(t, s, "editor@example.com", auth.RoleEditor)
+	challenge := func(adminID, purpose string, ttl time.Duration) string {
+		id, err := s.CreateWebAuthnChallenge(ctx, model.WebAuthnChallenge{AdminID: adminID, Challenge: "challenge", Purpose: purpose, ExpiresAt: time.Now().Add(ttl)})
+		ok(t, err)
+		return id
+	}
+
+	register := challenge(owner.ID, model.WebAuthnPurposeRegister, time.Minute)
+	_, err := s.ConsumeWebAuthnChallenge(ctx, register, model.WebAuthnPurposeLogin, owner.ID)
+	wantErr(t, err, repository.ErrNotFound)
+	_, err = s.ConsumeWebAuthnChallenge(ctx, register, model.WebAuthnPurposeRegister, editor.ID)
+	wantErr(t, err, repository.ErrNotFound)
+	got, err := s.ConsumeWebAuthnChallenge(ctx, register, model.WebAuthnPurposeRegister, owner.ID)
+	ok(t, err)
+	if got.ID != register || got.AdminID != owner.ID || got.Challenge != "challenge" {
+		t.Fatalf("challenge = %+v", got)
+	}
+	_, err = s.ConsumeWebAuthnChallenge(ctx, register, model.WebAuthnPurposeRegister, owner.ID)
+	wantErr(t, err, repository.ErrNotFound)
+
+	login := challenge("", model.WebAuthnPurposeLogin, time.Minute)
+	_, err = s.ConsumeWebAuthnChallenge(ctx, login, model.WebAuthnPurposeLogin, "")
+	ok(t, err)
+	expired := challenge("", model.WebAuthnPurposeLogin, -time.Minute)
+	_, err = s.ConsumeWebAuthnChallenge(ctx, expired, model.WebAuthnPurposeLogin, "")
+	wantErr(t, err, repository.ErrNotFound)
+
+	cred, err := s.CreateWebAuthnCredential(ctx, model.WebAuthnCredential{AdminID: owner.ID, CredentialID: "cred-1", PublicKey: []byte{1, 2, 3}, SignCount: 5, Name: "Laptop"})
+	ok(t, err)
+	if cred.ID == "" || cred.Transports == nil || len(cred.Transports) != 0 || cred.LastUsedAt != nil {
+		t.Fatalf("credential = %+v, want an id and no transports", cred)
+	}
+	_, err = s.CreateWebAuthnCredential(ctx, model.WebAuthnCredential{AdminID: editor.ID, CredentialID: "cred-1", PublicKey: []byte{4}})
+	wantErr(t, err, repository.ErrCredentialExists)
+
+	// The sign count never moves back.
+	ok(t, s.UpdateWebAuthnSignCount(ctx, cred.ID, 10))
+	ok(t, s.UpdateWebAuthnSignCount(ctx, cred.ID, 7))
+	stored, err := s.GetWebAuthnCredentialByCredentialID(ctx, "cred-1")
+	ok(t, err)
+	if stored.SignCount != 10 || stored.LastUsedAt == nil || !bytes.Equal(stored.PublicKey, []byte{1, 2, 3}) {
+		t.Fatalf("credential = %+v, want sign count 10 and a last use", stored)
+	}
+	_, err = s.GetWebAuthnCredentialByCredentialID(ctx, "unknown")
+	wantErr(t, err, repository.ErrNotFound)
+
+	creds, err := s.GetWebAuthnCredentials(ctx, owner.ID)
+	ok(t, err)
+	if len(creds) != 1 || creds[0].ID != cred.ID {
+		t.Fatalf("credentials = %+v", creds)
+	}
+	creds, err = s.GetWebAuthnCredentials(ctx, editor.ID)
+	ok(t, err)
+	if len(creds) != 0 {
+		t.Fatalf("editor credentials = %+v, want none", creds)
+	}
+
+	wantErr(t, s.DeleteWebAuthnCredential(ctx, editor.ID, cred.ID), repository.ErrNotFound)
+	ok(t, s.DeleteWebAuthnCredential(ctx, owner.ID, cred.ID))
+	wantErr(t, s.DeleteWebAuthnCredential(ctx, owner.ID, cred.ID), repository.ErrNotFound)
+}
+
+// === API keys ===
+
+func testAPIKeys(t *testing.T, s repository.Store) {
+	createOwner(t, s)
+	editor := createAdmin(t, s, "editor@example.com", auth.RoleEditor)
+
+	first, err := s.CreateAPIKey(ctx, model.APIKey{AdminID: editor.ID, Name: "CI", Prefix: "pk_1", KeyHash: "hash-1", Scopes: []string{"content:read"}})
+	ok(t, err)
+	if first.ID == "" || first.AdminRole != auth.RoleEditor || first.LastUsedAt != nil {
+		t.Fatalf("created key = %+v, want an id and the editor role", first)
+	}
+	second, err := s.CreateAPIKey(ctx, model.APIKey{AdminID: editor.ID, Name: "Deploy", Prefix: "pk_2", KeyHash: "hash-2", Scopes: []string{"content:write"}})
+	ok(t, err)
+
+	keys, err := s.GetAPIKeys(ctx)
+	ok(t, err)
+	if len(keys) != 2 || keys[0].ID != second.ID {
+		t.Fatalf("keys = %+v, want both, newest first", keys)
+	}
+
+	// Keys carry their creator's current role.
+	_, err = s.UpdateAdminUser(ctx, editor.ID, editor.Name, auth.RoleOwner)
+	ok(t, err)
+	got, err := s.GetAPIKeyByHash(ctx, "hash-1")
+	ok(t, err)
+	if got.ID != first.ID || got.AdminRole != auth.RoleOwner || len(got.Scopes) != 1 {
+		t.Fatalf("key = %+v, want the first key with the owner role", got)
+	}
+	_, err = s.GetAPIKeyByHash(ctx, "unknown")
+	wantErr(t, err, repository.ErrNotFound)
+
+	ok(t, s.TouchAPIKey(ctx, first.ID))
+	got, err = s.GetAPIKeyByHash(ctx, "hash-1")
+	ok(t, err)
+	if got.LastUsedAt == nil {
+		t.Fatal("touched key has no last use")
+	}
+
+	ok(t, s.DeleteAPIKey(ctx, first.ID))
+	wantErr(t, s.DeleteAPIKey(ctx, first.ID), repository.ErrNotFound)
+	_, err = s.GetAPIKeyByHash(ctx, "hash-1")
+	wantErr(t, err, repository.ErrNotFound)
+}
@@ -0,0 +1,432 @@
+package repositorytest
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// === Content ===
+
+func testVersions(t *testing.T, s repository.Store) {
+	skill, err := s.CreateSkill(ctx, model.Skill{Publication: published, Name: "Go", Proficiency: 90})
+	ok(t, err)
+	if skill.ID == "" || skill.Version != 1 || skill.Status != model.StatusPublished {
+		t.Fatalf("created skill = %+v, want an id, version 1 and published", skill)
+	}
+
+	stale := skill
+	skill.Name = "Golang"
+	skill, err = s.UpdateSkill(ctx, skill)
+	ok(t, err)
+	if skill.Version != 2 {
+		t.Fatalf("version after update = %d, want 2", skill.Version)
+	}
+	got, err := s.GetSkillByID(ctx, skill.ID)
+	ok(t, err)
+	if got.Name != "Golang" || got.Version != 2 {
+		t.Fatalf("stored skill = %+v, want the update", got)
+	}
+
+	_, err = s.UpdateSkill(ctx, stale)
+	wantErr(t, err, repository.ErrVersionConflict)
+	wantErr(t, s.DeleteSkill(ctx, skill.ID, stale.Version), repository.ErrVersionConflict)
+
+	ok(t, s.DeleteSkill(ctx, skill.ID, skill.Version))
+	_, err = s.GetSkillByID(ctx, skill.ID)
+	wantErr(t, err, repository.ErrNotFound)
+	_, err = s.UpdateSkill(ctx, skill)
+	wantErr(t, err, repository.ErrNotFound)
+	wantErr(t, s.DeleteSkill(ctx, missingID, 1), repository.ErrNotFound)
+	_, err = s.GetHobbyByID(ctx, missingID)
+	wantErr(t, err, repository.ErrNotFound)
+}
+
+// testConcurrentUpdates has several writers update the same version of a
+// row at once; exactly one of them may win.
+func testConcurrentUpdates(t *testing.T, s repository.Store) {
+	hobby, err := s.CreateHobby(ctx, model.Hobby{Publication: published, Name: "Chess"})
+	ok(t, err)
+
+	const writers = 8
+	errs := make(chan error, writers)
+	var wg sync.WaitGroup
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			update := hobby
+			update.SortOrder = i
+			_, err := s.UpdateHobby(ctx, update)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	won := 0
+	for err := range errs {
+		if err == nil {
+			won++
+			continue
+		}
+		wantErr(t, err, repository.ErrVersionConflict)
+	}
+	if won != 1 {
+		t.Fatalf("%d concurrent updates succeeded, want 1", won)
+	}
+	got, err := s.GetHobbyByID(ctx, hobby.ID)
+	ok(t, err)
+	if got.Version != 2 {
+		t.Fatalf("version = %d, want 2", got.Version)
+	}
+}
+
+func testPublication(t *testing.T, s repository.Store) {
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	create := func(name string, order int, status string, publishAt *time.Time) model.Project {
+		p, err := s.CreateProject(ctx, model.Project{
+			Title: name, SortOrder: order,
+			Publication: model.Publication{Status: status, PublishAt: publishAt},
+		})
+		ok(t, err)
+		return p
+	}
+	due := create("due", 3, model.StatusScheduled, &past)
+	create("later", 2, model.StatusScheduled, &future)
+	draft := create("draft", 1, model.StatusDraft, nil)
+	create("published", 4, model.StatusPublished, nil)
+
+	public, err := s.GetProjects(ctx)
+	ok(t, err)
+	if got, want := projectTitles(public), []string{"due", "published"}; !slices.Equal(got, want) {
+		t.Fatalf("public projects = %v, want %v", got, want)
+	}
+	all, err := s.GetAllProjects(ctx)
+	ok(t, err)
+	if got, want := projectTitles(all), []string{"draft", "later", "due", "published"}; !slices.Equal(got, want) {
+		t.Fatalf("all projects = %v, want %v", got, want)
+	}
+
+	// An update without a status leaves the workflow alone.
+	draft.Title = "still a draft"
+	draft, err = s.UpdateProject(ctx, draft)
+	ok(t, err)
+	if draft.Status != model.StatusDraft {
+		t.Fatalf("status after update = %q, want draft", draft.Status)
+	}
+
+	changed, err := s.PublishScheduled(ctx, time.Now())
+	ok(t, err)
+	if changed != 1 {
+		t.Fatalf("PublishScheduled changed %d rows, want 1", changed)
+	}
+	got, err := s.GetProjectByID(ctx, due.ID)
+	ok(t, err)
+	if got.Status != model.StatusPublished || got.Version != due.Version {
+		t.Fatalf("published project = %+v, want published at version %d", got, due.Version)
+	}
+}
+
+func projectTitles(projects []model.Project) []string {
+	titles := []string{}
+	for _, p := range projects {
+		titles = append(titles, p.Title)
+	}
+	return titles
+}
+
+func testTranslations(t *testing.T, s repository.Store) {
+	exp, err := s.CreateExperience(ctx, model.Experience{
+		Title: "Engineer", Company: "Acme", Description: []string{"Built things"}, Publication: published,
+		Translations: model.Translations{"fr": {"title": "Ingénieur", "description": []string{"Construit des choses"}}},
+	})
+	ok(t, err)
+	if got := exp.Translations.Text("fr", "title"); got != "Ingénieur" {
+		t.Fatalf("created title translation = %q", got)
+	}
+
+	// Fields left out are kept; empty values remove a translation.
+	exp.Translations = model.Translations{"fr": {"title": "", "company": "Acme SA"}}
+	_, err = s.UpdateExperience(ctx, exp)
+	ok(t, err)
+	got, err := s.GetExperienceByID(ctx, exp.ID)
+	ok(t, err)
+	if title := got.Translations.Text("fr", "title"); title != "" {
+		t.Fatalf("title translation = %q, want it removed", title)
+	}
+	if company := got.Translations.Text("fr", "company"); company != "Acme SA" {
+		t.Fatalf("company translation = %q, want Acme SA", company)
+	}
+	if list := got.Translations.List("fr", "description"); !slices.Equal(list, []string{"Construit des choses"}) {
+		t.Fatalf("description translation = %v, want it kept", list)
+	}
+
+	// Translations come back from lists too.
+	all, err := s.GetAllExperiences(ctx)
+	ok(t, err)
+	if len(all) != 1 || all[0].Translations.Text("fr", "company") != "Acme SA" {
+		t.Fatalf("listed experiences = %+v", all)
+	}
+}
+
+func testMachineTranslations(t *testing.T, s repository.Store) {
+	p, err := s.CreateProject(ctx, model.Project{
+		Title: "Portfolio", Description: "This site", Publication: published,
+		Translations: model.Translations{"fr": {"title": "Portfolio"}},
+	})
+	ok(t, err)
+
+	machine := model.Translations{"fr": {"title": "Portefeuille", "description": "Ce site"}}
+	wantErr(t, s.AddMachineTranslations(ctx, model.AuditEntityProject, p.ID, p.Version+1, machine), repository.ErrVersionConflict)
+	ok(t, s.AddMachineTranslations(ctx, model.AuditEntityProject, p.ID, p.Version, machine))
+
+	got, err := s.GetProjectByID(ctx, p.ID)
+	ok(t, err)
+	if got.Version != p.Version+1 {
+		t.Fatalf("version = %d, want %d", got.Version, p.Version+1)
+	}
+	if title := got.Translations.Text("fr", "title"); title != "Portfolio" {
+		t.Fatalf("title translation = %q, want the existing one kept", title)
+	}
+	if desc := got.Translations.Text("fr", "description"); desc != "Ce site" {
+		t.Fatalf("description translation = %q, want the machine one", desc)
+	}
+	if flags := got.NeedsReview["fr"]; !slices.Equal(flags, []string{"description"}) {
+		t.Fatalf("needsReview = %v, want [description]", got.NeedsReview)
+	}
+
+	// Saving the same text keeps the flag; editing it clears the flag.
+	got.Translations = model.Translations{"fr": {"description": "Ce site"}}
+	got, err = s.UpdateProject(ctx, got)
+	ok(t, err)
+	if len(got.NeedsReview["fr"]) != 1 {
+		t.Fatalf("needsReview after resaving = %v, want it kept", got.NeedsReview)
+	}
+	got.Translations = model.Translations{"fr": {"description": "Ce site web"}}
+	got, err = s.UpdateProject(ctx, got)
+	ok(t, err)
+	if len(got.NeedsReview["fr"]) != 0 {
+		t.Fatalf("needsReview after editing = %v, want it cleared", got.NeedsReview)
+	}
+}
+
+func testTranslationStatus(t *testing.T, s repository.Store) {
+	chess, err := s.CreateHobby(ctx, model.Hobby{Publication: published, Name: "Chess", Translations: model.Translations{"fr": {"name": "Échecs"}}})
+	ok(t, err)
+
+	status, err := s.TranslationStatus(ctx, []string{"fr"})
+	ok(t, err)
+	if len(status.Locales) != 1 || status.Locales[0] != (model.LocaleCoverage{Locale: "fr", Total: 1, Translated: 1, Coverage: 100}) {
+		t.Fatalf("coverage = %+v, want the one field translated", status.Locales)
+	}
+
+	// Changing the source makes the translation stale; a second hobby adds
+	// a missing field.
+	chess.Name = "Go"
+	_, err = s.UpdateHobby(ctx, chess)
+	ok(t, err)
+	hiking, err := s.CreateHobby(ctx, model.Hobby{Publication: published, Name: "Hiking"})
+	ok(t, err)
+	// Drafts are not live, so they do not count towards coverage.
+	_, err = s.CreateHobby(ctx, model.Hobby{Publication: model.Publication{Status: model.StatusDraft}, Name: "Sailing"})
+	ok(t, err)
+
+	status, err = s.TranslationStatus(ctx, []string{"fr"})
+	ok(t, err)
+	if got, want := status.Locales[0], (model.LocaleCoverage{Locale: "fr", Total: 2, Translated: 1, Missing: 1, Stale: 1, Coverage: 50}); got != want {
+		t.Fatalf("coverage = %+v, want %+v", got, want)
+	}
+	if len(status.Missing) != 1 || status.Missing[0].EntityID != hiking.ID || status.Missing[0].Field != "name" {
+		t.Fatalf("missing = %+v, want the new hobby's name", status.Missing)
+	}
+	if len(status.Stale) != 1 || status.Stale[0].EntityID != chess.ID || status.Stale[0].TranslatedAt == nil {
+		t.Fatalf("stale = %+v, want the edited hobby's name", status.Stale)
+	}
+
+	// Reviewing the kept translation brings it up to date with the new source.
+	wantErr(t, s.MarkTranslationsReviewed(ctx, model.AuditEntityHobby, chess.ID, "fr", []string{"name", "description"}), repository.ErrNotFound)
+	ok(t, s.MarkTranslationsReviewed(ctx, model.AuditEntityHobby, chess.ID, "fr", []string{"name"}))
+	status, err = s.TranslationStatus(ctx, []string{"fr"})
+	ok(t, err)
+	if got := status.Locales[0]; got.Stale != 0 || got.Translated != 1 || len(status.Stale) != 0 {
+		t.Fatalf("coverage = %+v, stale = %+v, want the reviewed translation up to date", got, status.Stale)
+	}
+}
+
+// === Trash ===
+
+func testTrash(t *testing.T, s repository.Store) {
+	skill, err := s.CreateSkill(ctx, model.Skill{Publication: published, Name: "Go"})
+	ok(t, err)
+	project, err := s.CreateProject(ctx, model.Project{Publication: published, Title: "Site", Translations: model.Translations{"fr": {"title": "Site"}}})
+	ok(t, err)
+	ok(t, s.CreateRevision(ctx, model.AuditEntityProject, project.ID, []byte(`{}`), nil))
+
+	ok(t, s.DeleteSkill(ctx, skill.ID, skill.Version))
+	ok(t, s.DeleteProject(ctx, project.ID, project.Version))
+
+	trash, err := s.GetTrash(ctx, repository.TrashEntityTypes)
+	ok(t, err)
+	if len(trash) != 2 || trash[0].ID != project.ID || trash[1].ID != skill.ID {
+		t.Fatalf("trash = %+v, want the project then the skill", trash)
+	}
+	if trash[0].EntityType != model.AuditEntityProject || len(trash[0].Data) == 0 {
+		t.Fatalf("trashed project = %+v", trash[0])
+	}
+	trash, err = s.GetTrash(ctx, []string{model.AuditEntitySkill})
+	ok(t, err)
+	if len(trash) != 1 {
+		t.Fatalf("skill trash = %+v, want one skill", trash)
+	}
+	public, err := s.GetAllSkills(ctx)
+	ok(t, err)
+	if len(public) != 0 {
+		t.Fatalf("skills = %+v, want the trashed skill left out", public)
+	}
+
+	ok(t, s.RestoreFromTrash(ctx, model.AuditEntitySkill, skill.ID))
+	wantErr(t, s.RestoreFromTrash(ctx, model.AuditEntitySkill, skill.ID), repository.ErrNotFound)
+	wantErr(t, s.RestoreFromTrash(ctx, model.AuditEntityContactInfo, missingID), repository.ErrNotFound)
+	restored, err := s.GetSkillByID(ctx, skill.ID)
+	ok(t, err)
+	if restored.Version != skill.Version+2 {
+		t.Fatalf("restored version = %d, want %d", restored.Version, skill.Version+2)
+	}
+
+	// Nothing was trashed before an hour ago.
+	purged, err := s.PurgeTrash(ctx, time.Now().Add(-time.Hour))
+	ok(t, err)
+	if purged != 0 {
+		t.Fatalf("purged %d rows, want 0", purged)
+	}
+	purged, err = s.PurgeTrash(ctx, time.Now().Add(time.Hour))
+	ok(t, err)
+	if purged != 1 {
+		t.Fatalf("purged %d rows, want 1", purged)
+	}
+	trash, err = s.GetTrash(ctx, repository.TrashEntityTypes)
+	ok(t, err)
+	if len(trash) != 0 {
+		t.Fatalf("trash after purge = %+v", trash)
+	}
+	revisions, err := s.GetRevisions(ctx, model.AuditEntityProject, project.ID)
+	ok(t, err)
+	if len(revisions) != 0 {
+		t.Fatalf("revisions of a purged project = %+v", revisions)
+	}
+	if snapshot, err := s.SnapshotEntity(ctx, model.AuditEntityProject, project.ID); err != nil || snapshot != nil {
+		t.Fatalf("snapshot of a purged project = %s, %v", snapshot, err)
+	}
+}
+
+// === Testimonials, messages and contact info ===
+
+func testTestimonials(t *testing.T, s repository.Store) {
+	pending, err := s.CreateTestimonial(ctx, model.Testimonial{AuthorName: "Ann", AuthorEmail: "ann@example.com", Content: "Great", Rating: 5, Status: "pending"})
+	ok(t, err)
+	approved, err := s.CreateTestimonial(ctx, model.Testimonial{AuthorName: "Bob", AuthorEmail: "bob@example.com", Content: "Good", Rating: 4, Status: "approved"})
+	ok(t, err)
+
+	public, err := s.GetApprovedTestimonials(ctx)
+	ok(t, err)
+	if len(public) != 1 || public[0].ID != approved.ID || public[0].AuthorEmail != "" {
+		t.Fatalf("approved testimonials = %+v, want Bob without his email", public)
+	}
+
+	ok(t, s.UpdateTestimonialStatus(ctx, pending.ID, "approved"))
+	public, err = s.GetApprovedTestimonials(ctx)
+	ok(t, err)
+	if len(public) != 2 || public[0].ID != approved.ID {
+		t.Fatalf("approved testimonials = %+v, want both, newest first", public)
+	}
+
+	ok(t, s.DeleteTestimonial(ctx, pending.ID))
+	wantErr(t, s.DeleteTestimonial(ctx, pending.ID), repository.ErrNotFound)
+	wantErr(t, s.UpdateTestimonialStatus(ctx, pending.ID, "rejected"), repository.ErrNotFound)
+	all, err := s.GetAllTestimonials(ctx)
+	ok(t, err)
+	if len(all) != 1 || all[0].AuthorEmail != "bob@example.com" {
+		t.Fatalf("all testimonials = %+v, want Bob with his email", all)
+	}
+}
+
+func testMessages(t *testing.T, s repository.Store) {
+	first, err := s.CreateMessage(ctx, model.Message{Name: "Ann", Email: "ann@example.com", Subject: "Hi", Content: "Hello there", ContentHash: "hash-1"})
+	ok(t, err)
+	second, err := s.CreateMessage(ctx, model.Message{Name: "Bob", Email: "bob@example.com", Subject: "Hey", Content: "Hello again", ContentHash: "hash-2"})
+	ok(t, err)
+
+	ok(t, s.MarkMessageRead(ctx, first.ID))
+	messages, err := s.GetMessages(ctx)
+	ok(t, err)
+	if len(messages) != 2 || messages[0].ID != second.ID || !messages[1].Read || messages[1].ContentHash != "" {
+		t.Fatalf("messages = %+v, want newest first, the first read and no hashes", messages)
+	}
+
+	// Trashed messages still count as duplicates.
+	ok(t, s.DeleteMessage(ctx, first.ID))
+	wantErr(t, s.DeleteMessage(ctx, first.ID), repository.ErrNotFound)
+	wantErr(t, s.MarkMessageRead(ctx, first.ID), repository.ErrNotFound)
+	for _, tc := range []struct {
+		email, hash string
+		want        bool
+	}{
+		{"ann@example.com", "hash-1", true},
+		{"ann@example.com", "hash-2", false},
+		{"bob@example.com", "hash-1", false},
+	} {
+		dup, err := s.HasRecentDuplicateMessage(ctx, tc.email, tc.hash, 0)
+		ok(t, err)
+		if dup != tc.want {
+			t.Fatalf("HasRecentDuplicateMessage(%s, %s) = %v, want %v", tc.email, tc.hash, dup, tc.want)
+		}
+	}
+
+	purged, err := s.PurgeMessages(ctx, time.Now().Add(time.Hour))
+	ok(t, err)
+	if purged != 2 {
+		t.Fatalf("purged %d messages, want both", purged)
+	}
+	dup, err := s.HasRecentDuplicateMessage(ctx, "ann@example.com", "hash-1", time.Hour)
+	ok(t, err)
+	if dup {
+		t.Fatal("purged message still counts as a duplicate")
+	}
+}
+
+func testContactInfo(t *testing.T, s repository.Store) {
+	info, err := s.GetContactInfo(ctx)
+	ok(t, err)
+	if info.ID != "" {
+		t.Fatalf("contact info = %+v, want none", info)
+	}
+
+	info, err = s.UpdateContactInfo(ctx, model.ContactInfo{Email: "me@example.com", Bio: "Developer", Translations: model.Translations{"fr": {"bio": "Développeur"}}})
+	ok(t, err)
+	if info.ID == "" {
+		t.Fatal("first update did not create the contact info")
+	}
+	if info.Version != 1 {
+		t.Fatalf("version = %d, want 1", info.Version)
+	}
+	_, err = s.UpdateContactInfo(ctx, model.ContactInfo{Email: "stale@example.com"})
+	wantErr(t, err, repository.ErrVersionConflict)
+	again, err := s.UpdateContactInfo(ctx, model.ContactInfo{Email: "new@example.com", Bio: "Developer", Version: info.Version})
+	ok(t, err)
+	if again.ID != info.ID || again.Version != 2 {
+		t.Fatalf("second update = %s at version %d, want %s replaced at version 2", again.ID, again.Version, info.ID)
+	}
+	_, err = s.UpdateContactInfo(ctx, model.ContactInfo{Email: "stale@example.com", Version: info.Version})
+	wantErr(t, err, repository.ErrVersionConflict)
+
+	got, err := s.GetContactInfo(ctx)
+	ok(t, err)
+	if got.Email != "new@example.com" || got.Translations.Text("fr", "bio") != "Développeur" {
+		t.Fatalf("contact info = %+v, want the new email and the kept translation", got)
+	}
+}
@@ -0,0 +1,181 @@
+package repositorytest
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// === Audit log ===
+
+func testAuditLog(t *testing.T, s repository.Store) {
+	owner := createOwner(t, s)
+	skillID := newID()
+	record := func(entry model.AuditEntry) {
+		ok(t, s.CreateAuditEntry(ctx, entry))
+	}
+	record(model.AuditEntry{ActorID: &owner.ID, ActorEmail: owner.Email, Action: model.AuditActionCreate, EntityType: model.AuditEntitySkill, EntityID: skillID, After: json.RawMessage(`{"name":"Go"}`)})
+	record(model.AuditEntry{ActorEmail: "gone@example.com", Action: model.AuditActionUpdate, EntityType: model.AuditEntityProject, EntityID: newID()})
+	// API key requests record no email.
+	record(model.AuditEntry{ActorID: &owner.ID, Action: model.AuditActionDelete, EntityType: model.AuditEntitySkill, EntityID: skillID, IPAddress: "127.0.0.1"})
+
+	entries, total, err := s.ListAuditEntries(ctx, model.AuditLogFilter{Limit: 10})
+	ok(t, err)
+	if total != 3 || len(entries) != 3 {
+		t.Fatalf("got %d of %d entries, want 3", len(entries), total)
+	}
+	if got := auditActions(entries); !reflect.DeepEqual(got, []string{"delete", "update", "create"}) {
+		t.Fatalf("actions = %v, want newest first", got)
+	}
+	if entries[0].ActorEmail != owner.Email || entries[1].ActorEmail != "gone@example.com" {
+		t.Fatalf("actor emails = %q, %q, want the actor's address as a fallback", entries[0].ActorEmail, entries[1].ActorEmail)
+	}
+	if entries[0].ID == "" || entries[0].CreatedAt.IsZero() || entries[0].IPAddress != "127.0.0.1" {
+		t.Fatalf("entry = %+v, want an id, a time and the address", entries[0])
+	}
+	if !jsonEqual(t, entries[2].After, `{"name":"Go"}`) || entries[2].Before != nil {
+		t.Fatalf("snapshots = %s, %s", entries[2].Before, entries[2].After)
+	}
+
+	future := time.Now().Add(time.Hour)
+	for _, tc := range []struct {
+		name   string
+		filter model.AuditLogFilter
+		want   []string
+		total  int
+	}{
+		{"actor", model.AuditLogFilter{ActorID: owner.ID}, []string{"delete", "create"}, 2},
+		{"action", model.AuditLogFilter{Action: model.AuditActionUpdate}, []string{"update"}, 1},
+		{"entity", model.AuditLogFilter{EntityType: model.AuditEntitySkill, EntityID: skillID}, []string{"delete", "create"}, 2},
+		{"from", model.AuditLogFilter{From: &future}, []string{}, 0},
+		{"to", model.AuditLogFilter{To: &future}, []string{"delete", "update", "create"}, 3},
+		{"page", model.AuditLogFilter{Offset: 1, Limit: 1}, []string{"update"}, 3},
+		{"empty page", model.AuditLogFilter{Offset: 3, Limit: 10}, []string{}, 3},
+	} {
+		if tc.filter.Limit == 0 {
+			tc.filter.Limit = 10
+		}
+		entries, total, err := s.ListAuditEntries(ctx, tc.filter)
+		ok(t, err)
+		if got := auditActions(entries); !reflect.DeepEqual(got, tc.want) || total != tc.total {
+			t.Fatalf("%s: got %v of %d, want %v of %d", tc.name, got, total, tc.want, tc.total)
+		}
+	}
+}
+
+func auditActions(entries []model.AuditEntry) []string {
+	actions := []string{}
+	for _, entry := range entries {
+		actions = append(actions, entry.Action)
+	}
+	return actions
+}
+
+// === Revisions ===
+
+func testRevisions(t *testing.T, s repository.Store) {
+	owner := createOwner(t, s)
+	p, err := s.CreateProject(ctx, model.Project{Publication: published, Title: "First", Tags: []string{"go"}, Translations: model.Translations{"fr": {"title": "Premier"}}})
+	ok(t, err)
+
+	first := snapshot(t, s, model.AuditEntityProject, p.ID)
+	if _, found := first["translations"]; !found {
+		t.Fatalf("snapshot %v has no translations", first)
+	}
+	ok(t, s.CreateRevision(ctx, model.AuditEntityProject, p.ID, mustJSON(t, first), &owner.ID))
+
+	p.Title, p.Tags, p.Status = "Second", []string{"go", "sql"}, model.StatusDraft
+	p.Translations = model.Translations{"fr": {"title": "Deuxième"}}
+	p, err = s.UpdateProject(ctx, p)
+	ok(t, err)
+	second, err := s.SnapshotEntity(ctx, model.AuditEntityProject, p.ID)
+	ok(t, err)
+	ok(t, s.CreateRevision(ctx, model.AuditEntityProject, p.ID, second, nil))
+
+	revisions, err := s.GetRevisions(ctx, model.AuditEntityProject, p.ID)
+	ok(t, err)
+	if len(revisions) != 2 || revisions[0].Revision != 2 || revisions[1].Revision != 1 {
+		t.Fatalf("revisions = %+v, want 2 then 1", revisions)
+	}
+	if revisions[1].ActorEmail != owner.Email || revisions[0].ActorID != nil {
+		t.Fatalf("revision actors = %+v", revisions)
+	}
+	rev, err := s.GetRevision(ctx, model.AuditEntityProject, p.ID, 1)
+	ok(t, err)
+	if rev.ID != revisions[1].ID {
+		t.Fatalf("revision 1 = %+v, want %+v", rev, revisions[1])
+	}
+	_, err = s.GetRevision(ctx, model.AuditEntityProject, p.ID, 3)
+	wantErr(t, err, repository.ErrNotFound)
+
+	// Restoring brings back the content and translations, but not the status,
+	// and only onto the version the caller last read.
+	wantErr(t, s.RestoreSnapshot(ctx, model.AuditEntityProject, p.ID, p.Version-1, rev.Snapshot), repository.ErrVersionConflict)
+	ok(t, s.RestoreSnapshot(ctx, model.AuditEntityProject, p.ID, p.Version, rev.Snapshot))
+	restored, err := s.GetProjectByID(ctx, p.ID)
+	ok(t, err)
+	if restored.Title != "First" || !reflect.DeepEqual(restored.Tags, []string{"go"}) || restored.Translations.Text("fr", "title") != "Premier" {
+		t.Fatalf("restored project = %+v, want the first revision", restored)
+	}
+	if restored.Status != model.StatusDraft || restored.Version != p.Version+1 {
+		t.Fatalf("restored project = %+v, want a draft at version %d", restored, p.Version+1)
+	}
+
+	if err := s.RestoreSnapshot(ctx, model.AuditEntityTestimonial, p.ID, restored.Version, rev.Snapshot); err == nil {
+		t.Fatal("restored a testimonial, which has no revisions")
+	}
+	ok(t, s.DeleteProject(ctx, p.ID, restored.Version))
+	wantErr(t, s.RestoreSnapshot(ctx, model.AuditEntityProject, p.ID, restored.Version+1, rev.Snapshot), repository.ErrNotFound)
+
+	// Snapshots include trashed rows, and only translatable types carry
+	// translations.
+	snapshot(t, s, model.AuditEntityProject, p.ID)
+	skill, err := s.CreateSkill(ctx, model.Skill{Publication: published, Name: "Go"})
+	ok(t, err)
+	if doc := snapshot(t, s, model.AuditEntitySkill, skill.ID); doc["translations"] != nil {
+		t.Fatalf("skill snapshot %v has translations", doc)
+	}
+	for _, tc := range []struct{ entityType, id string }{
+		{model.AuditEntityProject, missingID},
+		{model.AuditEntityProject, ""},
+		{model.AuditEntityResume, skill.ID},
+	} {
+		if got, err := s.SnapshotEntity(ctx, tc.entityType, tc.id); err != nil || got != nil {
+			t.Fatalf("SnapshotEntity(%s, %q) = %s, %v, want nil", tc.entityType, tc.id, got, err)
+		}
+	}
+}
+
+// snapshot returns the snapshot of an entity, which must exist, decoded.
+func snapshot(t *testing.T, s repository.Store, entityType, id string) map[string]any {
+	t.Helper()
+	raw, err := s.SnapshotEntity(ctx, entityType, id)
+	ok(t, err)
+	if raw == nil {
+		t.Fatalf("no snapshot of %s %s", entityType, id)
+	}
+	var doc map[string]any
+	ok(t, json.Unmarshal(raw, &doc))
+	return doc
+}
+
+func mustJSON(t *testing.T, v any) json.RawMessage {
+	t.Helper()
+	encoded, err := json.Marshal(v)
+	ok(t, err)
+	return encoded
+}
+
+// jsonEqual reports whether raw holds the same JSON value as want, however
+// it is formatted.
+func jsonEqual(t *testing.T, raw json.RawMessage, want string) bool {
+	t.Helper()
+	var got, expected any
+	ok(t, json.Unmarshal(raw, &got))
+	ok(t, json.Unmarshal([]byte(want), &expected))
+	return reflect.DeepEqual(got, expected)
+}
@@ -0,0 +1,100 @@
+// Package repositorytest is the contract every repository.Store
+// implementation must meet. The tests of each implementation call Run with a
+// constructor for an empty store, so Postgres and the in-memory store are
+// held to the same behaviour.
+package repositorytest
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/portfolio/backend/internal/auth"
+	"github.com/portfolio/backend/internal/model"
+	"github.com/portfolio/backend/internal/repository"
+)
+
+// NewStore returns an empty store. Each subtest gets its own, so subtests
+// never see each other's rows; they run one after the other and may share a
+// database.
+type NewStore func(t *testing.T) repository.Store
+
+// Run runs the contract suite against the stores newStore returns.
+func Run(t *testing.T, newStore NewStore) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, s repository.Store)
+	}{
+		{"Versions", testVersions},
+		{"ConcurrentUpdates", testConcurrentUpdates},
+		{"Publication", testPublication},
+		{"Translations", testTranslations},
+		{"MachineTranslations", testMachineTranslations},
+		{"TranslationStatus", testTranslationStatus},
+		{"Trash", testTrash},
+		{"Testimonials", testTestimonials},
+		{"Messages", testMessages},
+		{"ContactInfo", testContactInfo},
+		{"Admins", testAdmins},
+		{"LastOwner", testLastOwner},
+		{"TwoFactor", testTwoFactor},
+		{"Sessions", testSessions},
+		{"RefreshTokenReuse", testRefreshTokenReuse},
+		{"WebAuthn", testWebAuthn},
+		{"APIKeys", testAPIKeys},
+		{"AuditLog", testAuditLog},
+		{"Revisions", testRevisions},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, newStore(t))
+		})
+	}
+}
+
+var ctx = context.Background()
+
+// missingID is a well-formed id that no row has.
+const missingID = "00000000-0000-4000-8000-000000000000"
+
+// published is the publication the handlers give content created without
+// one; stores expect a status on create.
+var published = model.Publication{Status: model.StatusPublished}
+
+func ok(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func wantErr(t *testing.T, err, want error) {
+	t.Helper()
+	if !errors.Is(err, want) {
+		t.Fatalf("got error %v, want %v", err, want)
+	}
+}
+
+// newID returns a random version 4 UUID for the ids callers choose, such as
+// session ids.
+func newID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func createAdmin(t *testing.T, s repository.Store, email, role string) model.Admin {
+	t.Helper()
+	a, err := s.CreateAdmin(ctx, model.Admin{Email: email, PasswordHash: "hash", Name: email, Role: role})
+	ok(t, err)
+	return a
+}
+
+func createOwner(t *testing.T, s repository.Store) model.Admin {
+	t.Helper()
+	return createAdmin(t, s, "owner@example.com", auth.RoleOwner)
+}
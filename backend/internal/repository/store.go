@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/portfolio/backend/internal/model"
+)
+
+// Store is every aggregate together, as implemented by postgres.Repository
+// and memory.Store.
+type Store interface {
+	ContentStore
+	AdminStore
+	TwoFactorStore
+	SessionStore
+	WebAuthnStore
+	APIKeyStore
+	TrashStore
+	PublicationStore
+	TranslationStore
+}
+
+// ContentStore is the portfolio content, along with the audit log and
+// revision history that record changes to it.
+type ContentStore interface {
+	SkillStore
+	ProjectStore
+	ExperienceStore
+	EducationStore
+	HobbyStore
+	TestimonialStore
+	MessageStore
+	ContactInfoStore
+	AuditStore
+	RevisionStore
+}
+
+// === Content ===
+// Public getters (GetSkills, GetProjects...) return only live content:
+// published rows, and scheduled rows whose time has come. GetAll* and Get*ByID
+// also return drafts. None of them return rows in the trash. Lists are in
+// sort order.
+//
+// Versioned updates and deletes only apply when the given version is still the
+// stored one; they return ErrNotFound when the row is gone or in the trash and
+// ErrVersionConflict when it has moved on. An update with an empty status
+// keeps the stored status and publish time. Translations passed to creates
+// and updates are merged into the stored ones, an empty value removing a
+// translation.
+
+type SkillStore interface {
+	GetSkills(ctx context.Context) ([]model.Skill, error)
+	GetAllSkills(ctx context.Context) ([]model.Skill, error)
+	GetSkillByID(ctx context.Context, id string) (model.Skill, error)
+	CreateSkill(ctx context.Context, s model.Skill) (model.Skill, error)
+	UpdateSkill(ctx context.Context, s model.Skill) (model.Skill, error)
+	DeleteSkill(ctx context.Context, id string, version int) error
+}
+
+type ProjectStore interface {
+	GetProjects(ctx context.Context) ([]model.Project, error)
+	GetAllProjects(ctx context.Context) ([]model.Project, error)
+	GetProjectByID(ctx context.Context, id string) (model.Project, error)
+	CreateProject(ctx context.Context, p model.Project) (model.Project, error)
+	UpdateProject(ctx context.Context, p model.Project) (model.Project, error)
+	DeleteProject(ctx context.Context, id string, version int) error
+}
+
+type ExperienceStore interface {
+	GetExperiences(ctx context.Context) ([]model.Experience, error)
+	GetAllExperiences(ctx context.Context) ([]model.Experience, error)
+	GetExperienceByID(ctx context.Context, id string) (model.Experience, error)
+	CreateExperience(ctx context.Context, e model.Experience) (model.Experience, error)
+	UpdateExperience(ctx context.Context, e model.Experience) (model.Experience, error)
+	DeleteExperience(ctx context.Context, id string, version int) error
+}
+
+type EducationStore interface {
+	GetEducation(ctx context.Context) ([]model.Education, error)
+	GetAllEducation(ctx context.Context) ([]model.Education, error)
+	GetEducationByID(ctx context.Context, id string) (model.Education, error)
+	CreateEducation(ctx context.Context, e model.Education) (model.Education, error)
+	UpdateEducation(ctx context.Context, e model.Education) (model.Education, error)
+	DeleteEducation(ctx context.Context, id string, version int) error
+}
+
+type HobbyStore interface {
+	GetHobbies(ctx context.Context) ([]model.Hobby, error)
+	GetAllHobbies(ctx context.Context) ([]model.Hobby, error)
+	GetHobbyByID(ctx context.Context, id string) (model.Hobby, error)
+	CreateHobby(ctx context.Context, h model.Hobby) (model.Hobby, error)
+	UpdateHobby(ctx context.Context, h model.Hobby) (model.Hobby, error)
+	DeleteHobby(ctx context.Context, id string, version int) error
+}
+
+// TestimonialStore lists testimonials newest first.
+type TestimonialStore interface {
+	GetApprovedTestimonials(ctx context.Context) ([]model.Testimonial, error)
+	GetAllTestimonials(ctx context.Context) ([]model.Testimonial, error)
+	CreateTestimonial(ctx context.Context, t model.Testimonial) (model.Testimonial, error)
+	UpdateTestimonialStatus(ctx context.Context, id, status string) error
+	DeleteTestimonial(ctx context.Context, id string) error
+}
+
+// MessageStore lists messages newest first.
+type MessageStore interface {
+	GetMessages(ctx context.Context) ([]model.Message, error)
+	CreateMessage(ctx context.Context, m model.Message) (model.Message, error)
+	// HasRecentDuplicateMessage reports whether email sent contentHash within
+	// the last within (24 hours when not positive), trashed messages included.
+	HasRecentDuplicateMessage(ctx context.Context, email, contentHash string, within time.Duration) (bool, error)
+	MarkMessageRead(ctx context.Context, id string) error
+	DeleteMessage(ctx context.Context, id string) error
+	// PurgeMessages permanently deletes messages received before cutoff, read
+	// or not and including those in the trash, and returns how many were removed.
+	PurgeMessages(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type ContactInfoStore interface {
+	// GetContactInfo returns an empty ContactInfo when none has been saved.
+	GetContactInfo(ctx context.Context) (model.ContactInfo, error)
+	// UpdateContactInfo creates or replaces the contact info if it is still at
+	// info.Version, 0 while none has been saved, and returns
+	// ErrVersionConflict otherwise.
+	UpdateContactInfo(ctx context.Context, info model.ContactInfo) (model.ContactInfo, error)
+}
+
+// === Admin accounts ===
+
+type AdminStore interface {
+	CountAdmins(ctx context.Context) (int, error)
+	// GetAdminByEmail matches the email case-insensitively.
+	GetAdminByEmail(ctx context.Context, email string) (model.Admin, error)
+	GetAdminByID(ctx context.Context, id string) (model.Admin, error)
+	// CreateAdmin lowercases the email and returns ErrAdminExists when it is taken.
+	CreateAdmin(ctx context.Context, a model.Admin) (model.Admin, error)
+	GetAdmins(ctx context.Context) ([]model.Admin, error)
+	// UpdateAdminUser and DeleteAdmin return ErrLastOwner rather than leave
+	// the site without an owner. DeleteAdmin also signs the admin out.
+	UpdateAdminUser(ctx context.Context, id, name, role string) (model.Admin, error)
+	DeleteAdmin(ctx context.Context, id string) error
+	UpdateAdminPassword(ctx context.Context, id, passwordHash string) error
+}
+
+type TwoFactorStore interface {
+	// SetPendingTOTPSecret stores a secret that is not enforced until
+	// EnableTOTP; it returns ErrNotFound when 2FA is already enabled.
+	SetPendingTOTPSecret(ctx context.Context, adminID, secret string) error
+	// EnableTOTP turns on 2FA and replaces the recovery codes with codeHashes.
+	EnableTOTP(ctx context.Context, adminID string, step int64, codeHashes []string) error
+	DisableTOTP(ctx context.Context, adminID string) error
+	// ConsumeTOTPStep records step as used, and returns false if that step
+	// or a later one was already used.
+	ConsumeTOTPStep(ctx context.Context, adminID string, step int64) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, adminID string, codeHashes []string) error
+	// UseRecoveryCode returns ErrNotFound when the code is unknown or spent.
+	UseRecoveryCode(ctx context.Context, adminID, codeHash string) error
+	CountUnusedRecoveryCodes(ctx context.Context, adminID string) (int, error)
+}
+
+// SessionStore keeps refresh tokens, grouped in sessions, and the denylist of
+// revoked access tokens.
+type SessionStore interface {
+	CreateRefreshToken(ctx context.Context, t model.RefreshToken) (model.RefreshToken, error)
+	// GetRefreshToken reports expired tokens as not found.
+	GetRefreshToken(ctx context.Context, tokenHash string) (model.RefreshToken, error)
+	// RotateRefreshToken retires oldID and stores next in the same session.
+	// If oldID was already retired it revokes the session and returns
+	// ErrRefreshTokenReused.
+	RotateRefreshToken(ctx context.Context, oldID string, next model.RefreshToken) (model.RefreshToken, error)
+	// RevokeSession retires the session's refresh tokens and denylists the
+	// access tokens minted with them that have not expired yet.
+	RevokeSession(ctx context.Context, sessionID string) error
+	// RevokeAdminSession returns ErrNotFound unless the session is adminID's.
+	RevokeAdminSession(ctx context.Context, adminID, sessionID string) error
+	RevokeAdminSessions(ctx context.Context, adminID string) error
+	// GetActiveSessions lists the sessions that still hold a usable refresh
+	// token, most recently used first.
+	GetActiveSessions(ctx context.Context, adminID string) ([]model.Session, error)
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	// PurgeExpiredTokens drops denylist entries, refresh tokens and WebAuthn
+	// challenges past their expiry.
+	PurgeExpiredTokens(ctx context.Context) error
+}
+
+type WebAuthnStore interface {
+	CreateWebAuthnChallenge(ctx context.Context, challenge model.WebAuthnChallenge) (string, error)
+	// ConsumeWebAuthnChallenge deletes and returns an unexpired challenge
+	// with the given purpose and admin, so it can only be answered once.
+	ConsumeWebAuthnChallenge(ctx context.Context, id, purpose, adminID string) (model.WebAuthnChallenge, error)
+	// CreateWebAuthnCredential returns ErrCredentialExists for a credential
+	// ID that is already registered.
+	CreateWebAuthnCredential(ctx context.Context, cred model.WebAuthnCredential) (model.WebAuthnCredential, error)
+	GetWebAuthnCredentialByCredentialID(ctx context.Context, credentialID string) (model.WebAuthnCredential, error)
+	GetWebAuthnCredentials(ctx context.Context, adminID string) ([]model.WebAuthnCredential, error)
+	// UpdateWebAuthnSignCount records a successful assertion; the counter
+	// never moves back.
+	UpdateWebAuthnSignCount(ctx context.Context, id string, signCount uint32) error
+	DeleteWebAuthnCredential(ctx context.Context, adminID, id string) error
+}
+
+// APIKeyStore returns keys with their creator's current role.
+type APIKeyStore interface {
+	CreateAPIKey(ctx context.Context, key model.APIKey) (model.APIKey, error)
+	GetAPIKeys(ctx context.Context) ([]model.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (model.APIKey, error)
+	TouchAPIKey(ctx context.Context, id string) error
+	DeleteAPIKey(ctx context.Context, id string) error
+}
+
+// === History ===
+
+type AuditStore interface {
+	// SnapshotEntity returns the stored entity as JSON, trashed or not, or
+	// nil when the entity type is not stored or the entity does not exist.
+	// Translatable entities carry their translations under "translations".
+	SnapshotEntity(ctx context.Context, entityType, id string) (json.RawMessage, error)
+	CreateAuditEntry(ctx context.Context, entry model.AuditEntry) error
+	// ListAuditEntries returns one page of entries, newest first, plus the
+	// number of entries matching the filter.
+	ListAuditEntries(ctx context.Context, filter model.AuditLogFilter) ([]model.AuditEntry, int, error)
+}
+
+// RevisionStore keeps the snapshots taken by SnapshotEntity before each
+// update, numbered from 1 per entity.
+type RevisionStore interface {
+	CreateRevision(ctx context.Context, entityType, entityID string, snapshot json.RawMessage, actorID *string) error
+	// GetRevisions lists an entity's revisions, newest first.
+	GetRevisions(ctx context.Context, entityType, entityID string) ([]model.Revision, error)
+	GetRevision(ctx context.Context, entityType, entityID string, revision int) (model.Revision, error)
+	// RestoreSnapshot writes the content and translations of snapshot back
+	// onto the entity if it is still at version, leaving its identity,
+	// timestamps and status alone. It returns ErrNotFound when the entity is
+	// gone or in the trash and ErrVersionConflict when it has changed.
+	RestoreSnapshot(ctx context.Context, entityType, entityID string, version int, snapshot json.RawMessage) error
+}
+
+type TrashStore interface {
+	// GetTrash lists trashed rows of the given entity types, most recently
+	// deleted first.
+	GetTrash(ctx context.Context, entityTypes []string) ([]model.TrashItem, error)
+	// RestoreFromTrash returns ErrNotFound when the row is not in the trash.
+	RestoreFromTrash(ctx context.Context, entityType, id string) error
+	// PurgeTrash permanently deletes rows trashed before cutoff, with their
+	// revisions and translations, and returns how many were removed.
+	PurgeTrash(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+type PublicationStore interface {
+	// PublishScheduled publishes scheduled rows whose time has passed by now
+	// and returns how many changed.
+	PublishScheduled(ctx context.Context, now time.Time) (int64, error)
+}
+
+type TranslationStore interface {
+	// AddMachineTranslations stores t as machine translations that need
+	// review, keeping fields that already have a translation, and moves the
+	// entity to a new version provided it is still at version.
+	AddMachineTranslations(ctx context.Context, entityType, id string, version int, t model.Translations) error
+	// MarkTranslationsReviewed records that the translations of fields in
+	// locale were checked against the current source text, so they are no
+	// longer stale or flagged for review. It returns ErrNotFound, changing
+	// nothing, when one of them does not exist.
+	MarkTranslationsReviewed(ctx context.Context, entityType, id, locale string, fields []string) error
+	// TranslationStatus reports, per locale, the translatable fields of
+	// live content that have text but no translation, and the
+	// translations whose source text changed after they were last updated.
+	// Drafts, rows scheduled for later and the trash are left out.
+	TranslationStatus(ctx context.Context, locales []string) (model.TranslationStatus, error)
+}
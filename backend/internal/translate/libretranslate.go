@@ -0,0 +1,72 @@
+package translate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// LibreTranslate calls a LibreTranslate-compatible HTTP endpoint, such as a
+// self-hosted instance.
+type LibreTranslate struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewLibreTranslate returns a Translator for the instance at baseURL. apiKey
+// may be empty for instances that do not require one.
+func NewLibreTranslate(baseURL, apiKey string, timeout time.Duration) *LibreTranslate {
+	return &LibreTranslate{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+func (t *LibreTranslate) Translate(ctx context.Context, texts []string, source, target string) ([]string, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"q":       texts,
+		"source":  source,
+		"target":  target,
+		"format":  "text",
+		"api_key": t.apiKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, t.baseURL+"/translate", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := t.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("translation request failed: %w", err)
+	}
+	defer response.Body.Close()
+
+	var payload struct {
+		TranslatedText []string `json:"translatedText"`
+		Error          string   `json:"error"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to decode translation response (status %d): %w", response.StatusCode, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("translation failed with status %d: %s", response.StatusCode, payload.Error)
+	}
+	if len(payload.TranslatedText) != len(texts) {
+		return nil, fmt.Errorf("translation returned %d texts for %d inputs", len(payload.TranslatedText), len(texts))
+	}
+	return payload.TranslatedText, nil
+}
@@ -0,0 +1,11 @@
+// Package translate holds the Translator interface used to pre-fill content
+// translations, and its implementations.
+package translate
+
+import "context"
+
+// Translator machine-translates text. Implementations return one translation
+// per input text, in the same order.
+type Translator interface {
+	Translate(ctx context.Context, texts []string, source, target string) ([]string, error)
+}
@@ -10,6 +10,8 @@ TRUNCATE TABLE
   messages
 RESTART IDENTITY CASCADE;
 
+DELETE FROM translations WHERE entity_type IN ('project', 'experience', 'education', 'hobby');
+
 -- Insert Skills
 -- Languages
 INSERT INTO skills (name, category, proficiency, sort_order, show_in_portfolio) VALUES
@@ -44,25 +46,68 @@ INSERT INTO skills (name, category, proficiency, sort_order, show_in_portfolio)
 ('Lombok', 'Libraries', 80, 3, true);
 
 -- Insert Projects
-INSERT INTO projects (title, title_fr, description, description_fr, tags, featured, sort_order) VALUES
-('Visual Impact', 'Visual Impact', 'Built a B2B platform connecting advertisers with media owners to manage and display ads on digital screens.\nRole: Developed and refined the UI for booking and advertisement pages, improving navigation and performance for both advertisers and media owners.', 'Création d''une plateforme B2B reliant les annonceurs et les propriétaires de médias pour gérer et afficher des publicités sur des écrans numériques.', '{"Spring Boot", "JavaScript", "CSS", "React", "JIRA", "Playwright"}', true, 1),
-('Champlain Pet Clinic', 'Clinique vétérinaire Champlain', 'Developed a microservice-based web application that allows users to create visits, purchase products, and submit reviews.\nRole: Designed and enhanced the Visit and Review pages to improve the user interface and overall experience.', 'Développement d''une application web en microservices permettant de créer des visites, acheter des produits et soumettre des avis.', '{"Spring Boot", "JavaScript", "HTML", "CSS", "React", "JIRA"}', true, 2),
-('Library Management', 'Gestion de bibliothèque', 'Collaborated on developing a microservice-based library system to manage user accounts, book inventory, and loan tracking.\nRole: Led the implementation of the author microservice.', 'Conception d''un système de bibliothèque en microservices pour gérer les comptes, l''inventaire et les prêts.', '{"Spring Boot", "Docker", "Java"}', false, 3);
+INSERT INTO projects (title, description, tags, featured, sort_order) VALUES
+('Visual Impact', 'Built a B2B platform connecting advertisers with media owners to manage and display ads on digital screens.\nRole: Developed and refined the UI for booking and advertisement pages, improving navigation and performance for both advertisers and media owners.', '{"Spring Boot", "JavaScript", "CSS", "React", "JIRA", "Playwright"}', true, 1),
+('Champlain Pet Clinic', 'Developed a microservice-based web application that allows users to create visits, purchase products, and submit reviews.\nRole: Designed and enhanced the Visit and Review pages to improve the user interface and overall experience.', '{"Spring Boot", "JavaScript", "HTML", "CSS", "React", "JIRA"}', true, 2),
+('Library Management', 'Collaborated on developing a microservice-based library system to manage user accounts, book inventory, and loan tracking.\nRole: Led the implementation of the author microservice.', '{"Spring Boot", "Docker", "Java"}', false, 3);
+
+INSERT INTO translations (entity_type, entity_id, locale, field, value)
+SELECT 'project', t.id, 'fr', v.field, v.value
+FROM (VALUES
+  ('Visual Impact', 'title', to_jsonb('Visual Impact'::text)),
+  ('Visual Impact', 'description', to_jsonb('Création d''une plateforme B2B reliant les annonceurs et les propriétaires de médias pour gérer et afficher des publicités sur des écrans numériques.'::text)),
+  ('Champlain Pet Clinic', 'title', to_jsonb('Clinique vétérinaire Champlain'::text)),
+  ('Champlain Pet Clinic', 'description', to_jsonb('Développement d''une application web en microservices permettant de créer des visites, acheter des produits et soumettre des avis.'::text)),
+  ('Library Management', 'title', to_jsonb('Gestion de bibliothèque'::text)),
+  ('Library Management', 'description', to_jsonb('Conception d''un système de bibliothèque en microservices pour gérer les comptes, l''inventaire et les prêts.'::text))
+) AS v(title, field, value)
+JOIN projects t ON t.title = v.title;
 
 -- Insert Experience
 INSERT INTO experiences (
-  title, title_fr, company, company_fr, location, location_fr, start_date, end_date, is_current, description, description_fr, sort_order
+  title, company, location, start_date, end_date, is_current, description, sort_order
 ) VALUES
-('Computer Science Tutor', 'Tuteur en informatique', 'Champlain College', 'Collège Champlain', 'Saint-Lambert, QC', 'Saint-Lambert, QC', '2025-08-01', '2025-11-01', false, '{"Mentored students in computer science concepts.", "Collaborated in a fast-paced and high-pressure environment."}', '{"Encadrement des étudiants en informatique.", "Collaboration efficace dans un environnement dynamique."}', 1),
-('Food Service Worker', 'Employé en service alimentaire', 'McDonald', 'McDonald', 'Brossard, QC', 'Brossard, QC', '2021-08-01', '2023-08-01', false, '{"Mentored employees in the kitchen.", "Organized workflow to maintain speed and quality."}', '{"Supervision des employés en cuisine.", "Organisation des opérations pour assurer le bon fonctionnement."}', 2),
-('Mathematics Tutor', 'Tuteur en mathématiques', 'Kumon', 'Kumon', 'Longueuil, QC', 'Longueuil, QC', '2022-08-01', '2023-06-01', false, '{"Guided learners through exercises and encouraged strong study habits.", "Supported student progress and confidence in math."}', '{"Aide aux élèves dans la compréhension des notions de mathématiques.", "Accompagnement dans la résolution d''exercices."}', 3);
+('Computer Science Tutor', 'Champlain College', 'Saint-Lambert, QC', '2025-08-01', '2025-11-01', false, '{"Mentored students in computer science concepts.", "Collaborated in a fast-paced and high-pressure environment."}', 1),
+('Food Service Worker', 'McDonald', 'Brossard, QC', '2021-08-01', '2023-08-01', false, '{"Mentored employees in the kitchen.", "Organized workflow to maintain speed and quality."}', 2),
+('Mathematics Tutor', 'Kumon', 'Longueuil, QC', '2022-08-01', '2023-06-01', false, '{"Guided learners through exercises and encouraged strong study habits.", "Supported student progress and confidence in math."}', 3);
+
+INSERT INTO translations (entity_type, entity_id, locale, field, value)
+SELECT 'experience', t.id, 'fr', v.field, v.value
+FROM (VALUES
+  ('Computer Science Tutor', 'title', to_jsonb('Tuteur en informatique'::text)),
+  ('Computer Science Tutor', 'company', to_jsonb('Collège Champlain'::text)),
+  ('Computer Science Tutor', 'location', to_jsonb('Saint-Lambert, QC'::text)),
+  ('Computer Science Tutor', 'description', to_jsonb('{"Encadrement des étudiants en informatique.", "Collaboration efficace dans un environnement dynamique."}'::text[])),
+  ('Food Service Worker', 'title', to_jsonb('Employé en service alimentaire'::text)),
+  ('Food Service Worker', 'company', to_jsonb('McDonald'::text)),
+  ('Food Service Worker', 'location', to_jsonb('Brossard, QC'::text)),
+  ('Food Service Worker', 'description', to_jsonb('{"Supervision des employés en cuisine.", "Organisation des opérations pour assurer le bon fonctionnement."}'::text[])),
+  ('Mathematics Tutor', 'title', to_jsonb('Tuteur en mathématiques'::text)),
+  ('Mathematics Tutor', 'company', to_jsonb('Kumon'::text)),
+  ('Mathematics Tutor', 'location', to_jsonb('Longueuil, QC'::text)),
+  ('Mathematics Tutor', 'description', to_jsonb('{"Aide aux élèves dans la compréhension des notions de mathématiques.", "Accompagnement dans la résolution d''exercices."}'::text[]))
+) AS v(title, field, value)
+JOIN experiences t ON t.title = v.title;
 
 -- Insert Education
 INSERT INTO education (
-  degree, degree_fr, school, school_fr, location, location_fr, start_date, end_date, description, description_fr, sort_order
+  degree, school, location, start_date, end_date, description, sort_order
 ) VALUES
-('Diploma of College Studies (DEC) in Computer Science', 'Diplôme d''études collégiales (DEC) en informatique', 'Champlain College Saint-Lambert', 'Collège Champlain Saint-Lambert', 'Saint-Lambert, QC', 'Saint-Lambert, QC', '2023-08-01', '2026-06-01', 'Expected graduation June 2026', 'Prévision de diplomation en juin 2026', 1),
-('Secondary School Diploma', 'Diplôme d''études secondaires', 'Centennial Regional High School', 'École secondaire Centennial Regional', 'Greenfield Park, QC', 'Greenfield Park, QC', '2018-09-01', '2023-06-01', '', '', 2);
+('Diploma of College Studies (DEC) in Computer Science', 'Champlain College Saint-Lambert', 'Saint-Lambert, QC', '2023-08-01', '2026-06-01', 'Expected graduation June 2026', 1),
+('Secondary School Diploma', 'Centennial Regional High School', 'Greenfield Park, QC', '2018-09-01', '2023-06-01', '', 2);
+
+INSERT INTO translations (entity_type, entity_id, locale, field, value)
+SELECT 'education', t.id, 'fr', v.field, v.value
+FROM (VALUES
+  ('Diploma of College Studies (DEC) in Computer Science', 'degree', to_jsonb('Diplôme d''études collégiales (DEC) en informatique'::text)),
+  ('Diploma of College Studies (DEC) in Computer Science', 'school', to_jsonb('Collège Champlain Saint-Lambert'::text)),
+  ('Diploma of College Studies (DEC) in Computer Science', 'location', to_jsonb('Saint-Lambert, QC'::text)),
+  ('Diploma of College Studies (DEC) in Computer Science', 'description', to_jsonb('Prévision de diplomation en juin 2026'::text)),
+  ('Secondary School Diploma', 'degree', to_jsonb('Diplôme d''études secondaires'::text)),
+  ('Secondary School Diploma', 'school', to_jsonb('École secondaire Centennial Regional'::text)),
+  ('Secondary School Diploma', 'location', to_jsonb('Greenfield Park, QC'::text))
+) AS v(degree, field, value)
+JOIN education t ON t.degree = v.degree;
 
 -- Insert Hobbies
 INSERT INTO hobbies (name, icon, description, sort_order) VALUES
@@ -70,13 +115,25 @@ INSERT INTO hobbies (name, icon, description, sort_order) VALUES
 ('Chess', 'chess', 'Strategy and tactical thinking practice.', 2),
 ('Coding Side Projects', 'laptop', 'Building and experimenting with new ideas.', 3);
 
+INSERT INTO translations (entity_type, entity_id, locale, field, value)
+SELECT 'hobby', t.id, 'fr', v.field, v.value
+FROM (VALUES
+  ('Gym', 'name', to_jsonb('Musculation'::text)),
+  ('Gym', 'description', to_jsonb('Entraînement en force et routines de mise en forme.'::text)),
+  ('Chess', 'name', to_jsonb('Échecs'::text)),
+  ('Chess', 'description', to_jsonb('Pratique de la réflexion stratégique et tactique.'::text)),
+  ('Coding Side Projects', 'name', to_jsonb('Projets personnels de programmation'::text)),
+  ('Coding Side Projects', 'description', to_jsonb('Créer et expérimenter de nouvelles idées.'::text))
+) AS v(name, field, value)
+JOIN hobbies t ON t.name = v.name;
+
 -- Insert approved Testimonials
 INSERT INTO testimonials (author_name, author_role, author_email, content, rating, status) VALUES
 ('Sarah Jenkins', 'Product Manager', 'sarah.j@techstart.com', 'Working with this developer was an absolute pleasure. High-quality delivery and clear communication.', 5, 'approved'),
 ('Michael Chen', 'CTO', 'michael@innovate.io', 'Exceptional technical skills and maintainable code quality.', 5, 'approved');
 
 -- Ensure contact info has one usable row
-INSERT INTO contact_info (email, phone, location, linkedin, github, website, bio, bio_fr, about_title, about_title_fr)
+INSERT INTO contact_info (email, phone, location, linkedin, github, website, bio, about_title)
 VALUES (
   'clay@portfolio.com',
   '',
@@ -85,10 +142,17 @@ VALUES (
   'https://github.com/',
   'https://claytkc.dev',
   'I am a passionate software developer specializing in building modern web applications. With expertise in Go, React, and PostgreSQL, I enjoy solving complex problems and crafting robust, clean software that provides exceptional user experiences.',
-  'Je suis un développeur logiciel passionné spécialisé dans la création d''applications web modernes. Fort d''une expertise en Go, React et PostgreSQL, j''aime résoudre des problèmes complexes et concevoir des logiciels robustes et propres offrant d''exceptionnelles expériences utilisateur.',
-  'Crafting elegant solutions through code',
-  'Concevoir des solutions élégantes par le code'
+  'Crafting elegant solutions through code'
 )
 ON CONFLICT DO NOTHING;
 
+INSERT INTO translations (entity_type, entity_id, locale, field, value)
+SELECT 'contact_info', c.id, 'fr', v.field, to_jsonb(v.value)
+FROM contact_info c, (VALUES
+  ('bio', 'Je suis un développeur logiciel passionné spécialisé dans la création d''applications web modernes. Fort d''une expertise en Go, React et PostgreSQL, j''aime résoudre des problèmes complexes et concevoir des logiciels robustes et propres offrant d''exceptionnelles expériences utilisateur.'),
+  ('aboutTitle', 'Concevoir des solutions élégantes par le code'),
+  ('location', 'Montréal, QC')
+) AS v(field, value)
+ON CONFLICT DO NOTHING;
+
 COMMIT;
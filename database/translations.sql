@@ -1,62 +1,51 @@
 -- Update script for French translations
 
 -- Projects
-UPDATE projects
-SET 
-  title_fr = 'Visual Impact',
-  description_fr = 'Création d''une plateforme B2B reliant les annonceurs et les propriétaires de médias pour gérer et afficher des publicités sur des écrans numériques.\nRôle: travaillé sur la page de visite et amélioré l''interface de la page d''avis.\nTechnologies: Spring Boot, JavaScript, CSS, React, JIRA (méthodes Scrum)'
-WHERE title = 'Visual Impact';
-
-UPDATE projects
-SET 
-  title_fr = 'Champlain Pet Clinic',
-  description_fr = 'Développement d''une application web basée sur une architecture de microservices permettant aux utilisateurs de créer des visites, d''acheter des produits et de soumettre des avis.\nRôle: travaillé sur la page de visite et amélioré l''interface de la page d''avis.\nTechnologies: Spring Boot, JavaScript, HTML, CSS, React, JIRA (méthodes Scrum)'
-WHERE title = 'Champlain Pet Clinic';
-
-UPDATE projects
-SET 
-  title_fr = 'LibraryManagement',
-  description_fr = 'Conçu un microservice en groupe pour gérer les opérations d''une bibliothèque, incluant l''inventaire des livres, les comptes utilisateurs et le suivi des prêts\nRôle: responsable du microservice Auteur\nTechnologies: Spring Boot, Docker, Java'
-WHERE title = 'Library Management';
+INSERT INTO translations (entity_type, entity_id, locale, field, value)
+SELECT 'project', t.id, 'fr', v.field, v.value
+FROM (VALUES
+  ('Visual Impact', 'title', to_jsonb('Visual Impact'::text)),
+  ('Visual Impact', 'description', to_jsonb('Création d''une plateforme B2B reliant les annonceurs et les propriétaires de médias pour gérer et afficher des publicités sur des écrans numériques.\nRôle: travaillé sur la page de visite et amélioré l''interface de la page d''avis.\nTechnologies: Spring Boot, JavaScript, CSS, React, JIRA (méthodes Scrum)'::text)),
+  ('Champlain Pet Clinic', 'title', to_jsonb('Champlain Pet Clinic'::text)),
+  ('Champlain Pet Clinic', 'description', to_jsonb('Développement d''une application web basée sur une architecture de microservices permettant aux utilisateurs de créer des visites, d''acheter des produits et de soumettre des avis.\nRôle: travaillé sur la page de visite et amélioré l''interface de la page d''avis.\nTechnologies: Spring Boot, JavaScript, HTML, CSS, React, JIRA (méthodes Scrum)'::text)),
+  ('Library Management', 'title', to_jsonb('LibraryManagement'::text)),
+  ('Library Management', 'description', to_jsonb('Conçu un microservice en groupe pour gérer les opérations d''une bibliothèque, incluant l''inventaire des livres, les comptes utilisateurs et le suivi des prêts\nRôle: responsable du microservice Auteur\nTechnologies: Spring Boot, Docker, Java'::text))
+) AS v(title, field, value)
+JOIN projects t ON t.title = v.title
+ON CONFLICT (entity_type, entity_id, locale, field) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW();
 
 -- Experience
-UPDATE experiences
-SET 
-  title_fr = 'Tuteur en informatique',
-  company_fr = 'Collège Champlain',
-  location_fr = 'Saint-Lambert, QC',
-  description_fr = '{"Encadrait les étudiants dans leurs travaux et leurs projets .", "Appris à collaborer efficacement dans un environnement dynamique et sous pression"}'
-WHERE title = 'Computer Science Tutor';
-
-UPDATE experiences
-SET 
-  title_fr = 'Employé en service alimentaire',
-  company_fr = 'McDonald',
-  location_fr = 'Brossard, QC',
-  description_fr = '{"Supervisé les employés en cuisine.", "Organisé le personnel de cuisine pour assurer le bon fonctionnement."}'
-WHERE title = 'Food Service Worker';
-
-UPDATE experiences
-SET 
-  title_fr = 'Tuteur en mathématiques',
-  company_fr = 'Kumon',
-  location_fr = 'Longueuil, QC',
-  description_fr = '{"Aidait les élèves à comprendre et pratiquer les notions de mathématiques", "Accompagnait les étudiants dans la résolution d''exercices et le développement de bonnes méthodes de travail"}'
-WHERE title = 'Mathematics Tutor';
+INSERT INTO translations (entity_type, entity_id, locale, field, value)
+SELECT 'experience', t.id, 'fr', v.field, v.value
+FROM (VALUES
+  ('Computer Science Tutor', 'title', to_jsonb('Tuteur en informatique'::text)),
+  ('Computer Science Tutor', 'company', to_jsonb('Collège Champlain'::text)),
+  ('Computer Science Tutor', 'location', to_jsonb('Saint-Lambert, QC'::text)),
+  ('Computer Science Tutor', 'description', to_jsonb('{"Encadrait les étudiants dans leurs travaux et leurs projets .", "Appris à collaborer efficacement dans un environnement dynamique et sous pression"}'::text[])),
+  ('Food Service Worker', 'title', to_jsonb('Employé en service alimentaire'::text)),
+  ('Food Service Worker', 'company', to_jsonb('McDonald'::text)),
+  ('Food Service Worker', 'location', to_jsonb('Brossard, QC'::text)),
+  ('Food Service Worker', 'description', to_jsonb('{"Supervisé les employés en cuisine.", "Organisé le personnel de cuisine pour assurer le bon fonctionnement."}'::text[])),
+  ('Mathematics Tutor', 'title', to_jsonb('Tuteur en mathématiques'::text)),
+  ('Mathematics Tutor', 'company', to_jsonb('Kumon'::text)),
+  ('Mathematics Tutor', 'location', to_jsonb('Longueuil, QC'::text)),
+  ('Mathematics Tutor', 'description', to_jsonb('{"Aidait les élèves à comprendre et pratiquer les notions de mathématiques", "Accompagnait les étudiants dans la résolution d''exercices et le développement de bonnes méthodes de travail"}'::text[]))
+) AS v(title, field, value)
+JOIN experiences t ON t.title = v.title
+ON CONFLICT (entity_type, entity_id, locale, field) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW();
 
 -- Education
-UPDATE education
-SET 
-  degree_fr = 'Diplôme d''études collégiales (DEC) en informatique',
-  school_fr = 'Collège Champlain Saint-Lambert',
-  location_fr = 'Saint-Lambert, QC',
-  description_fr = 'août 2023 – juin 2026, prévu'
-WHERE degree = 'Diploma of College Studies (DEC) in Computer Science';
-
-UPDATE education
-SET 
-  degree_fr = 'Diplôme d''études secondaires',
-  school_fr = 'Centennial Regional High School',
-  location_fr = 'Greenfield Park, QC',
-  description_fr = 'sept. 2018 – juin 2023'
-WHERE degree = 'Secondary School Diploma';
+INSERT INTO translations (entity_type, entity_id, locale, field, value)
+SELECT 'education', t.id, 'fr', v.field, v.value
+FROM (VALUES
+  ('Diploma of College Studies (DEC) in Computer Science', 'degree', to_jsonb('Diplôme d''études collégiales (DEC) en informatique'::text)),
+  ('Diploma of College Studies (DEC) in Computer Science', 'school', to_jsonb('Collège Champlain Saint-Lambert'::text)),
+  ('Diploma of College Studies (DEC) in Computer Science', 'location', to_jsonb('Saint-Lambert, QC'::text)),
+  ('Diploma of College Studies (DEC) in Computer Science', 'description', to_jsonb('août 2023 – juin 2026, prévu'::text)),
+  ('Secondary School Diploma', 'degree', to_jsonb('Diplôme d''études secondaires'::text)),
+  ('Secondary School Diploma', 'school', to_jsonb('Centennial Regional High School'::text)),
+  ('Secondary School Diploma', 'location', to_jsonb('Greenfield Park, QC'::text)),
+  ('Secondary School Diploma', 'description', to_jsonb('sept. 2018 – juin 2023'::text))
+) AS v(degree, field, value)
+JOIN education t ON t.degree = v.degree
+ON CONFLICT (entity_type, entity_id, locale, field) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW();
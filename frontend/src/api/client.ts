@@ -1,4 +1,5 @@
 import axios from 'axios';
+import i18n from '../i18n';
 import { eventBus } from '../utils/eventBus';
 import { getApiBaseUrl } from './url';
 
@@ -10,6 +11,13 @@ const client = axios.create({
     },
 });
 
+// A shared preview link (?preview=<token>) shows drafts on the public site;
+// remember the token for the rest of the tab's session.
+const previewFromUrl = new URLSearchParams(window.location.search).get('preview');
+if (previewFromUrl) {
+    sessionStorage.setItem('preview_token', previewFromUrl);
+}
+
 // Add a request interceptor to attach the JWT token if available
 client.interceptors.request.use(
     (config) => {
@@ -17,6 +25,12 @@ client.interceptors.request.use(
         if (token) {
             config.headers.Authorization = `Bearer ${token}`;
         }
+        const previewToken = sessionStorage.getItem('preview_token');
+        if (previewToken && config.url?.startsWith('/public/')) {
+            config.headers['X-Preview-Token'] = previewToken;
+        }
+        // Error messages come back in the language the visitor is reading
+        config.headers['Accept-Language'] = i18n.language;
         return config;
     },
     (error) => {
@@ -24,14 +38,51 @@ client.interceptors.request.use(
     }
 );
 
+// Share one in-flight refresh between concurrent 401s; refresh tokens are single-use.
+let refreshPromise: Promise<string | null> | null = null;
+
+const refreshAccessToken = (): Promise<string | null> => {
+    const refreshToken = localStorage.getItem('admin_refresh_token');
+    if (!refreshToken) {
+        return Promise.resolve(null);
+    }
+
+    if (!refreshPromise) {
+        refreshPromise = axios
+            .post(`${getApiBaseUrl()}/admin/refresh`, { refreshToken })
+            .then((response) => {
+                localStorage.setItem('admin_token', response.data.token);
+                localStorage.setItem('admin_refresh_token', response.data.refreshToken);
+                return response.data.token as string;
+            })
+            .catch(() => null)
+            .finally(() => {
+                refreshPromise = null;
+            });
+    }
+    return refreshPromise;
+};
+
 // Add a response interceptor to handle common errors
 client.interceptors.response.use(
     (response) => response,
-    (error) => {
+    async (error) => {
         if (error.response) {
-            // Handle 401 Unauthorized - clear token
+            const originalRequest = error.config;
+
+            // Handle 401 Unauthorized - try a refresh once, then clear token
+            if (error.response.status === 401 && originalRequest && !originalRequest._retry && !originalRequest.url?.includes('/admin/login')) {
+                originalRequest._retry = true;
+                const token = await refreshAccessToken();
+                if (token) {
+                    originalRequest.headers.Authorization = `Bearer ${token}`;
+                    return client(originalRequest);
+                }
+            }
+
             if (error.response.status === 401) {
                 localStorage.removeItem('admin_token');
+                localStorage.removeItem('admin_refresh_token');
                 // Optional: Redirect to login if not already there
                 eventBus.emit('auth:session-expired');
             }
@@ -40,4 +91,26 @@ client.interceptors.response.use(
     }
 );
 
+// Errors are RFC 7807 problem documents (application/problem+json). Prefer the
+// first field message, then the detail, then the caller's fallback. label turns
+// a field name into what the user sees on the form.
+export const problemMessage = (
+    error: unknown,
+    fallback: string,
+    label: (field: string) => string = (field) => field,
+): string => {
+    if (!axios.isAxiosError(error)) {
+        return fallback;
+    }
+    const problem = error.response?.data;
+    const field = problem?.errors?.[0];
+    if (field && typeof field.message === 'string') {
+        return `${label(field.field)}: ${field.message}`;
+    }
+    if (typeof problem?.detail === 'string') {
+        return problem.detail;
+    }
+    return fallback;
+};
+
 export default client;
@@ -80,7 +80,7 @@ export const AddProjectModal: React.FC<AddProjectModalProps> = ({ isOpen, onClos
             };
 
             if (project && project.id) {
-                await contentService.updateProject(project.id, projectData);
+                await contentService.updateProject(project.id, projectData, project.version);
                 setStatusModal({
                     isOpen: true,
                     type: 'success',
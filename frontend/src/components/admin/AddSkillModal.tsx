@@ -60,7 +60,7 @@ export const AddSkillModal: React.FC<AddSkillModalProps> = ({ isOpen, onClose, s
         setIsLoading(true);
         try {
             if (skill && skill.id) {
-                await contentService.updateSkill(skill.id, { ...formData, sortOrder: skill.sortOrder || 0 });
+                await contentService.updateSkill(skill.id, { ...formData, sortOrder: skill.sortOrder || 0 }, skill.version);
                 setStatusModal({
                     isOpen: true,
                     type: 'success',
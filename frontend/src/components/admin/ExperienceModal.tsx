@@ -67,6 +67,7 @@ export const ExperienceModal: React.FC<ExperienceModalProps> = ({
 
         onSave({
             ...data,
+            endDate: data.current ? '' : data.endDate, // a current role has no end date
             description: descriptionArray,
             descriptionFr: descriptionFrArray,
             sortOrder: Number(data.sortOrder) || 0
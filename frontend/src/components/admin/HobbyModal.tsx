@@ -24,14 +24,18 @@ export const HobbyModal: React.FC<HobbyModalProps> = ({
     useEffect(() => {
         if (initialData) {
             setValue('name', initialData.name);
+            setValue('nameFr', initialData.nameFr || '');
             setValue('icon', initialData.icon);
             setValue('description', initialData.description);
+            setValue('descriptionFr', initialData.descriptionFr || '');
             setValue('sortOrder', initialData.sortOrder);
         } else {
             reset({
                 name: '',
+                nameFr: '',
                 icon: '',
                 description: '',
+                descriptionFr: '',
                 sortOrder: 0
             });
         }
@@ -62,6 +66,17 @@ export const HobbyModal: React.FC<HobbyModalProps> = ({
                     />
                 </div>
 
+                <div>
+                    <label className="block text-sm font-medium text-[var(--color-text-muted)] mb-1">
+                        Name (French)
+                    </label>
+                    <input
+                        {...register('nameFr')}
+                        className="w-full px-4 py-2 rounded-lg bg-[var(--color-surface)] border border-[var(--glass-border)] text-[var(--color-text)] focus:outline-none focus:border-[var(--color-primary)]"
+                        placeholder="ex. Photographie"
+                    />
+                </div>
+
                 <div>
                     <label className="block text-sm font-medium text-[var(--color-text-muted)] mb-1">
                         Icon (Emoji)
@@ -84,6 +99,17 @@ export const HobbyModal: React.FC<HobbyModalProps> = ({
                     />
                 </div>
 
+                <div>
+                    <label className="block text-sm font-medium text-[var(--color-text-muted)] mb-1">
+                        Description (French)
+                    </label>
+                    <textarea
+                        {...register('descriptionFr')}
+                        className="w-full px-4 py-2 rounded-lg bg-[var(--color-surface)] border border-[var(--glass-border)] text-[var(--color-text)] focus:outline-none focus:border-[var(--color-primary)] min-h-[100px]"
+                        placeholder="Brève description du loisir..."
+                    />
+                </div>
+
                 <div>
                     <label className="block text-sm font-medium text-[var(--color-text-muted)] mb-1">
                         Sort Order
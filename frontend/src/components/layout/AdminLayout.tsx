@@ -22,14 +22,31 @@ export const AdminLayout: React.FC = () => {
         };
     }, []);
 
-    const handleLogout = () => {
-        authService.logout();
+    const handleLogout = async () => {
+        await authService.logout();
         navigate('/');
     };
 
+    const [previewMessage, setPreviewMessage] = useState<{ type: 'success' | 'error'; text: string } | null>(null);
+
+    const handleSharePreview = async () => {
+        try {
+            const { token, expiresAt } = await contentService.createPreviewToken();
+            const link = `${window.location.origin}/?preview=${encodeURIComponent(token)}`;
+            await navigator.clipboard?.writeText(link).catch(() => undefined);
+            setPreviewMessage({
+                type: 'success',
+                text: `Preview link (drafts included, valid until ${new Date(expiresAt).toLocaleString()}) copied to clipboard: ${link}`,
+            });
+        } catch (error) {
+            console.error('Failed to create preview link:', error);
+            setPreviewMessage({ type: 'error', text: 'Failed to create preview link' });
+        }
+    };
+
     const handleSessionExpiredClose = () => {
         setIsSessionExpired(false);
-        handleLogout();
+        void handleLogout();
     };
 
     const [counts, setCounts] = useState({
@@ -46,11 +63,11 @@ export const AdminLayout: React.FC = () => {
         const fetchCounts = async () => {
             try {
                 const [skills, projects, experience, education, hobbies, testimonials, messages] = await Promise.all([
-                    contentService.getSkills(),
-                    contentService.getProjects(),
-                    contentService.getExperiences(),
-                    contentService.getEducation(),
-                    contentService.getHobbies(),
+                    contentService.getAdminSkills(),
+                    contentService.getAdminProjects(),
+                    contentService.getAdminExperiences(),
+                    contentService.getAdminEducation(),
+                    contentService.getAdminHobbies(),
                     contentService.getPendingTestimonials(),
                     contentService.getRecentMessages()
                 ]);
@@ -102,6 +119,13 @@ export const AdminLayout: React.FC = () => {
                 title="Session Expired"
                 message="Your session has expired. Please log in again to continue."
             />
+            <StatusModal
+                isOpen={previewMessage !== null}
+                onClose={() => setPreviewMessage(null)}
+                type={previewMessage?.type ?? 'success'}
+                title="Preview Link"
+                message={previewMessage?.text ?? ''}
+            />
             <div className="container mx-auto px-6">
                 <div className="pt-8 pb-8">
                     <h1 className="section-title">{t('admin.dashboard')}</h1>
@@ -157,6 +181,13 @@ export const AdminLayout: React.FC = () => {
                                     <span>🌐</span>
                                     <span>View Portfolio</span>
                                 </Link>
+                                <button
+                                    onClick={handleSharePreview}
+                                    className="w-full flex items-center gap-3 px-4 py-3 rounded-lg text-[var(--color-text-muted)] hover:text-[var(--color-text)] hover:bg-[var(--color-surface)] transition-colors"
+                                >
+                                    <span>🔗</span>
+                                    <span>Share Preview Link</span>
+                                </button>
                                 <button
                                     onClick={handleLogout}
                                     className="w-full flex items-center gap-3 px-4 py-3 rounded-lg text-[var(--color-text-muted)] hover:text-red-500 hover:bg-red-500/10 transition-colors"
@@ -20,12 +20,12 @@ export const About: React.FC = () => {
             .finally(() => {
                 setLoading(false);
             });
-    }, []);
+    }, [i18n.language]);
 
-    const bioText = info ? (isFr ? info.bioFr : info.bio) : '';
+    const bioText = info ? info.bio : '';
     const displayBio = bioText && bioText.trim() ? bioText : t('hero.subtitle');
 
-    const titleText = info ? (isFr ? info.aboutTitleFr : info.aboutTitle) : '';
+    const titleText = info ? info.aboutTitle : '';
     const displayTitle = titleText && titleText.trim() ? titleText : (isFr ? 'Concevoir des solutions élégantes par le code' : 'Crafting elegant solutions through code');
 
     if (loading) {
@@ -8,7 +8,6 @@ export const Education: React.FC = () => {
     const { t, i18n } = useTranslation();
     const [education, setEducation] = useState<EducationType[]>([]);
     const [loading, setLoading] = useState(true);
-    const isFrench = i18n.language === 'fr';
 
     useEffect(() => {
         const fetchEducation = async () => {
@@ -23,7 +22,7 @@ export const Education: React.FC = () => {
         };
 
         fetchEducation();
-    }, []);
+    }, [i18n.language]);
 
     if (loading) {
         return (
@@ -60,17 +59,17 @@ export const Education: React.FC = () => {
                                 </div>
                                 <div>
                                     <h3 className="text-lg font-semibold mb-1">
-                                        {(isFrench && edu.degreeFr) ? edu.degreeFr : edu.degree}
+                                        {edu.degree}
                                     </h3>
                                     <p className="text-[var(--color-primary)] font-medium">
-                                        {(isFrench && edu.schoolFr) ? edu.schoolFr : edu.school}
+                                        {edu.school}
                                     </p>
                                     <p className="text-sm text-[var(--color-text-muted)] mb-2">
-                                        {(isFrench && edu.locationFr) ? edu.locationFr : edu.location} • {new Date(edu.startDate).getFullYear()} -
+                                        {edu.location} • {new Date(edu.startDate).getFullYear()} -
                                         {edu.endDate ? new Date(edu.endDate).getFullYear() : 'Present'}
                                     </p>
                                     <p className="text-sm text-[var(--color-text-muted)]">
-                                        {(isFrench && edu.descriptionFr) ? edu.descriptionFr : edu.description}
+                                        {edu.description}
                                     </p>
                                 </div>
                             </div>
@@ -6,7 +6,6 @@ export const Experience: React.FC = () => {
     const { t, i18n } = useTranslation();
     const [experiences, setExperiences] = useState<ExperienceType[]>([]);
     const [loading, setLoading] = useState(true);
-    const isFrench = i18n.language === 'fr';
 
     useEffect(() => {
         const fetchExperiences = async () => {
@@ -21,7 +20,7 @@ export const Experience: React.FC = () => {
         };
 
         fetchExperiences();
-    }, []);
+    }, [i18n.language]);
 
     if (loading) {
         return (
@@ -56,15 +55,15 @@ export const Experience: React.FC = () => {
                                 <div className="flex flex-col md:flex-row md:items-center md:justify-between gap-2 mb-4">
                                     <div>
                                         <h3 className="text-xl font-semibold text-[var(--color-text)]">
-                                            {(isFrench && exp.titleFr) ? exp.titleFr : exp.title}
+                                            {exp.title}
                                         </h3>
                                         <p className="text-[var(--color-primary)] font-medium">
-                                            {(isFrench && exp.companyFr) ? exp.companyFr : exp.company}
+                                            {exp.company}
                                         </p>
                                     </div>
                                     <div className="text-right">
                                         <p className="text-[var(--color-text-muted)] text-sm">
-                                            {(isFrench && exp.locationFr) ? exp.locationFr : exp.location}
+                                            {exp.location}
                                         </p>
                                         <p className="text-[var(--color-secondary)] font-medium text-sm">
                                             {new Date(exp.startDate).getFullYear()} -
@@ -74,7 +73,7 @@ export const Experience: React.FC = () => {
                                 </div>
 
                                 <ul className="space-y-2">
-                                    {((isFrench && exp.descriptionFr && exp.descriptionFr.length > 0) ? exp.descriptionFr : exp.description).map((item, i) => (
+                                    {(exp.description || []).map((item, i) => (
                                         <li
                                             key={i}
                                             className="flex items-start gap-3 text-[var(--color-text-muted)]"
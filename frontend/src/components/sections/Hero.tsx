@@ -17,8 +17,6 @@ export const Hero: React.FC = () => {
         [i18n.language]
     );
 
-    const isFr = i18n.language?.startsWith('fr');
-
     React.useEffect(() => {
         // Check if profile picture exists
         fetch(profilePictureUrl)
@@ -33,11 +31,11 @@ export const Hero: React.FC = () => {
     React.useEffect(() => {
         contentService.getContactInfo()
             .then(info => {
-                const text = isFr ? info.bioFr : info.bio;
+                const text = info.bio;
                 if (text && text.trim()) setBio(text.trim());
             })
             .catch(() => { /* fall back to i18n key */ });
-    }, [isFr]);
+    }, [i18n.language]);
 
     const scrollToProjects = () => {
         document.getElementById('projects')?.scrollIntoView({ behavior: 'smooth' });
@@ -4,7 +4,7 @@ import { Card } from '../ui/Card';
 import { contentService, type Hobby } from '../../services/content.service';
 
 export const Hobbies: React.FC = () => {
-    const { t } = useTranslation();
+    const { t, i18n } = useTranslation();
     const [hobbies, setHobbies] = useState<Hobby[]>([]);
     const [loading, setLoading] = useState(true);
 
@@ -21,7 +21,7 @@ export const Hobbies: React.FC = () => {
         };
 
         fetchHobbies();
-    }, []);
+    }, [i18n.language]);
 
     if (loading) {
         return (
@@ -14,7 +14,6 @@ export const Projects: React.FC = () => {
     const [projects, setProjects] = useState<Project[]>([]);
     const [loading, setLoading] = useState(true);
     const [error, setError] = useState<string | null>(null);
-    const isFrench = i18n.language === 'fr';
 
     useEffect(() => {
         const fetchProjects = async () => {
@@ -32,7 +31,7 @@ export const Projects: React.FC = () => {
         };
 
         fetchProjects();
-    }, []);
+    }, [i18n.language]);
 
     if (loading) {
         return (
@@ -89,10 +88,10 @@ export const Projects: React.FC = () => {
                             {/* Content */}
                             <div className="flex-1">
                                 <h3 className="text-xl font-semibold mb-2">
-                                    {(isFrench && project.titleFr) ? project.titleFr : project.title}
+                                    {project.title}
                                 </h3>
                                 <p className="text-[var(--color-text-muted)] text-sm mb-4">
-                                    {(isFrench && project.descriptionFr) ? project.descriptionFr : project.description}
+                                    {project.description}
                                 </p>
 
                                 {/* Tags */}
@@ -2,7 +2,8 @@ import React, { useState } from 'react';
 import { useTranslation } from 'react-i18next';
 import { Link, useNavigate } from 'react-router-dom';
 import { Button } from '../components/ui/Button';
-import { authService } from '../services/auth.service';
+import { problemMessage } from '../api/client';
+import { authService, type LoginResponse } from '../services/auth.service';
 
 export const AdminLogin: React.FC = () => {
     const { t } = useTranslation();
@@ -13,6 +14,18 @@ export const AdminLogin: React.FC = () => {
     });
     const [isLoading, setIsLoading] = useState(false);
     const [error, setError] = useState<string | null>(null);
+    const [challengeToken, setChallengeToken] = useState<string | null>(null);
+    const [code, setCode] = useState('');
+
+    const completeLogin = (response: LoginResponse) => {
+        // Store token and user info
+        localStorage.setItem('admin_token', response.token);
+        localStorage.setItem('admin_refresh_token', response.refreshToken);
+        localStorage.setItem('admin_user', JSON.stringify(response.admin));
+
+        // Redirect to dashboard
+        navigate('/admin/dashboard');
+    };
 
     const handleSubmit = async (e: React.FormEvent) => {
         e.preventDefault();
@@ -20,17 +33,23 @@ export const AdminLogin: React.FC = () => {
         setError(null);
 
         try {
-            const response = await authService.login(formData);
+            if (challengeToken) {
+                completeLogin(await authService.verifyTwoFactor(challengeToken, code));
+                return;
+            }
 
-            // Store token and user info
-            localStorage.setItem('admin_token', response.token);
-            localStorage.setItem('admin_user', JSON.stringify(response.admin));
-
-            // Redirect to dashboard
-            navigate('/admin/dashboard');
+            const response = await authService.login(formData);
+            if ('twoFactorRequired' in response) {
+                setChallengeToken(response.challengeToken);
+                return;
+            }
+            completeLogin(response);
         } catch (err: any) {
             console.error('Login failed:', err);
-            setError(err.response?.data?.error || 'Failed to login. Please check your credentials.');
+            setError(problemMessage(err, 'Failed to login. Please check your credentials.'));
+            if (challengeToken) {
+                setCode('');
+            }
         } finally {
             setIsLoading(false);
         }
@@ -65,39 +84,65 @@ export const AdminLogin: React.FC = () => {
 
                         {/* Form */}
                         <form onSubmit={handleSubmit} className="space-y-4">
-                            <div>
-                                <label htmlFor="email" className="block text-sm font-medium mb-2">
-                                    {t('admin.email')}
-                                </label>
-                                <input
-                                    type="email"
-                                    id="email"
-                                    name="email"
-                                    value={formData.email}
-                                    onChange={handleChange}
-                                    required
-                                    className="form-input"
-                                    placeholder="admin@example.com"
-                                    disabled={isLoading}
-                                />
-                            </div>
+                            {challengeToken ? (
+                                <div>
+                                    <label htmlFor="code" className="block text-sm font-medium mb-2">
+                                        {t('admin.verificationCode')}
+                                    </label>
+                                    <input
+                                        type="text"
+                                        id="code"
+                                        name="code"
+                                        value={code}
+                                        onChange={(e) => setCode(e.target.value)}
+                                        required
+                                        autoComplete="one-time-code"
+                                        autoFocus
+                                        className="form-input"
+                                        placeholder="123456"
+                                        disabled={isLoading}
+                                    />
+                                    <p className="text-xs text-[var(--color-text-muted)] mt-2">
+                                        {t('admin.verificationCodeHint')}
+                                    </p>
+                                </div>
+                            ) : (
+                                <>
+                                <div>
+                                    <label htmlFor="email" className="block text-sm font-medium mb-2">
+                                        {t('admin.email')}
+                                    </label>
+                                    <input
+                                        type="email"
+                                        id="email"
+                                        name="email"
+                                        value={formData.email}
+                                        onChange={handleChange}
+                                        required
+                                        className="form-input"
+                                        placeholder="admin@example.com"
+                                        disabled={isLoading}
+                                    />
+                                </div>
 
-                            <div>
-                                <label htmlFor="password" className="block text-sm font-medium mb-2">
-                                    {t('admin.password')}
-                                </label>
-                                <input
-                                    type="password"
-                                    id="password"
-                                    name="password"
-                                    value={formData.password}
-                                    onChange={handleChange}
-                                    required
-                                    className="form-input"
-                                    placeholder="••••••••"
-                                    disabled={isLoading}
-                                />
-                            </div>
+                                <div>
+                                    <label htmlFor="password" className="block text-sm font-medium mb-2">
+                                        {t('admin.password')}
+                                    </label>
+                                    <input
+                                        type="password"
+                                        id="password"
+                                        name="password"
+                                        value={formData.password}
+                                        onChange={handleChange}
+                                        required
+                                        className="form-input"
+                                        placeholder="••••••••"
+                                        disabled={isLoading}
+                                    />
+                                </div>
+                                </>
+                            )}
 
                             <Button
                                 type="submit"
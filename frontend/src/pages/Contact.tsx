@@ -1,6 +1,6 @@
 import React, { useState } from 'react';
 import { useTranslation } from 'react-i18next';
-import axios from 'axios';
+import { problemMessage } from '../api/client';
 import { Button } from '../components/ui/Button';
 import { contentService } from '../services/content.service';
 
@@ -23,8 +23,15 @@ declare global {
     }
 }
 
+// Maps API field names to the labels shown on the form.
+const contactFieldLabels: Record<string, string> = {
+    name: 'contact.name',
+    email: 'contact.email',
+    content: 'contact.message',
+};
+
 export const ContactPage: React.FC = () => {
-    const { t } = useTranslation();
+    const { t, i18n } = useTranslation();
     const turnstileSiteKey = (import.meta.env.VITE_TURNSTILE_SITE_KEY as string | undefined)?.trim() || '';
     const turnstileContainerRef = React.useRef<HTMLDivElement | null>(null);
     const turnstileWidgetIdRef = React.useRef<string | null>(null);
@@ -72,7 +79,7 @@ export const ContactPage: React.FC = () => {
             }
         };
         fetchInfo();
-    }, []);
+    },[i18n.language]);
 
     React.useEffect(() => {
         if (!turnstileSiteKey) {
@@ -170,11 +177,7 @@ export const ContactPage: React.FC = () => {
             }
         } catch (error) {
             console.error('Failed to submit message:', error);
-            if (axios.isAxiosError(error) && typeof error.response?.data?.error === 'string') {
-                setSubmitError(error.response.data.error);
-            } else {
-                setSubmitError('Failed to send message. Please try again.');
-            }
+            setSubmitError(problemMessage(error, t('contact.error'), (field) => t(contactFieldLabels[field] ?? field)));
         } finally {
             setIsSubmitting(false);
         }
@@ -14,6 +14,7 @@ export const ManageContactInfo: React.FC = () => {
         email: '',
         phone: '',
         location: '',
+        locationFr: '',
         linkedin: '',
         github: '',
         twitter: '',
@@ -42,7 +43,7 @@ export const ManageContactInfo: React.FC = () => {
 
     const fetchContactInfo = async () => {
         try {
-            const data = await contentService.getContactInfo();
+            const data = await contentService.getAdminContactInfo();
             setInfo(data);
         } catch (error) {
             console.error('Failed to fetch contact info:', error);
@@ -65,7 +66,7 @@ export const ManageContactInfo: React.FC = () => {
         e.preventDefault();
         setSaving(true);
         try {
-            await contentService.updateContactInfo(info);
+            setInfo(await contentService.updateContactInfo(info, info.version));
             setStatusModal({
                 isOpen: true,
                 type: 'success',
@@ -129,6 +130,16 @@ export const ManageContactInfo: React.FC = () => {
                                 className="w-full px-4 py-2 rounded-lg bg-[var(--color-background)] border border-[var(--glass-border)] text-[var(--color-text)] focus:border-[var(--color-primary)] outline-none transition-colors"
                             />
                         </div>
+                        <div className="space-y-2">
+                            <label className="text-sm font-medium text-[var(--color-text-muted)]">Location (French)</label>
+                            <input
+                                type="text"
+                                name="locationFr"
+                                value={info.locationFr || ''}
+                                onChange={handleChange}
+                                className="w-full px-4 py-2 rounded-lg bg-[var(--color-background)] border border-[var(--glass-border)] text-[var(--color-text)] focus:border-[var(--color-primary)] outline-none transition-colors"
+                            />
+                        </div>
                     </div>
 
                     <div className="border-t border-[var(--glass-border)] my-6"></div>
@@ -20,7 +20,7 @@ export const ManageEducation: React.FC = () => {
 
     const fetchEducation = React.useCallback(async () => {
         try {
-            const data = await contentService.getEducation();
+            const data = await contentService.getAdminEducation();
             setEducation(data);
         } catch (error) {
             console.error('Failed to fetch education:', error);
@@ -44,7 +44,7 @@ export const ManageEducation: React.FC = () => {
         setIsLoading(true);
         try {
             if (editingEducation?.id) {
-                await contentService.updateEducation(editingEducation.id, edu);
+                await contentService.updateEducation(editingEducation.id, edu, editingEducation.version);
                 showStatus('success', 'Education updated successfully');
             } else {
                 await contentService.createEducation(edu);
@@ -70,7 +70,7 @@ export const ManageEducation: React.FC = () => {
         if (!deleteId) return;
 
         try {
-            await contentService.deleteEducation(deleteId);
+            await contentService.deleteEducation(deleteId, education.find(e => e.id === deleteId)?.version);
             showStatus('success', 'Education deleted successfully');
             fetchEducation();
         } catch (error) {
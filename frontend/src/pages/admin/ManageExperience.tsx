@@ -20,7 +20,7 @@ export const ManageExperience: React.FC = () => {
 
     const fetchExperiences = React.useCallback(async () => {
         try {
-            const data = await contentService.getExperiences();
+            const data = await contentService.getAdminExperiences();
             setExperiences(data);
         } catch (error) {
             console.error('Failed to fetch experiences:', error);
@@ -44,7 +44,7 @@ export const ManageExperience: React.FC = () => {
         setIsLoading(true);
         try {
             if (editingExperience?.id) {
-                await contentService.updateExperience(editingExperience.id, exp);
+                await contentService.updateExperience(editingExperience.id, exp, editingExperience.version);
                 showStatus('success', 'Experience updated successfully');
             } else {
                 await contentService.createExperience(exp);
@@ -70,7 +70,7 @@ export const ManageExperience: React.FC = () => {
         if (!deleteId) return;
 
         try {
-            await contentService.deleteExperience(deleteId);
+            await contentService.deleteExperience(deleteId, experiences.find(e => e.id === deleteId)?.version);
             showStatus('success', 'Experience deleted successfully');
             fetchExperiences();
         } catch (error) {
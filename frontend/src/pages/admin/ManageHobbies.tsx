@@ -20,7 +20,7 @@ export const ManageHobbies: React.FC = () => {
 
     const fetchHobbies = React.useCallback(async () => {
         try {
-            const data = await contentService.getHobbies();
+            const data = await contentService.getAdminHobbies();
             setHobbies(data);
         } catch (error) {
             console.error('Failed to fetch hobbies:', error);
@@ -44,7 +44,7 @@ export const ManageHobbies: React.FC = () => {
         setIsLoading(true);
         try {
             if (editingHobby?.id) {
-                await contentService.updateHobby(editingHobby.id, hobby);
+                await contentService.updateHobby(editingHobby.id, hobby, editingHobby.version);
                 showStatus('success', 'Hobby updated successfully');
             } else {
                 await contentService.createHobby(hobby);
@@ -70,7 +70,7 @@ export const ManageHobbies: React.FC = () => {
         if (!deleteId) return;
 
         try {
-            await contentService.deleteHobby(deleteId);
+            await contentService.deleteHobby(deleteId, hobbies.find(h => h.id === deleteId)?.version);
             showStatus('success', 'Hobby deleted successfully');
             fetchHobbies();
         } catch (error) {
@@ -27,7 +27,7 @@ export const ManageProjects: React.FC = () => {
 
     const fetchProjects = async () => {
         try {
-            const data = await contentService.getProjects();
+            const data = await contentService.getAdminProjects();
             setProjects(data);
         } catch (error) {
             console.error('Failed to fetch projects:', error);
@@ -53,7 +53,7 @@ export const ManageProjects: React.FC = () => {
         if (!confirmModal.id) return;
 
         try {
-            await contentService.deleteProject(confirmModal.id);
+            await contentService.deleteProject(confirmModal.id, projects.find(p => p.id === confirmModal.id)?.version);
             setProjects(prev => prev.filter(p => p.id !== confirmModal.id));
             setStatusModal({
                 isOpen: true,
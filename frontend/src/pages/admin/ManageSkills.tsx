@@ -40,7 +40,7 @@ export const ManageSkills: React.FC = () => {
 
     const fetchSkills = async () => {
         try {
-            const data = await contentService.getSkills();
+            const data = await contentService.getAdminSkills();
             setSkills(data);
         } catch (error) {
             console.error('Failed to fetch skills:', error);
@@ -66,7 +66,7 @@ export const ManageSkills: React.FC = () => {
         if (!confirmModal.id) return;
 
         try {
-            await contentService.deleteSkill(confirmModal.id);
+            await contentService.deleteSkill(confirmModal.id, skills.find(s => s.id === confirmModal.id)?.version);
             setSkills(prev => prev.filter(s => s.id !== confirmModal.id));
             setStatusModal({
                 isOpen: true,
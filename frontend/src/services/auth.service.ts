@@ -5,23 +5,43 @@ export interface LoginRequest {
     password: string;
 }
 
+export interface TwoFactorChallenge {
+    twoFactorRequired: true;
+    challengeToken: string;
+    expiresIn: number;
+}
+
 export interface LoginResponse {
     token: string;
+    refreshToken: string;
+    expiresIn: number;
     admin: {
         id: string;
         email: string;
         name: string;
+        role: 'owner' | 'editor' | 'moderator' | 'viewer';
     };
 }
 
 export const authService = {
-    async login(credentials: LoginRequest): Promise<LoginResponse> {
-        const response = await client.post<LoginResponse>('/admin/login', credentials);
+    async login(credentials: LoginRequest): Promise<LoginResponse | TwoFactorChallenge> {
+        const response = await client.post<LoginResponse | TwoFactorChallenge>('/admin/login', credentials);
+        return response.data;
+    },
+
+    async verifyTwoFactor(challengeToken: string, code: string): Promise<LoginResponse> {
+        const response = await client.post<LoginResponse>('/admin/login/2fa', { challengeToken, code });
         return response.data;
     },
 
-    logout() {
+    async logout(): Promise<void> {
+        // Revoke the session server-side before dropping the tokens the request
+        // authenticates with; local state is cleared regardless of the outcome.
+        if (localStorage.getItem('admin_token')) {
+            await client.post('/admin/logout').catch(() => undefined);
+        }
         localStorage.removeItem('admin_token');
+        localStorage.removeItem('admin_refresh_token');
         localStorage.removeItem('admin_user');
     },
 
@@ -1,6 +1,18 @@
 import client from '../api/client';
 
-export interface Project {
+export type PublicationStatus = 'draft' | 'published' | 'scheduled';
+
+export interface Publication {
+    status?: PublicationStatus;
+    publishAt?: string | null;
+    version?: number; // Row version, sent back as If-Match on updates and deletes
+}
+
+// Admin updates and deletes must name the version they were based on; the API
+// answers 412 when someone else saved the item in between.
+const ifMatch = (version?: number) => ({ headers: { 'If-Match': `"${version ?? 0}"` } });
+
+export interface Project extends Publication {
     id?: string;
     title: string;
     titleFr?: string; // French Title
@@ -14,7 +26,7 @@ export interface Project {
     sortOrder?: number;
 }
 
-export interface Skill {
+export interface Skill extends Publication {
     id?: string;
     name: string;
     icon?: string;
@@ -24,7 +36,7 @@ export interface Skill {
     showInPortfolio?: boolean; // New field
 }
 
-export interface Experience {
+export interface Experience extends Publication {
     id?: string;
     title: string;
     titleFr?: string;
@@ -33,14 +45,14 @@ export interface Experience {
     location: string;
     locationFr?: string;
     startDate: string;
-    endDate?: string;
+    endDate?: string | null; // YYYY-MM-DD or YYYY-MM, null when ongoing
     current: boolean;
     description: string[];
     descriptionFr?: string[];
     sortOrder?: number;
 }
 
-export interface Education {
+export interface Education extends Publication {
     id?: string;
     degree: string;
     degreeFr?: string;
@@ -49,17 +61,19 @@ export interface Education {
     location: string;
     locationFr?: string;
     startDate: string;
-    endDate?: string;
+    endDate?: string | null; // YYYY-MM-DD or YYYY-MM, null when ongoing
     description: string;
     descriptionFr?: string;
     sortOrder?: number;
 }
 
-export interface Hobby {
+export interface Hobby extends Publication {
     id?: string;
     name: string;
+    nameFr?: string;
     icon: string;
     description: string;
+    descriptionFr?: string;
     sortOrder?: number;
 }
 
@@ -103,6 +117,7 @@ export interface ContactInfo {
     email: string;
     phone: string;
     location: string;
+    locationFr?: string;
     linkedin: string;
     github: string;
     twitter: string;
@@ -111,6 +126,7 @@ export interface ContactInfo {
     bioFr: string;
     aboutTitle?: string;
     aboutTitleFr?: string;
+    version?: number; // Row version, sent back as If-Match on updates
 }
 
 export const contentService = {
@@ -120,8 +136,14 @@ export const contentService = {
         return response.data;
     },
 
-    async updateContactInfo(info: Partial<ContactInfo>): Promise<ContactInfo> {
-        const response = await client.put<ContactInfo>('/admin/contact-info', info);
+    // Both languages, for editing
+    async getAdminContactInfo(): Promise<ContactInfo> {
+        const response = await client.get<ContactInfo>('/admin/contact-info');
+        return response.data;
+    },
+
+    async updateContactInfo(info: Partial<ContactInfo>, version?: number): Promise<ContactInfo> {
+        const response = await client.put<ContactInfo>('/admin/contact-info', info, ifMatch(version));
         return response.data;
     },
 
@@ -154,13 +176,13 @@ export const contentService = {
         return response.data;
     },
 
-    async updateProject(id: string, project: Project): Promise<Project> {
-        const response = await client.put<Project>(`/admin/projects/${id}`, project);
+    async updateProject(id: string, project: Project, version?: number): Promise<Project> {
+        const response = await client.put<Project>(`/admin/projects/${id}`, project, ifMatch(version));
         return response.data;
     },
 
-    async deleteProject(id: string): Promise<void> {
-        await client.delete(`/admin/projects/${id}`);
+    async deleteProject(id: string, version?: number): Promise<void> {
+        await client.delete(`/admin/projects/${id}`, ifMatch(version));
     },
 
     // Skills
@@ -169,13 +191,13 @@ export const contentService = {
         return response.data;
     },
 
-    async updateSkill(id: string, skill: Skill): Promise<Skill> {
-        const response = await client.put<Skill>(`/admin/skills/${id}`, skill);
+    async updateSkill(id: string, skill: Skill, version?: number): Promise<Skill> {
+        const response = await client.put<Skill>(`/admin/skills/${id}`, skill, ifMatch(version));
         return response.data;
     },
 
-    async deleteSkill(id: string): Promise<void> {
-        await client.delete(`/admin/skills/${id}`);
+    async deleteSkill(id: string, version?: number): Promise<void> {
+        await client.delete(`/admin/skills/${id}`, ifMatch(version));
     },
 
     // Public Data Fetching
@@ -204,19 +226,51 @@ export const contentService = {
         return response.data || [];
     },
 
+    // Admin Data Fetching (includes drafts and scheduled items)
+    async getAdminProjects(): Promise<Project[]> {
+        const response = await client.get<Project[]>('/admin/projects');
+        return response.data || [];
+    },
+
+    async getAdminSkills(): Promise<Skill[]> {
+        const response = await client.get<Skill[]>('/admin/skills');
+        return response.data || [];
+    },
+
+    async getAdminExperiences(): Promise<Experience[]> {
+        const response = await client.get<Experience[]>('/admin/experience');
+        return response.data || [];
+    },
+
+    async getAdminEducation(): Promise<Education[]> {
+        const response = await client.get<Education[]>('/admin/education');
+        return response.data || [];
+    },
+
+    async getAdminHobbies(): Promise<Hobby[]> {
+        const response = await client.get<Hobby[]>('/admin/hobbies');
+        return response.data || [];
+    },
+
+    // Preview links let a reviewer see drafts without admin credentials
+    async createPreviewToken(expiresInHours?: number): Promise<{ token: string; expiresAt: string }> {
+        const response = await client.post<{ token: string; expiresAt: string }>('/admin/preview-token', expiresInHours ? { expiresInHours } : undefined);
+        return response.data;
+    },
+
     // Experience
     async createExperience(experience: Experience): Promise<Experience> {
         const response = await client.post<Experience>('/admin/experience', experience);
         return response.data;
     },
 
-    async updateExperience(id: string, experience: Experience): Promise<Experience> {
-        const response = await client.put<Experience>(`/admin/experience/${id}`, experience);
+    async updateExperience(id: string, experience: Experience, version?: number): Promise<Experience> {
+        const response = await client.put<Experience>(`/admin/experience/${id}`, experience, ifMatch(version));
         return response.data;
     },
 
-    async deleteExperience(id: string): Promise<void> {
-        await client.delete(`/admin/experience/${id}`);
+    async deleteExperience(id: string, version?: number): Promise<void> {
+        await client.delete(`/admin/experience/${id}`, ifMatch(version));
     },
 
     // Education
@@ -225,13 +279,13 @@ export const contentService = {
         return response.data;
     },
 
-    async updateEducation(id: string, education: Education): Promise<Education> {
-        const response = await client.put<Education>(`/admin/education/${id}`, education);
+    async updateEducation(id: string, education: Education, version?: number): Promise<Education> {
+        const response = await client.put<Education>(`/admin/education/${id}`, education, ifMatch(version));
         return response.data;
     },
 
-    async deleteEducation(id: string): Promise<void> {
-        await client.delete(`/admin/education/${id}`);
+    async deleteEducation(id: string, version?: number): Promise<void> {
+        await client.delete(`/admin/education/${id}`, ifMatch(version));
     },
 
     // Hobbies
@@ -240,13 +294,13 @@ export const contentService = {
         return response.data;
     },
 
-    async updateHobby(id: string, hobby: Hobby): Promise<Hobby> {
-        const response = await client.put<Hobby>(`/admin/hobbies/${id}`, hobby);
+    async updateHobby(id: string, hobby: Hobby, version?: number): Promise<Hobby> {
+        const response = await client.put<Hobby>(`/admin/hobbies/${id}`, hobby, ifMatch(version));
         return response.data;
     },
 
-    async deleteHobby(id: string): Promise<void> {
-        await client.delete(`/admin/hobbies/${id}`);
+    async deleteHobby(id: string, version?: number): Promise<void> {
+        await client.delete(`/admin/hobbies/${id}`, ifMatch(version));
     },
 
     // Testimonials & Messages (Mocked for now)